@@ -5,7 +5,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -13,28 +14,41 @@ import (
 	"time"
 
 	_ "github.com/lib/pq"
+	"google.golang.org/grpc"
+	_ "time/tzdata" // EMAIL_DIGEST_TZ работает и в образе без zoneinfo
 
 	"avi_internship_autumn/internal/app"
 	"avi_internship_autumn/internal/config"
+	migrations "avi_internship_autumn/internal/db"
+	apigrpc "avi_internship_autumn/internal/grpc"
 	apihttp "avi_internship_autumn/internal/http"
+	"avi_internship_autumn/internal/logging"
+	"avi_internship_autumn/internal/metrics"
 	"avi_internship_autumn/internal/service"
+	"avi_internship_autumn/internal/tracing"
 )
 
 func main() {
 	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("failed to load config: %v", err)
+		fatal("failed to load config", err)
 	}
+
+	logger, err := logging.New(os.Stderr, logging.Settings{Format: cfg.Log.Format, Level: cfg.Log.Level})
+	if err != nil {
+		fatal("failed to set up logging", err)
+	}
+	slog.SetDefault(logger)
 	dsn := cfg.DB.DSNString()
 
 	db, err := sql.Open("postgres", dsn)
 	if err != nil {
-		log.Fatalf("failed to open database: %v", err)
+		fatal("failed to open database", err)
 	}
 	defer func(db *sql.DB) {
 		err := db.Close()
 		if err != nil {
-			log.Fatalf("failed to close database: %v", err)
+			fatal("failed to close database", err)
 		}
 	}(db)
 
@@ -45,18 +59,125 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	if err := db.PingContext(ctx); err != nil {
 		cancel()
-		log.Fatalf("failed to ping database: %v", err)
+		fatal("failed to ping database", err)
 	}
 	cancel()
 
-	repos := app.NewRepositories(db)
+	tracing.InitPropagation()
+	if cfg.Tracing.Enabled() {
+		shutdownTracing, err := tracing.Setup(context.Background(), tracing.Settings{
+			ServiceName: cfg.Tracing.ServiceName,
+			SampleRatio: cfg.Tracing.SampleRatio,
+		})
+		if err != nil {
+			fatal("failed to set up tracing", err)
+		}
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdownTracing(ctx); err != nil {
+				slog.Error("tracing shutdown failed", "error", err)
+			}
+		}()
+	}
+
+	pgRepos := app.NewRepositories(db)
+	// скрейп метрик ходит в БД в обход трейсинга, чтобы не плодить корневые спаны
+	m := metrics.New(db, pgRepos.PRs)
+	repos := tracing.InstrumentRepositories(pgRepos)
+
+	webhookSvc := tracing.InstrumentWebhookService(service.NewWebhookService(repos.Teams, repos.Webhooks))
+	chatNotifier := service.NewChatNotifier(repos.Teams, nil, cfg.Chat.Timeout)
+	bus := app.NewEventBus(cfg.Stream.HistorySize)
+	events := app.MultiPublisher{webhookSvc, chatNotifier, bus}
+
+	var emailNotifier *service.EmailNotifier
+	if cfg.SMTP.Enabled() {
+		emailNotifier = service.NewEmailNotifier(repos.Users, repos.PRs, service.SMTPSettings{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+			From:     cfg.SMTP.From,
+			Timeout:  cfg.SMTP.Timeout,
+		})
+		events = append(events, emailNotifier)
+	}
+
+	teamSvc := tracing.InstrumentTeamService(service.NewTeamService(repos.Teams, repos.Users))
+	userSvc := m.InstrumentUserService(tracing.InstrumentUserService(service.NewUserService(repos.Users, repos.PRs, events)))
+	prSvc := m.InstrumentPRService(tracing.InstrumentPRService(service.NewPRService(repos.PRs, repos.Users, events)))
+	integrationSvc := tracing.InstrumentIntegrationService(service.NewIntegrationService(repos.Identities, repos.Users, prSvc))
+	tokenSvc := service.NewTokenService(repos.Tokens, repos.Users, cfg.Auth.AdminToken)
+	if cfg.Auth.JWTEnabled() {
+		tokenSvc, err = service.NewJWTTokenService(tokenSvc, service.JWTSettings{
+			HMACSecret: cfg.Auth.JWTHMACSecret,
+			JWKSFile:   cfg.Auth.JWTJWKSFile,
+			Issuer:     cfg.Auth.JWTIssuer,
+			Audience:   cfg.Auth.JWTAudience,
+		})
+		if err != nil {
+			fatal("failed to set up JWT authentication", err)
+		}
+	}
+	tokenSvc = tracing.InstrumentTokenService(tokenSvc)
+	idempotencySvc := tracing.InstrumentIdempotencyService(
+		service.NewIdempotencyService(repos.Idempotency, cfg.Idempotency.TTL, cfg.Idempotency.LockTimeout))
+	if cfg.Auth.AdminToken == "" {
+		slog.Warn("AUTH_ADMIN_TOKEN is empty: only tokens issued earlier are accepted")
+	}
+
+	handler := apihttp.NewRouter(
+		teamSvc,
+		userSvc,
+		prSvc,
+		webhookSvc,
+		integrationSvc,
+		tokenSvc,
+		idempotencySvc,
+		apihttp.IntegrationSecrets{
+			GitHubWebhookSecret: cfg.Integrations.GitHubWebhookSecret,
+			GitLabWebhookToken:  cfg.Integrations.GitLabWebhookToken,
+		},
+		apihttp.Limits{
+			IP:          apihttp.RateLimit{Rate: cfg.RateLimit.IPRate, Burst: cfg.RateLimit.IPBurst},
+			Default:     apihttp.RateLimit{Rate: cfg.RateLimit.Rate, Burst: cfg.RateLimit.Burst},
+			Heavy:       apihttp.RateLimit{Rate: cfg.RateLimit.HeavyRate, Burst: cfg.RateLimit.HeavyBurst},
+			MaxInFlight: cfg.HTTP.MaxInFlight,
+		},
+		bus,
+		cfg.Stream.Heartbeat,
+	)
+	schemaVersion, err := migrations.LatestVersion()
+	if err != nil {
+		fatal("failed to read migrations", err)
+	}
+	// пробы дёргаются раз в несколько секунд — в трейсы их не пускаем
+	healthSvc := service.NewHealthService(pgRepos.Schema, schemaVersion)
+
+	root := http.NewServeMux()
+	root.Handle("GET /metrics", m.Handler())
+	apihttp.NewHealthHandler(healthSvc).Register(root)
+	root.Handle("/", logging.Middleware(tracing.Middleware(m.Middleware(handler))))
 
-	teamSvc := service.NewTeamService(repos.Teams, repos.Users)
-	userSvc := service.NewUserService(repos.Users, repos.PRs)
-	prSvc := service.NewPRService(repos.PRs, repos.Users)
+	application := app.NewApp(root, teamSvc, userSvc, prSvc, webhookSvc, integrationSvc, tokenSvc)
 
-	handler := apihttp.NewRouter(teamSvc, userSvc, prSvc)
-	application := app.NewApp(handler, teamSvc, userSvc, prSvc)
+	dispatcher := service.NewWebhookDispatcher(
+		repos.Webhooks,
+		&http.Client{Timeout: cfg.Webhooks.Timeout},
+		service.RetryPolicy{
+			MaxAttempts: cfg.Webhooks.MaxAttempts,
+			BaseDelay:   cfg.Webhooks.BaseDelay,
+			MaxDelay:    cfg.Webhooks.MaxDelay,
+		},
+	)
+	dispatchCtx, stopDispatch := context.WithCancel(context.Background())
+	defer stopDispatch()
+	go dispatcher.Run(dispatchCtx, cfg.Webhooks.PollInterval)
+	go service.RunIdempotencyPurge(dispatchCtx, idempotencySvc, cfg.Idempotency.PurgeInterval)
+	if emailNotifier != nil && cfg.SMTP.DigestEnabled {
+		go emailNotifier.RunDigest(dispatchCtx, cfg.SMTP.DigestAt, cfg.SMTP.DigestTZ)
+	}
 
 	srv := &http.Server{
 		Addr:         ":" + cfg.HTTP.Port,
@@ -65,11 +186,27 @@ func main() {
 		WriteTimeout: cfg.HTTP.WriteTimeout,
 		IdleTimeout:  cfg.HTTP.IdleTimeout,
 	}
+	srv.RegisterOnShutdown(bus.Close)
 
 	go func() {
-		log.Printf("HTTP server listening on :%s", cfg.HTTP.Port)
+		slog.Info("HTTP server listening", "addr", srv.Addr)
 		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			log.Fatalf("server error: %v", err)
+			fatal("server error", err)
+		}
+	}()
+
+	grpcSrv := apigrpc.NewServer(teamSvc, userSvc, prSvc, webhookSvc, tokenSvc, bus, cfg.Stream.Heartbeat,
+		grpc.ChainUnaryInterceptor(tracing.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(tracing.StreamServerInterceptor),
+	)
+	grpcLis, err := net.Listen("tcp", ":"+cfg.GRPC.Port)
+	if err != nil {
+		fatal("failed to listen for gRPC", err)
+	}
+	go func() {
+		slog.Info("gRPC server listening", "addr", grpcLis.Addr().String())
+		if err := grpcSrv.Serve(grpcLis); err != nil {
+			fatal("gRPC server error", err)
 		}
 	}()
 
@@ -77,17 +214,43 @@ func main() {
 	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
 
 	<-stop
-	log.Println("shutting down...")
+	slog.Info("shutting down", "drain_delay", cfg.HTTP.ShutdownDelay)
+
+	// сначала выпадаем из балансировки, потом перестаём принимать соединения
+	healthSvc.Drain()
+	grpcSrv.Drain()
+	time.Sleep(cfg.HTTP.ShutdownDelay)
+
+	stopDispatch()
 
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer shutdownCancel()
 
 	if err := srv.Shutdown(shutdownCtx); err != nil {
-		log.Printf("graceful shutdown failed: %v", err)
+		slog.Error("graceful shutdown failed", "error", err)
 		if err := srv.Close(); err != nil {
-			log.Printf("server close failed: %v", err)
+			slog.Error("server close failed", "error", err)
 		}
 	}
 
-	log.Println("server stopped")
+	// стримы WatchAssignments уже завершены закрытием шины в srv.Shutdown
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcSrv.GracefulStop()
+		close(grpcStopped)
+	}()
+	select {
+	case <-grpcStopped:
+	case <-shutdownCtx.Done():
+		slog.Error("gRPC graceful shutdown timed out")
+		grpcSrv.Stop()
+	}
+
+	slog.Info("server stopped")
+}
+
+// fatal пишет ошибку и завершает процесс, как log.Fatal.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
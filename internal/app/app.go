@@ -9,9 +9,12 @@ import (
 type App struct {
 	Handler http.Handler
 
-	TeamService TeamService
-	UserService UserService
-	PRService   PRService
+	TeamService        TeamService
+	UserService        UserService
+	PRService          PRService
+	WebhookService     WebhookService
+	IntegrationService IntegrationService
+	TokenService       TokenService
 }
 
 // NewApp обертка в красивую структуру
@@ -20,11 +23,17 @@ func NewApp(
 	teamSvc TeamService,
 	userSvc UserService,
 	prSvc PRService,
+	webhookSvc WebhookService,
+	integrationSvc IntegrationService,
+	tokenSvc TokenService,
 ) *App {
 	return &App{
-		Handler:     handler,
-		TeamService: teamSvc,
-		UserService: userSvc,
-		PRService:   prSvc,
+		Handler:            handler,
+		TeamService:        teamSvc,
+		UserService:        userSvc,
+		PRService:          prSvc,
+		WebhookService:     webhookSvc,
+		IntegrationService: integrationSvc,
+		TokenService:       tokenSvc,
 	}
 }
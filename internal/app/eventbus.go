@@ -0,0 +1,131 @@
+package app
+
+import (
+	"avi_internship_autumn/internal/domain"
+	"context"
+	"sync"
+)
+
+// буфер одного подписчика; кто не успевает его разбирать, отключается и догоняет через replay
+const busSubscriberBuffer = 64
+
+// BusEvent событие шины с порядковым номером (используется как SSE id).
+type BusEvent struct {
+	ID    uint64
+	Event domain.Event
+}
+
+// EventBus внутрипроцессная шина событий: сервисы публикуют в неё через EventPublisher,
+// долгоживущие подписчики (SSE-стримы) читают. Последние события хранятся в кольцевом буфере,
+// чтобы переподключившийся клиент мог дочитать пропущенное.
+type EventBus struct {
+	mu      sync.Mutex
+	lastID  uint64
+	history []BusEvent // кольцевой буфер, history[(id-1) % len]
+	subs    map[chan BusEvent]struct{}
+	closed  bool
+}
+
+// NewEventBus создаёт шину, помнящую historySize последних событий.
+func NewEventBus(historySize int) *EventBus {
+	if historySize <= 0 {
+		historySize = 1
+	}
+	return &EventBus{
+		history: make([]BusEvent, historySize),
+		subs:    make(map[chan BusEvent]struct{}),
+	}
+}
+
+// Publish нумерует событие, кладёт в историю и раздаёт подписчикам без блокировки.
+// Переполненный подписчик отключается: его канал закрывается.
+func (b *EventBus) Publish(_ context.Context, ev domain.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	be := BusEvent{ID: b.lastID, Event: ev}
+	b.history[(be.ID-1)%uint64(len(b.history))] = be
+
+	for ch := range b.subs {
+		select {
+		case ch <- be:
+		default:
+			delete(b.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// Subscription подписка на шину.
+type Subscription struct {
+	// Replay события из истории после запрошенного ID, в порядке публикации.
+	Replay []BusEvent
+	// LastID номер последнего опубликованного к моменту подписки события.
+	LastID uint64
+	// Missed true, если часть событий после запрошенного ID уже вытеснена из истории.
+	Missed bool
+	// Events новые события. Закрывается при Close или если подписчик не успевает читать.
+	Events <-chan BusEvent
+
+	close func()
+}
+
+// Close отписывает от шины. Вызывать обязательно, повторный вызов безопасен.
+func (s *Subscription) Close() {
+	s.close()
+}
+
+// Subscribe подписывает на новые события. Если afterID > 0, в Replay попадут
+// сохранившиеся в истории события с ID > afterID.
+func (b *EventBus) Subscribe(afterID uint64) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub := &Subscription{LastID: b.lastID}
+
+	// id из будущего — клиент пришёл из прошлой жизни процесса, дочитать ему нечего
+	if afterID > b.lastID {
+		sub.Missed = true
+	} else if afterID > 0 {
+		oldest := uint64(1)
+		if b.lastID > uint64(len(b.history)) {
+			oldest = b.lastID - uint64(len(b.history)) + 1
+		}
+		sub.Missed = afterID+1 < oldest
+		for id := max(afterID+1, oldest); id <= b.lastID; id++ {
+			sub.Replay = append(sub.Replay, b.history[(id-1)%uint64(len(b.history))])
+		}
+	}
+
+	ch := make(chan BusEvent, busSubscriberBuffer)
+	if b.closed {
+		close(ch)
+	} else {
+		b.subs[ch] = struct{}{}
+	}
+	sub.Events = ch
+
+	sub.close = func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subs[ch]; ok {
+			delete(b.subs, ch)
+			close(ch)
+		}
+	}
+	return sub
+}
+
+// Close отключает всех подписчиков и не даёт подписаться новым.
+// Вызывается при остановке сервера, чтобы бесконечные стримы не держали graceful shutdown.
+func (b *EventBus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for ch := range b.subs {
+		delete(b.subs, ch)
+		close(ch)
+	}
+}
@@ -0,0 +1,28 @@
+package app
+
+import (
+	"avi_internship_autumn/internal/domain"
+	"context"
+)
+
+// EventPublisher принимает доменные события от сервисного слоя.
+// Publish не должен ломать бизнес-операцию: ошибки доставки — забота реализации.
+type EventPublisher interface {
+	Publish(ctx context.Context, ev domain.Event)
+}
+
+// MultiPublisher раздаёт событие всем публикаторам по очереди.
+type MultiPublisher []EventPublisher
+
+// Publish отдаёт событие каждому публикатору.
+func (m MultiPublisher) Publish(ctx context.Context, ev domain.Event) {
+	for _, p := range m {
+		p.Publish(ctx, ev)
+	}
+}
+
+// NopPublisher публикатор-заглушка, когда события никому не нужны (например, в тестах).
+type NopPublisher struct{}
+
+// Publish ничего не делает.
+func (NopPublisher) Publish(context.Context, domain.Event) {}
@@ -0,0 +1,20 @@
+package app
+
+import (
+	"avi_internship_autumn/internal/domain"
+	"context"
+)
+
+type principalKey struct{}
+
+// WithPrincipal кладёт аутентифицированного принципала в context.
+func WithPrincipal(ctx context.Context, p domain.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFrom достаёт принципала из context. ok=false — вызов без аутентификации:
+// фоновые задачи и вебхуки Git-хостингов, которые проверяют подпись сами.
+func PrincipalFrom(ctx context.Context) (domain.Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(domain.Principal)
+	return p, ok
+}
@@ -8,16 +8,26 @@ import (
 
 // Repositories обертка над репозиториями, чтобы иметь возможность передавать единым скопом
 type Repositories struct {
-	Teams repository.TeamRepository
-	Users repository.UserRepository
-	PRs   repository.PRRepository
+	Teams       repository.TeamRepository
+	Users       repository.UserRepository
+	PRs         repository.PRRepository
+	Webhooks    repository.WebhookRepository
+	Identities  repository.IdentityRepository
+	Schema      repository.SchemaRepository
+	Tokens      repository.TokenRepository
+	Idempotency repository.IdempotencyRepository
 }
 
 // NewRepositories создаёт postgres-реализации всех репозиториев.
 func NewRepositories(db *sql.DB) *Repositories {
 	return &Repositories{
-		Teams: pg.NewTeamRepository(db),
-		Users: pg.NewUserRepository(db),
-		PRs:   pg.NewPRRepository(db),
+		Teams:       pg.NewTeamRepository(db),
+		Users:       pg.NewUserRepository(db),
+		PRs:         pg.NewPRRepository(db),
+		Webhooks:    pg.NewWebhookRepository(db),
+		Identities:  pg.NewIdentityRepository(db),
+		Schema:      pg.NewSchemaRepository(db),
+		Tokens:      pg.NewTokenRepository(db),
+		Idempotency: pg.NewIdempotencyRepository(db),
 	}
 }
@@ -9,11 +9,14 @@ import (
 type TeamService interface {
 	CreateTeam(ctx context.Context, team domain.Team) (domain.Team, error)
 	GetTeam(ctx context.Context, teamName string) (domain.Team, error)
+	SetChatWebhook(ctx context.Context, teamName, url string) error
 }
 
 // UserService описывает операции над пользователями.
 type UserService interface {
 	SetIsActive(ctx context.Context, userID string, isActive bool) (domain.User, error)
+	SetChatHandle(ctx context.Context, userID, handle string) (domain.User, error)
+	SetEmailSettings(ctx context.Context, userID, email string, optOut domain.EmailOptOut) (domain.User, error)
 	GetReviewPRs(ctx context.Context, userID string) ([]domain.PullRequest, error)
 	BulkDeactivateTeam(ctx context.Context, teamName string, userIDs []string) (domain.BulkDeactivateResult, error)
 }
@@ -23,7 +26,63 @@ type PRService interface {
 	CreatePR(ctx context.Context, id, name, authorID string) (domain.PullRequest, error)
 	MergePR(ctx context.Context, id string) (domain.PullRequest, error)
 	ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (domain.PullRequest, string, error)
+	ClosePR(ctx context.Context, id string) (domain.PullRequest, error)
+	ReopenPR(ctx context.Context, id string) (domain.PullRequest, error)
+	SetReviewers(ctx context.Context, prID string, reviewerIDs []string) (domain.PullRequest, error)
+	SubmitVerdict(ctx context.Context, prID, reviewerID string, verdict domain.ReviewVerdict) (domain.PullRequest, error)
 
-	GetAssignmentStatsByReviewer(ctx context.Context) ([]domain.AssignmentStats, error)
-	GetAssignmentStatsByPR(ctx context.Context) ([]domain.PullRequestAssignmentStats, error)
+	GetAssignmentStatsByReviewer(ctx context.Context, f domain.AssignmentStatsFilter) ([]domain.AssignmentStats, error)
+	GetAssignmentStatsByPR(ctx context.Context, f domain.AssignmentStatsFilter) ([]domain.PullRequestAssignmentStats, error)
+	GetLatencyStats(ctx context.Context, f domain.LatencyFilter) ([]domain.LatencyStats, error)
+	GetFairnessReport(ctx context.Context, teamName string) ([]domain.TeamFairness, error)
+	GetReviewPairs(ctx context.Context, f domain.ReviewPairsFilter, topN int) (domain.PairMatrix, error)
+
+	ExportPullRequests(ctx context.Context, f domain.ExportFilter, fn func(domain.PullRequest) error) error
+	ExportAssignments(ctx context.Context, f domain.ExportFilter, fn func(domain.Assignment) error) error
+}
+
+// WebhookService описывает управление подписками на исходящие вебхуки.
+// Сам сервис является EventPublisher: на каждое событие ставит доставки в очередь.
+type WebhookService interface {
+	EventPublisher
+
+	Subscribe(ctx context.Context, sub domain.WebhookSubscription) (domain.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context, teamName string) ([]domain.WebhookSubscription, error)
+	SetSubscriptionActive(ctx context.Context, subscriptionID int64, active bool) (domain.WebhookSubscription, error)
+	RotateSecret(ctx context.Context, subscriptionID int64, secret string) (domain.WebhookSubscription, error)
+	ListDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]domain.WebhookDelivery, error)
+	ReplayDelivery(ctx context.Context, deliveryID int64) (domain.WebhookDelivery, error)
+}
+
+// IntegrationService описывает приём событий из внешних Git-хостингов.
+type IntegrationService interface {
+	LinkIdentity(ctx context.Context, id domain.Identity) error
+	HandlePREvent(ctx context.Context, ev domain.ExternalPREvent) (domain.IntegrationResult, error)
+}
+
+// TokenService выпускает, отзывает и проверяет bearer-токены API.
+// Issue возвращает сам токен — он показывается один раз и больше нигде не хранится.
+type TokenService interface {
+	Issue(ctx context.Context, t domain.APIToken) (domain.APIToken, string, error)
+	List(ctx context.Context) ([]domain.APIToken, error)
+	Revoke(ctx context.Context, id int64) (domain.APIToken, error)
+	Authenticate(ctx context.Context, token string) (domain.Principal, error)
+}
+
+// IdempotencyService хранит ответы POST-запросов с Idempotency-Key.
+// Begin либо занимает ключ (replay=false, запрос надо выполнить и затем вызвать Complete или Release),
+// либо возвращает сохранённый ответ. Тот же ключ с другим запросом — domain.ErrIdempotencyMismatch,
+// с незавершённым — domain.ErrIdempotencyInProgress.
+type IdempotencyService interface {
+	Begin(ctx context.Context, scope, key, requestHash string) (resp domain.IdempotentResponse, replay bool, err error)
+	Complete(ctx context.Context, scope, key string, resp domain.IdempotentResponse) error
+	Release(ctx context.Context, scope, key string) error
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+// HealthService отвечает на проверки живости и готовности.
+// Drain переводит сервис в неготовность перед остановкой, чтобы балансировщик успел снять трафик.
+type HealthService interface {
+	Ready(ctx context.Context) domain.HealthReport
+	Drain()
 }
@@ -0,0 +1,42 @@
+// Package buildinfo хранит сведения о сборке. Значения подставляются при сборке:
+//
+//	go build -ldflags "-X avi_internship_autumn/internal/buildinfo.Commit=$(git rev-parse HEAD) \
+//	    -X avi_internship_autumn/internal/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+package buildinfo
+
+import "runtime/debug"
+
+var (
+	// Commit хеш коммита, из которого собран бинарь.
+	Commit = ""
+	// BuildTime время сборки в RFC 3339.
+	BuildTime = ""
+)
+
+// Info сведения о сборке для /version.
+type Info struct {
+	Commit    string
+	BuildTime string
+	GoVersion string
+}
+
+// Get отдаёт сведения о сборке. Без ldflags коммит и время берутся из VCS-меток,
+// которые go build пишет сам, если собирать внутри git-репозитория.
+func Get() Info {
+	info := Info{Commit: Commit, BuildTime: BuildTime}
+
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	info.GoVersion = bi.GoVersion
+	for _, s := range bi.Settings {
+		switch {
+		case s.Key == "vcs.revision" && info.Commit == "":
+			info.Commit = s.Value
+		case s.Key == "vcs.time" && info.BuildTime == "":
+			info.BuildTime = s.Value
+		}
+	}
+	return info
+}
@@ -19,6 +19,34 @@ const (
 	defaultDBMaxOpenConns    = 10
 	defaultDBMaxIdleConns    = 5
 	defaultDBConnMaxLifetime = 30 * time.Minute
+
+	defaultWebhookPollInterval = 2 * time.Second
+	defaultWebhookMaxAttempts  = 8
+	defaultWebhookBaseDelay    = 5 * time.Second
+	defaultWebhookMaxDelay     = 30 * time.Minute
+	defaultWebhookTimeout      = 10 * time.Second
+
+	defaultChatTimeout = 5 * time.Second
+
+	defaultRateLimitIPRPS      = 50
+	defaultRateLimitIPBurst    = 100
+	defaultRateLimitRPS        = 20
+	defaultRateLimitBurst      = 40
+	defaultRateLimitHeavyRPS   = 1
+	defaultRateLimitHeavyBurst = 5
+
+	defaultIdempotencyTTL           = 24 * time.Hour
+	defaultIdempotencyLockTimeout   = time.Minute
+	defaultIdempotencyPurgeInterval = 10 * time.Minute
+
+	defaultStreamHeartbeat   = 15 * time.Second
+	defaultStreamHistorySize = 1024
+
+	defaultSMTPPort      = 587
+	defaultSMTPTimeout   = 10 * time.Second
+	defaultEmailDigestAt = 9 * time.Hour
+
+	defaultTracingServiceName = "pr-reviewer"
 )
 
 // HTTPConfig содержит настройки HTTP-сервера.
@@ -27,6 +55,29 @@ type HTTPConfig struct {
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	IdleTimeout  time.Duration
+	// ShutdownDelay сколько /readyz отдаёт 503 до srv.Shutdown, чтобы балансировщик успел снять трафик
+	ShutdownDelay time.Duration
+	// MaxInFlight сколько запросов API обрабатывается одновременно; сверх — сразу 503,
+	// а не очередь за соединением пула БД. 0 — без ограничения.
+	MaxInFlight int
+}
+
+// GRPCConfig содержит настройки gRPC-сервера для внутренних сервисов.
+type GRPCConfig struct {
+	Port string
+}
+
+// RateLimitConfig содержит лимиты token bucket на клиента: токен API, а без токена — IP.
+// Rate — запросов в секунду, Burst — сколько можно сделать подряд. Rate 0 выключает лимит.
+type RateLimitConfig struct {
+	// лимит на IP действует до проверки токена и для всех запросов с одного адреса
+	IPRate  float64
+	IPBurst int
+	Rate    float64
+	Burst   int
+	// тяжёлые ручки (массовая деактивация, статистика, выгрузки) дополнительно ограничены строже
+	HeavyRate  float64
+	HeavyBurst int
 }
 
 // DBConfig содержит настройки подключения к базе данных.
@@ -46,10 +97,114 @@ type DBConfig struct {
 	ConnMaxLifetime time.Duration
 }
 
+// WebhookConfig содержит настройки доставки исходящих вебхуков.
+type WebhookConfig struct {
+	PollInterval time.Duration
+	MaxAttempts  int           // после стольких неудач доставка уходит в DEAD
+	BaseDelay    time.Duration // пауза после первой неудачи, дальше удваивается
+	MaxDelay     time.Duration
+	Timeout      time.Duration // таймаут одного HTTP-запроса к подписчику
+}
+
+// IntegrationsConfig содержит секреты входящих вебхуков Git-хостингов.
+// Пустое значение выключает соответствующую интеграцию.
+type IntegrationsConfig struct {
+	GitHubWebhookSecret string
+	GitLabWebhookToken  string
+}
+
+// AuthConfig содержит настройки аутентификации API.
+type AuthConfig struct {
+	// AdminToken бутстрап-токен с admin-scope, которым выпускают остальные токены.
+	// В БД не хранится; пустое значение его отключает.
+	AdminToken string
+
+	// JWT принимаются, если задан хотя бы один источник ключей.
+	JWTHMACSecret string // общий секрет для HS256
+	JWTJWKSFile   string // путь к JWKS с ключами RS256 (RSA) и HS256 (oct)
+	JWTIssuer     string // ожидаемый iss; пусто — не проверяется
+	JWTAudience   string // ожидаемый aud; пусто — не проверяется
+}
+
+// JWTEnabled сообщает, заданы ли ключи для проверки JWT.
+func (c AuthConfig) JWTEnabled() bool {
+	return c.JWTHMACSecret != "" || c.JWTJWKSFile != ""
+}
+
+// IdempotencyConfig содержит настройки ключей Idempotency-Key для POST-запросов.
+type IdempotencyConfig struct {
+	TTL           time.Duration // сколько хранится ответ для повторов
+	LockTimeout   time.Duration // сколько незавершённый запрос держит ключ, если процесс упал
+	PurgeInterval time.Duration // как часто удалять истёкшие ключи
+}
+
+// ChatConfig содержит настройки уведомлений в Slack/Mattermost.
+// Адреса каналов хранятся у команд, здесь только общие параметры отправки.
+type ChatConfig struct {
+	Timeout time.Duration
+}
+
+// StreamConfig содержит настройки SSE-стримов.
+type StreamConfig struct {
+	Heartbeat   time.Duration
+	HistorySize int // сколько последних событий хранить для переподключения с Last-Event-ID
+}
+
+// SMTPConfig содержит настройки email-уведомлений.
+// Пустой Host выключает email целиком.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	Timeout  time.Duration
+
+	DigestEnabled bool
+	DigestAt      time.Duration // время отправки дайджеста: смещение от полуночи по DigestTZ
+	DigestTZ      *time.Location
+}
+
+// Enabled сообщает, настроен ли SMTP.
+func (c SMTPConfig) Enabled() bool {
+	return c.Host != ""
+}
+
+// TracingConfig содержит настройки OpenTelemetry-трейсинга.
+// Без OTLP-эндпоинта спаны не экспортируются (no-op провайдер).
+// Остальные OTEL_EXPORTER_OTLP_* (заголовки, таймаут, TLS) экспортёр читает сам.
+type TracingConfig struct {
+	Endpoint    string
+	ServiceName string
+	SampleRatio float64 // доля корневых трейсов, которые пишем; входящий sampled-флаг уважается всегда
+}
+
+// Enabled сообщает, настроен ли экспорт трейсов.
+func (c TracingConfig) Enabled() bool {
+	return c.Endpoint != ""
+}
+
+// LogConfig содержит настройки логирования.
+type LogConfig struct {
+	Format string // json | text
+	Level  string // debug | info | warn | error
+}
+
 // Config агрегирует конфигурацию всех подсистем приложения.
 type Config struct {
-	HTTP HTTPConfig
-	DB   DBConfig
+	HTTP         HTTPConfig
+	GRPC         GRPCConfig
+	RateLimit    RateLimitConfig
+	DB           DBConfig
+	Webhooks     WebhookConfig
+	Integrations IntegrationsConfig
+	Auth         AuthConfig
+	Idempotency  IdempotencyConfig
+	Chat         ChatConfig
+	SMTP         SMTPConfig
+	Stream       StreamConfig
+	Tracing      TracingConfig
+	Log          LogConfig
 }
 
 // DSNString возвращает строку подключения для database/sql.
@@ -77,6 +232,8 @@ func Load() (Config, error) {
 		ReadTimeout:  getDurationEnv("HTTP_READ_TIMEOUT", 5*time.Second),
 		WriteTimeout: getDurationEnv("HTTP_WRITE_TIMEOUT", 5*time.Second),
 		IdleTimeout:  getDurationEnv("HTTP_IDLE_TIMEOUT", 60*time.Second),
+
+		ShutdownDelay: getDurationEnv("HTTP_SHUTDOWN_DELAY", 3*time.Second),
 	}
 
 	dbPort := getIntEnv("DB_PORT", defaultDBPort)
@@ -98,9 +255,86 @@ func Load() (Config, error) {
 		ConnMaxLifetime: dbConnLife,
 	}
 
+	// по умолчанию — два запроса на соединение пула: часть времени запрос соединение не держит,
+	// а сверх этого запросы уже стояли бы в очереди пула до таймаута
+	httpCfg.MaxInFlight = getIntEnv("HTTP_MAX_IN_FLIGHT", 2*dbMaxOpen)
+
+	webhookCfg := WebhookConfig{
+		PollInterval: getDurationEnv("WEBHOOK_POLL_INTERVAL", defaultWebhookPollInterval),
+		MaxAttempts:  getIntEnv("WEBHOOK_MAX_ATTEMPTS", defaultWebhookMaxAttempts),
+		BaseDelay:    getDurationEnv("WEBHOOK_BASE_DELAY", defaultWebhookBaseDelay),
+		MaxDelay:     getDurationEnv("WEBHOOK_MAX_DELAY", defaultWebhookMaxDelay),
+		Timeout:      getDurationEnv("WEBHOOK_TIMEOUT", defaultWebhookTimeout),
+	}
+
+	integrationsCfg := IntegrationsConfig{
+		GitHubWebhookSecret: os.Getenv("GITHUB_WEBHOOK_SECRET"),
+		GitLabWebhookToken:  os.Getenv("GITLAB_WEBHOOK_TOKEN"),
+	}
+
+	smtpCfg := SMTPConfig{
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     getIntEnv("SMTP_PORT", defaultSMTPPort),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     getEnv("SMTP_FROM", "pr-reviewer@localhost"),
+		Timeout:  getDurationEnv("SMTP_TIMEOUT", defaultSMTPTimeout),
+
+		DigestEnabled: getEnv("EMAIL_DIGEST_ENABLED", "true") == "true",
+		DigestAt:      getClockEnv("EMAIL_DIGEST_AT", defaultEmailDigestAt),
+		DigestTZ:      time.UTC,
+	}
+	if tz := os.Getenv("EMAIL_DIGEST_TZ"); tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return Config{}, fmt.Errorf("EMAIL_DIGEST_TZ: %w", err)
+		}
+		smtpCfg.DigestTZ = loc
+	}
+
 	cfg := Config{
-		HTTP: httpCfg,
-		DB:   dbCfg,
+		HTTP:         httpCfg,
+		GRPC:         GRPCConfig{Port: getEnv("GRPC_PORT", "9090")},
+		DB:           dbCfg,
+		Webhooks:     webhookCfg,
+		Integrations: integrationsCfg,
+		Auth: AuthConfig{
+			AdminToken:    os.Getenv("AUTH_ADMIN_TOKEN"),
+			JWTHMACSecret: os.Getenv("AUTH_JWT_HS256_SECRET"),
+			JWTJWKSFile:   os.Getenv("AUTH_JWT_JWKS_FILE"),
+			JWTIssuer:     os.Getenv("AUTH_JWT_ISSUER"),
+			JWTAudience:   os.Getenv("AUTH_JWT_AUDIENCE"),
+		},
+		RateLimit: RateLimitConfig{
+			IPRate:     getFloatEnv("RATE_LIMIT_IP_RPS", defaultRateLimitIPRPS),
+			IPBurst:    getIntEnv("RATE_LIMIT_IP_BURST", defaultRateLimitIPBurst),
+			Rate:       getFloatEnv("RATE_LIMIT_RPS", defaultRateLimitRPS),
+			Burst:      getIntEnv("RATE_LIMIT_BURST", defaultRateLimitBurst),
+			HeavyRate:  getFloatEnv("RATE_LIMIT_HEAVY_RPS", defaultRateLimitHeavyRPS),
+			HeavyBurst: getIntEnv("RATE_LIMIT_HEAVY_BURST", defaultRateLimitHeavyBurst),
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:           getDurationEnv("IDEMPOTENCY_TTL", defaultIdempotencyTTL),
+			LockTimeout:   getDurationEnv("IDEMPOTENCY_LOCK_TIMEOUT", defaultIdempotencyLockTimeout),
+			PurgeInterval: getDurationEnv("IDEMPOTENCY_PURGE_INTERVAL", defaultIdempotencyPurgeInterval),
+		},
+		Chat: ChatConfig{
+			Timeout: getDurationEnv("CHAT_NOTIFY_TIMEOUT", defaultChatTimeout),
+		},
+		SMTP: smtpCfg,
+		Stream: StreamConfig{
+			Heartbeat:   getDurationEnv("SSE_HEARTBEAT_INTERVAL", defaultStreamHeartbeat),
+			HistorySize: getIntEnv("SSE_HISTORY_SIZE", defaultStreamHistorySize),
+		},
+		Tracing: TracingConfig{
+			Endpoint:    getEnv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")),
+			ServiceName: getEnv("OTEL_SERVICE_NAME", defaultTracingServiceName),
+			SampleRatio: getFloatEnv("OTEL_TRACES_SAMPLER_ARG", 1),
+		},
+		Log: LogConfig{
+			Format: getEnv("LOG_FORMAT", "json"),
+			Level:  getEnv("LOG_LEVEL", "info"),
+		},
 	}
 
 	return cfg, nil
@@ -125,6 +359,18 @@ func getIntEnv(key string, def int) int {
 	return n
 }
 
+func getFloatEnv(key string, def float64) float64 {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return def
+	}
+	return f
+}
+
 func getDurationEnv(key string, def time.Duration) time.Duration {
 	v := os.Getenv(key)
 	if v == "" {
@@ -136,3 +382,16 @@ func getDurationEnv(key string, def time.Duration) time.Duration {
 	}
 	return d
 }
+
+// getClockEnv читает время суток в формате "15:04" и возвращает смещение от полуночи.
+func getClockEnv(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		return def
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+}
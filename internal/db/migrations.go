@@ -0,0 +1,39 @@
+// Package db содержит SQL-миграции схемы. Они вшиваются в бинарь,
+// чтобы сервис знал, какую версию схемы ожидает.
+package db
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+// Migrations файлы миграций вида 000N_name.sql.
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
+
+// LatestVersion номер последней миграции — версия схемы, с которой собран бинарь.
+func LatestVersion() (int, error) {
+	names, err := fs.Glob(Migrations, "migrations/*.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	latest := 0
+	for _, name := range names {
+		base := strings.TrimPrefix(name, "migrations/")
+		prefix, _, ok := strings.Cut(base, "_")
+		if !ok {
+			return 0, fmt.Errorf("migration %s: no version prefix", base)
+		}
+		v, err := strconv.Atoi(prefix)
+		if err != nil {
+			return 0, fmt.Errorf("migration %s: %w", base, err)
+		}
+		latest = max(latest, v)
+	}
+	return latest, nil
+}
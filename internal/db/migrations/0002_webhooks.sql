@@ -0,0 +1,30 @@
+-- Подписки команд на исходящие вебхуки
+CREATE TABLE webhook_subscriptions (
+                                       subscription_id BIGSERIAL PRIMARY KEY,
+                                       team_name       TEXT NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+                                       url             TEXT NOT NULL,
+                                       secret          TEXT NOT NULL,
+                                       event_types     TEXT[] NOT NULL,
+                                       is_active       BOOLEAN NOT NULL DEFAULT TRUE,
+                                       created_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_webhook_subscriptions_team ON webhook_subscriptions(team_name) WHERE is_active;
+
+-- Доставки: payload храним как есть, т.к. подпись считается по точным байтам тела
+CREATE TABLE webhook_deliveries (
+                                    delivery_id      BIGSERIAL PRIMARY KEY,
+                                    subscription_id  BIGINT NOT NULL REFERENCES webhook_subscriptions(subscription_id) ON DELETE CASCADE,
+                                    event_type       TEXT NOT NULL,
+                                    payload          BYTEA NOT NULL,
+                                    status           TEXT NOT NULL CHECK (status IN ('PENDING', 'DELIVERED', 'DEAD')),
+                                    attempts         INT NOT NULL DEFAULT 0,
+                                    next_attempt_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                    last_status_code INT,
+                                    last_error       TEXT,
+                                    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                    delivered_at     TIMESTAMPTZ
+);
+
+CREATE INDEX idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id, created_at DESC);
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'PENDING';
@@ -0,0 +1,10 @@
+-- Логины во внешних Git-хостингах (GitHub, GitLab) -> наши пользователи
+CREATE TABLE user_identities (
+                                 provider   TEXT NOT NULL CHECK (provider IN ('github', 'gitlab')),
+                                 login      TEXT NOT NULL,
+                                 user_id    TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+                                 created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                 PRIMARY KEY (provider, login)
+);
+
+CREATE INDEX idx_user_identities_user ON user_identities(user_id);
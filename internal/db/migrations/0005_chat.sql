@@ -0,0 +1,3 @@
+-- Чат-уведомления: incoming-webhook канала команды и ники пользователей для упоминаний
+ALTER TABLE teams ADD COLUMN chat_webhook_url TEXT;
+ALTER TABLE users ADD COLUMN chat_handle TEXT;
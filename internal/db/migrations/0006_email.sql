@@ -0,0 +1,4 @@
+-- Email-уведомления: адрес и отписки пользователя
+ALTER TABLE users ADD COLUMN email TEXT;
+ALTER TABLE users ADD COLUMN email_opt_out_assignments BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN email_opt_out_digest BOOLEAN NOT NULL DEFAULT FALSE;
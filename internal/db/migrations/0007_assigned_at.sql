@@ -0,0 +1,13 @@
+-- Время назначения ревьювера: для статистики за период.
+-- Старым назначениям проставляем время создания PR — точнее уже не узнать.
+ALTER TABLE pr_reviewers ADD COLUMN assigned_at TIMESTAMPTZ;
+
+UPDATE pr_reviewers r
+SET assigned_at = p.created_at
+FROM pull_requests p
+WHERE p.pull_request_id = r.pull_request_id;
+
+ALTER TABLE pr_reviewers ALTER COLUMN assigned_at SET DEFAULT now();
+ALTER TABLE pr_reviewers ALTER COLUMN assigned_at SET NOT NULL;
+
+CREATE INDEX idx_pr_reviewers_assigned_at ON pr_reviewers(assigned_at);
@@ -0,0 +1,3 @@
+-- Вердикт ревьювера по PR: нужен для метрики «время до первого вердикта»
+ALTER TABLE pr_reviewers ADD COLUMN verdict TEXT CHECK (verdict IN ('APPROVED', 'CHANGES_REQUESTED'));
+ALTER TABLE pr_reviewers ADD COLUMN verdict_at TIMESTAMPTZ;
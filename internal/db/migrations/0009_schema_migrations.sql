@@ -0,0 +1,10 @@
+-- Учёт применённых миграций: /readyz сверяет max(version) с последней миграцией в бинаре.
+-- Каждая следующая миграция должна заканчиваться INSERT INTO schema_migrations (version) VALUES (N).
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version    INT PRIMARY KEY,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+INSERT INTO schema_migrations (version)
+SELECT generate_series(1, 9)
+ON CONFLICT (version) DO NOTHING;
@@ -0,0 +1,14 @@
+-- Bearer-токены API. Храним только SHA-256 от токена: сам токен показывается один раз при выпуске.
+CREATE TABLE api_tokens (
+    token_id   BIGSERIAL PRIMARY KEY,
+    token_hash TEXT NOT NULL UNIQUE,
+    scope      TEXT NOT NULL CHECK (scope IN ('admin', 'user')),
+    user_id    TEXT REFERENCES users(user_id) ON DELETE CASCADE,
+    name       TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    revoked_at TIMESTAMPTZ,
+    -- user-токен всегда привязан к пользователю, admin-токен — никогда
+    CHECK ((scope = 'user') = (user_id IS NOT NULL))
+);
+
+INSERT INTO schema_migrations (version) VALUES (10);
@@ -0,0 +1,17 @@
+-- Ключи идемпотентности POST-запросов: хэш запроса и готовый ответ, пока не истёк TTL.
+CREATE TABLE idempotency_keys (
+    scope        TEXT NOT NULL, -- владелец ключа: token:<id>, user:<sub> или admin
+    idem_key     TEXT NOT NULL,
+    request_hash TEXT NOT NULL,
+    -- пусто, пока первый запрос выполняется
+    status_code  INT,
+    content_type TEXT NOT NULL DEFAULT '',
+    body         BYTEA,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at   TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (scope, idem_key)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+
+INSERT INTO schema_migrations (version) VALUES (11);
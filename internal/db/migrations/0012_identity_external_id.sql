@@ -0,0 +1,6 @@
+-- Числовой id пользователя на хостинге: в Merge Request Hook GitLab автор MR приходит только как author_id
+ALTER TABLE user_identities ADD COLUMN external_id TEXT;
+
+CREATE UNIQUE INDEX idx_user_identities_external_id ON user_identities (provider, external_id);
+
+INSERT INTO schema_migrations (version) VALUES (12);
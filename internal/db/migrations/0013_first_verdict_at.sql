@@ -0,0 +1,7 @@
+-- Время первого вердикта ревьювера: verdict_at сдвигается каждым повторным вердиктом,
+-- а метрика «время до первого вердикта» должна считаться от самого первого
+ALTER TABLE pr_reviewers ADD COLUMN first_verdict_at TIMESTAMPTZ;
+
+UPDATE pr_reviewers SET first_verdict_at = verdict_at WHERE verdict_at IS NOT NULL;
+
+INSERT INTO schema_migrations (version) VALUES (13);
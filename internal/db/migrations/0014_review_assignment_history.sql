@@ -0,0 +1,17 @@
+-- Журнал назначений ревьюверов: в pr_reviewers только текущие, reassign и bulkDeactivate
+-- удаляют оттуда строки, а исторической нагрузке нужен источник, из которого не удаляют.
+CREATE TABLE review_assignment_history (
+    id              BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    reviewer_id     TEXT NOT NULL REFERENCES users(user_id) ON DELETE RESTRICT,
+    assigned_at     TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_review_assignment_history_reviewer ON review_assignment_history(reviewer_id);
+
+-- снятые раньше назначения уже не восстановить, журнал начинается с текущих
+INSERT INTO review_assignment_history (pull_request_id, reviewer_id, assigned_at)
+SELECT pull_request_id, reviewer_id, assigned_at
+FROM pr_reviewers;
+
+INSERT INTO schema_migrations (version) VALUES (14);
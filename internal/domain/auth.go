@@ -0,0 +1,52 @@
+package domain
+
+import "time"
+
+// Scope область действия токена API.
+type Scope string
+
+const (
+	// ScopeAdmin управление командами, деактивация, статистика и выпуск токенов.
+	ScopeAdmin Scope = "admin"
+	// ScopeUser только своя очередь ревью и переназначение своих ревью.
+	ScopeUser Scope = "user"
+)
+
+// Valid проверяет, что scope известен.
+func (s Scope) Valid() bool {
+	return s == ScopeAdmin || s == ScopeUser
+}
+
+// APIToken выпущенный bearer-токен. Сам токен не хранится — только его хэш.
+type APIToken struct {
+	ID        int64
+	Scope     Scope
+	UserID    string // владелец user-токена, у admin-токена пусто
+	Name      string // подпись для людей: кому и зачем выдан
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
+
+// Principal от чьего имени выполняется запрос.
+type Principal struct {
+	TokenID  int64 // 0 — бутстрап-токен из конфига или JWT
+	Scope    Scope
+	UserID   string
+	TeamName string // команда из claim team JWT; у непрозрачных токенов пусто
+	TeamLead bool   // роль team_lead в JWT: управляет ревью своей команды
+}
+
+// IsAdmin сообщает, что у принципала admin-scope.
+func (p Principal) IsAdmin() bool {
+	return p.Scope == ScopeAdmin
+}
+
+// LeadsTeam является ли принципал тимлидом команды teamName.
+func (p Principal) LeadsTeam(teamName string) bool {
+	return p.TeamLead && p.TeamName != "" && p.TeamName == teamName
+}
+
+// CanActAs может ли принципал действовать за пользователя: админ — за любого, user-токен — только за себя.
+func (p Principal) CanActAs(userID string) bool {
+	return p.IsAdmin() || (p.Scope == ScopeUser && p.UserID == userID)
+}
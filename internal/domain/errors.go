@@ -10,10 +10,26 @@ var (
 	ErrPRExists = errors.New("pr exists")
 	// ErrPRMerged Pull Request смержили
 	ErrPRMerged = errors.New("pr is merged")
+	// ErrPRClosed Pull Request закрыт без merge
+	ErrPRClosed = errors.New("pr is closed")
 	// ErrNotAssigned ревьюер не назначен
 	ErrNotAssigned = errors.New("reviewer not assigned")
 	// ErrNoCandidate нет свободных кандидатов в ревьюеры
 	ErrNoCandidate = errors.New("no candidate")
 	// ErrNotFound ресурс не найден (общая ошибка относительно)
 	ErrNotFound = errors.New("not found")
+	// ErrInvalidSignature подпись/токен входящего вебхука не сошлись
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrUnauthorized bearer-токен не передан, неизвестен или отозван
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden токен валиден, но его scope не разрешает операцию
+	ErrForbidden = errors.New("forbidden")
+	// ErrRateLimited клиент превысил лимит запросов
+	ErrRateLimited = errors.New("rate limited")
+	// ErrOverloaded сервер обрабатывает максимум запросов и новый не берёт
+	ErrOverloaded = errors.New("overloaded")
+	// ErrIdempotencyMismatch Idempotency-Key уже использован с другим запросом
+	ErrIdempotencyMismatch = errors.New("idempotency key reused with different request")
+	// ErrIdempotencyInProgress запрос с этим Idempotency-Key ещё выполняется
+	ErrIdempotencyInProgress = errors.New("idempotency key in progress")
 )
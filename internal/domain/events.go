@@ -0,0 +1,69 @@
+package domain
+
+import "time"
+
+// EventType тип доменного события, которое сервисный слой отдаёт наружу.
+type EventType string
+
+const (
+	// EventPRCreated PR создан.
+	EventPRCreated EventType = "pull_request.created"
+	// EventPRMerged PR переведён в MERGED.
+	EventPRMerged EventType = "pull_request.merged"
+	// EventPRClosed PR закрыт без merge.
+	EventPRClosed EventType = "pull_request.closed"
+	// EventPRReopened закрытый PR снова открыт.
+	EventPRReopened EventType = "pull_request.reopened"
+	// EventReviewerAssigned ревьювер назначен на PR.
+	EventReviewerAssigned EventType = "reviewer.assigned"
+	// EventReviewerUnassigned ревьювер снят с PR.
+	EventReviewerUnassigned EventType = "reviewer.unassigned"
+)
+
+// EventTypes все известные типы событий.
+var EventTypes = []EventType{
+	EventPRCreated,
+	EventPRMerged,
+	EventPRClosed,
+	EventPRReopened,
+	EventReviewerAssigned,
+	EventReviewerUnassigned,
+}
+
+// Valid проверяет, что тип события известен.
+func (t EventType) Valid() bool {
+	for _, known := range EventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Event доменное событие по PR или ревьюверу.
+type Event struct {
+	Type        EventType
+	TeamName    string
+	PullRequest PullRequest
+	ReviewerID  string // заполнен только для reviewer.* событий
+	// ReplacedReviewerID для reviewer.assigned: кого заменил новый ревьювер
+	// (переназначение, деактивация). Пусто при первичном назначении.
+	ReplacedReviewerID string
+	OccurredAt         time.Time
+}
+
+// ConcernsReviewer сообщает, меняет ли событие очередь ревью пользователя:
+// его назначили или сняли, либо смержили PR, который он ревьюит.
+func (e Event) ConcernsReviewer(userID string) bool {
+	switch e.Type {
+	case EventReviewerAssigned, EventReviewerUnassigned:
+		return e.ReviewerID == userID
+	case EventPRMerged:
+		for _, id := range e.PullRequest.AssignedReviewers {
+			if id == userID {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"math"
+	"sort"
+)
+
+// ReviewerLoad нагрузка одного участника команды.
+type ReviewerLoad struct {
+	UserID   string
+	TeamName string
+	IsActive bool
+	Open     int64 // назначения на открытые PR
+	Total    int64 // все назначения за всё время, включая снятые при переназначении
+}
+
+// FairnessIndex показатели равномерности распределения назначений.
+type FairnessIndex struct {
+	Mean   float64
+	StdDev float64 // стандартное отклонение по генеральной совокупности
+	// Gini 0 — у всех поровну, ближе к 1 — всё у одного.
+	Gini float64
+	// MaxMinRatio max/min; +Inf, если у кого-то ноль, а у кого-то нет; 1, если у всех ноль.
+	MaxMinRatio float64
+}
+
+// LoadDistribution распределение одного вида нагрузки по команде.
+type LoadDistribution struct {
+	Index FairnessIndex
+	// Overloaded и Underused участники, отклоняющиеся от среднего больше чем на StdDev.
+	Overloaded []string
+	Underused  []string
+}
+
+// TeamFairness отчёт по команде. Индексы считаются только по активным участникам:
+// неактивных стратегия назначения не выбирает, их нули исказили бы картину.
+type TeamFairness struct {
+	TeamName   string
+	Members    []ReviewerLoad
+	Open       LoadDistribution
+	Historical LoadDistribution
+}
+
+// NewTeamFairness строит отчёт по участникам одной команды.
+func NewTeamFairness(teamName string, members []ReviewerLoad) TeamFairness {
+	active := make([]ReviewerLoad, 0, len(members))
+	for _, m := range members {
+		if m.IsActive {
+			active = append(active, m)
+		}
+	}
+
+	return TeamFairness{
+		TeamName:   teamName,
+		Members:    members,
+		Open:       newLoadDistribution(active, func(m ReviewerLoad) int64 { return m.Open }),
+		Historical: newLoadDistribution(active, func(m ReviewerLoad) int64 { return m.Total }),
+	}
+}
+
+func newLoadDistribution(members []ReviewerLoad, load func(ReviewerLoad) int64) LoadDistribution {
+	values := make([]int64, 0, len(members))
+	for _, m := range members {
+		values = append(values, load(m))
+	}
+
+	d := LoadDistribution{
+		Index:      NewFairnessIndex(values),
+		Overloaded: make([]string, 0),
+		Underused:  make([]string, 0),
+	}
+	if d.Index.StdDev == 0 {
+		return d
+	}
+
+	for _, m := range members {
+		v := float64(load(m))
+		switch {
+		case v > d.Index.Mean+d.Index.StdDev:
+			d.Overloaded = append(d.Overloaded, m.UserID)
+		case v < d.Index.Mean-d.Index.StdDev:
+			d.Underused = append(d.Underused, m.UserID)
+		}
+	}
+	return d
+}
+
+// NewFairnessIndex считает показатели по набору неотрицательных значений.
+func NewFairnessIndex(values []int64) FairnessIndex {
+	if len(values) == 0 {
+		return FairnessIndex{MaxMinRatio: 1}
+	}
+
+	sorted := append([]int64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	n := float64(len(sorted))
+	var sum, weighted float64
+	for i, v := range sorted {
+		sum += float64(v)
+		weighted += float64(i+1) * float64(v)
+	}
+	mean := sum / n
+
+	var sqDiff float64
+	for _, v := range sorted {
+		d := float64(v) - mean
+		sqDiff += d * d
+	}
+
+	idx := FairnessIndex{
+		Mean:   mean,
+		StdDev: math.Sqrt(sqDiff / n),
+	}
+
+	if sum > 0 {
+		// формула для отсортированной выборки: G = 2·Σ(i·x_i) / (n·Σx) − (n+1)/n
+		idx.Gini = 2*weighted/(n*sum) - (n+1)/n
+	}
+
+	lo, hi := sorted[0], sorted[len(sorted)-1]
+	switch {
+	case hi == 0:
+		idx.MaxMinRatio = 1
+	case lo == 0:
+		idx.MaxMinRatio = math.Inf(1)
+	default:
+		idx.MaxMinRatio = float64(hi) / float64(lo)
+	}
+
+	return idx
+}
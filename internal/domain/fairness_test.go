@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestNewFairnessIndex(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int64
+		want   FairnessIndex
+	}{
+		{name: "empty", values: nil, want: FairnessIndex{MaxMinRatio: 1}},
+		{name: "single member", values: []int64{5}, want: FairnessIndex{Mean: 5, MaxMinRatio: 1}},
+		{name: "single member without load", values: []int64{0}, want: FairnessIndex{MaxMinRatio: 1}},
+		{name: "all zero", values: []int64{0, 0, 0}, want: FairnessIndex{MaxMinRatio: 1}},
+		{name: "equal", values: []int64{3, 3, 3}, want: FairnessIndex{Mean: 3, MaxMinRatio: 1}},
+		{name: "two members", values: []int64{8, 2}, want: FairnessIndex{Mean: 5, StdDev: 3, Gini: 0.3, MaxMinRatio: 4}},
+		{
+			name:   "unsorted",
+			values: []int64{4, 1, 3, 2},
+			want:   FairnessIndex{Mean: 2.5, StdDev: math.Sqrt(1.25), Gini: 0.25, MaxMinRatio: 4},
+		},
+		{
+			name:   "everything on one member",
+			values: []int64{0, 6, 0, 0},
+			want:   FairnessIndex{Mean: 1.5, StdDev: math.Sqrt(6.75), Gini: 0.75, MaxMinRatio: math.Inf(1)},
+		},
+	}
+
+	near := func(a, b float64) bool {
+		return a == b || math.Abs(a-b) < 1e-9
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := slices.Clone(tt.values)
+			got := NewFairnessIndex(tt.values)
+			if !near(got.Mean, tt.want.Mean) || !near(got.StdDev, tt.want.StdDev) ||
+				!near(got.Gini, tt.want.Gini) || !near(got.MaxMinRatio, tt.want.MaxMinRatio) {
+				t.Fatalf("NewFairnessIndex(%v) = %+v, want %+v", tt.values, got, tt.want)
+			}
+			if !slices.Equal(tt.values, input) {
+				t.Fatalf("input was modified: %v, was %v", tt.values, input)
+			}
+		})
+	}
+}
+
+func TestNewTeamFairness(t *testing.T) {
+	members := []ReviewerLoad{
+		{UserID: "u1", IsActive: true, Open: 6, Total: 10},
+		{UserID: "u2", IsActive: true, Open: 2, Total: 10},
+		{UserID: "u3", IsActive: true, Open: 1, Total: 10},
+		{UserID: "u4", IsActive: true, Open: 3, Total: 10},
+		// неактивный не участвует в индексах, но остаётся в списке участников
+		{UserID: "u5", IsActive: false, Open: 0, Total: 0},
+	}
+
+	got := NewTeamFairness("backend", members)
+	if got.TeamName != "backend" || len(got.Members) != len(members) {
+		t.Fatalf("unexpected report header: %+v", got)
+	}
+	// open: 6, 2, 1, 3 — среднее 3, отклонение ≈1.87
+	if got.Open.Index.Mean != 3 || !slices.Equal(got.Open.Overloaded, []string{"u1"}) || !slices.Equal(got.Open.Underused, []string{"u3"}) {
+		t.Fatalf("unexpected open distribution: %+v", got.Open)
+	}
+	if got.Historical.Index.Gini != 0 || got.Historical.Index.MaxMinRatio != 1 ||
+		len(got.Historical.Overloaded) != 0 || len(got.Historical.Underused) != 0 {
+		t.Fatalf("equal history must be perfectly fair: %+v", got.Historical)
+	}
+}
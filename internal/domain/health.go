@@ -0,0 +1,14 @@
+package domain
+
+// HealthCheck результат одной проверки готовности.
+type HealthCheck struct {
+	Name  string
+	OK    bool
+	Error string
+}
+
+// HealthReport итог /readyz: сервис готов, только если прошли все проверки.
+type HealthReport struct {
+	Ready  bool
+	Checks []HealthCheck
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// IdempotentResponse сохранённый ответ, который отдаётся повторно на запрос с тем же Idempotency-Key.
+type IdempotentResponse struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// IdempotencyRecord ключ идемпотентности. Ключи живут в пространстве своего владельца (Scope):
+// одинаковые ключи разных клиентов не пересекаются.
+type IdempotencyRecord struct {
+	Scope       string
+	Key         string
+	RequestHash string              // SHA-256 от метода, пути и тела запроса
+	Response    *IdempotentResponse // nil, пока первый запрос ещё выполняется
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
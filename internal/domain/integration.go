@@ -0,0 +1,83 @@
+package domain
+
+// Provider внешний Git-хостинг, из которого приходят события.
+type Provider string
+
+const (
+	// ProviderGitHub GitHub (github.com или GHE).
+	ProviderGitHub Provider = "github"
+	// ProviderGitLab self-hosted GitLab.
+	ProviderGitLab Provider = "gitlab"
+)
+
+// Valid проверяет, что провайдер известен.
+func (p Provider) Valid() bool {
+	return p == ProviderGitHub || p == ProviderGitLab
+}
+
+// Identity связка логина во внешней системе с нашим user_id.
+type Identity struct {
+	Provider Provider
+	Login    string
+	// ExternalID числовой id пользователя на хостинге; пусто — не привязан.
+	// GitLab присылает автора MR только в виде id, без логина.
+	ExternalID string
+	UserID     string
+}
+
+// ExternalPRAction что произошло с PR во внешней системе.
+type ExternalPRAction string
+
+const (
+	// ExternalPROpened PR открыт (или вышел из драфта) — его пора ревьюить.
+	ExternalPROpened ExternalPRAction = "opened"
+	// ExternalPRMerged PR влит.
+	ExternalPRMerged ExternalPRAction = "merged"
+	// ExternalPRReopened закрытый PR снова открыт.
+	ExternalPRReopened ExternalPRAction = "reopened"
+	// ExternalPRClosed PR закрыт без merge.
+	ExternalPRClosed ExternalPRAction = "closed"
+	// ExternalPRUpdated PR изменён (заголовок, ревьюверы и т.п.).
+	ExternalPRUpdated ExternalPRAction = "updated"
+)
+
+// ExternalPREvent событие по PR, уже приведённое к нашим понятиям.
+type ExternalPREvent struct {
+	Provider    Provider
+	Action      ExternalPRAction
+	PullRequest string // наш pull_request_id, собранный из репозитория и номера PR
+	Title       string
+	AuthorLogin string
+	// AuthorExternalID id автора на хостинге; если задан, автор ищется сначала по нему
+	AuthorExternalID string
+
+	// SyncReviewers — во внешней системе ревьюверов выставили вручную,
+	// и наш список надо привести к ReviewerLogins.
+	SyncReviewers  bool
+	ReviewerLogins []string
+}
+
+// IntegrationOutcome что сервис сделал с внешним событием.
+type IntegrationOutcome string
+
+const (
+	// OutcomeCreated PR создан и ревьюверы назначены.
+	OutcomeCreated IntegrationOutcome = "created"
+	// OutcomeMerged PR переведён в MERGED.
+	OutcomeMerged IntegrationOutcome = "merged"
+	// OutcomeClosed PR закрыт без merge.
+	OutcomeClosed IntegrationOutcome = "closed"
+	// OutcomeReopened закрытый PR снова открыт.
+	OutcomeReopened IntegrationOutcome = "reopened"
+	// OutcomeReviewersSynced ревьюверы приведены к списку из внешней системы.
+	OutcomeReviewersSynced IntegrationOutcome = "reviewers_synced"
+	// OutcomeIgnored событие не требует действий (драфт, неизвестный автор, повтор и т.п.).
+	OutcomeIgnored IntegrationOutcome = "ignored"
+)
+
+// IntegrationResult результат обработки внешнего события.
+type IntegrationResult struct {
+	Outcome       IntegrationOutcome
+	PullRequestID string
+	Reason        string // почему проигнорировано
+}
@@ -6,16 +6,26 @@ import (
 
 // User представляет пользователя системы.
 type User struct {
-	ID       string
-	Username string
-	TeamName string
-	IsActive bool
+	ID          string
+	Username    string
+	TeamName    string
+	IsActive    bool
+	ChatHandle  string // ник в Slack/Mattermost для упоминаний, может быть пустым
+	Email       string // адрес для писем, может быть пустым
+	EmailOptOut EmailOptOut
+}
+
+// EmailOptOut от каких писем пользователь отписался.
+type EmailOptOut struct {
+	Assignments bool // письма о назначении ревьювером
+	Digest      bool // ежедневный дайджест
 }
 
 // Team представляет команду пользователей.
 type Team struct {
-	Name    string
-	Members []User
+	Name           string
+	Members        []User
+	ChatWebhookURL string // incoming-webhook канала команды, может быть пустым
 }
 
 // PRStatus описывает статус pull requestа.
@@ -26,6 +36,8 @@ const (
 	PRStatusOpen PRStatus = "OPEN"
 	// PRStatusMerged означает, что pull request замержен.
 	PRStatusMerged PRStatus = "MERGED"
+	// PRStatusClosed означает, что pull request закрыт без merge.
+	PRStatusClosed PRStatus = "CLOSED"
 )
 
 // PullRequest представляет pull request в репозитории.
@@ -37,4 +49,43 @@ type PullRequest struct {
 	AssignedReviewers []string
 	CreatedAt         time.Time
 	MergedAt          *time.Time
+	ClosedAt          *time.Time
+}
+
+// ReviewVerdict итог ревью от одного ревьювера.
+type ReviewVerdict string
+
+const (
+	// VerdictApproved ревьювер одобрил PR.
+	VerdictApproved ReviewVerdict = "APPROVED"
+	// VerdictChangesRequested ревьювер попросил доработать PR.
+	VerdictChangesRequested ReviewVerdict = "CHANGES_REQUESTED"
+)
+
+// Valid проверяет, что вердикт известен.
+func (v ReviewVerdict) Valid() bool {
+	return v == VerdictApproved || v == VerdictChangesRequested
+}
+
+// ExportFilter отбор строк для выгрузок. Пустые поля не ограничивают выборку.
+// Для PR окно и команда относятся к созданию PR и автору,
+// для назначений — ко времени назначения и команде ревьювера.
+type ExportFilter struct {
+	From     *time.Time
+	To       *time.Time
+	TeamName string
+	Status   PRStatus
+}
+
+// Assignment одно назначение ревьювера на PR.
+type Assignment struct {
+	PullRequestID   string
+	PullRequestName string
+	AuthorID        string
+	Status          PRStatus
+	ReviewerID      string
+	ReviewerTeam    string
+	AssignedAt      time.Time
+	Verdict         ReviewVerdict // пусто, пока ревьювер не высказался
+	VerdictAt       *time.Time
 }
@@ -41,11 +41,19 @@ func (pr PullRequest) IsMerged() bool {
 	return pr.Status == PRStatusMerged
 }
 
+// IsClosed показывает, что PR закрыт без merge.
+func (pr PullRequest) IsClosed() bool {
+	return pr.Status == PRStatusClosed
+}
+
 // CanBeReassigned проверяет, можно ли менять ревьюверов для этого PR.
-// Если нельзя — возвращает доменную ошибку (например, ErrPRMerged).
+// Если нельзя — возвращает доменную ошибку (ErrPRMerged или ErrPRClosed).
 func (pr PullRequest) CanBeReassigned() error {
 	if pr.IsMerged() {
 		return ErrPRMerged
 	}
+	if pr.IsClosed() {
+		return ErrPRClosed
+	}
 	return nil
 }
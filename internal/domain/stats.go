@@ -1,13 +1,149 @@
 package domain
 
+import (
+	"sort"
+	"time"
+)
+
+// StatsGrouping шаг временного ряда в статистике.
+type StatsGrouping string
+
+const (
+	// GroupByNone без разбивки по времени — одна цифра на ключ за весь период.
+	GroupByNone StatsGrouping = ""
+	// GroupByDay разбивка по суткам (UTC).
+	GroupByDay StatsGrouping = "day"
+	// GroupByWeek разбивка по неделям, неделя начинается с понедельника (UTC).
+	GroupByWeek StatsGrouping = "week"
+)
+
+// Valid проверяет, что шаг известен.
+func (g StatsGrouping) Valid() bool {
+	return g == GroupByNone || g == GroupByDay || g == GroupByWeek
+}
+
+// AssignmentStatsFilter отбор назначений для статистики. Пустые поля не ограничивают выборку.
+type AssignmentStatsFilter struct {
+	From     *time.Time // назначения не раньше From
+	To       *time.Time // и строго раньше To
+	TeamName string     // команда ревьювера
+	Status   PRStatus   // статус PR
+	GroupBy  StatsGrouping
+}
+
 // AssignmentStats содержит количество назначений ревьюверу.
 type AssignmentStats struct {
 	ReviewerID string
-	Count      int64
+	// Period начало дня/недели при группировке, nil без неё.
+	Period *time.Time
+	Count  int64
 }
 
 // PullRequestAssignmentStats — количество назначений по PR.
 type PullRequestAssignmentStats struct {
 	PullRequestID string
+	Period        *time.Time
 	Count         int64
 }
+
+// LatencyFilter окно по времени создания PR и команда автора для метрик задержек.
+type LatencyFilter struct {
+	From     *time.Time
+	To       *time.Time
+	TeamName string
+}
+
+// LatencyDimension разрез, по которому считаются задержки.
+type LatencyDimension string
+
+const (
+	// LatencyOverall все PR окна вместе.
+	LatencyOverall LatencyDimension = "overall"
+	// LatencyByTeam по команде автора.
+	LatencyByTeam LatencyDimension = "team"
+	// LatencyByAuthor по автору.
+	LatencyByAuthor LatencyDimension = "author"
+	// LatencyByReviewer по ревьюверу; время до вердикта считается от его назначения.
+	LatencyByReviewer LatencyDimension = "reviewer"
+)
+
+// Percentiles перцентили длительности по выборке из Count значений.
+// При Count == 0 остальные поля нулевые.
+type Percentiles struct {
+	Count int64
+	P50   time.Duration
+	P90   time.Duration
+	P99   time.Duration
+}
+
+// LatencyStats задержки ревью для одного ключа разреза (команды, автора, ревьювера).
+type LatencyStats struct {
+	Dimension          LatencyDimension
+	Key                string // пусто для LatencyOverall
+	TimeToMerge        Percentiles
+	TimeToFirstVerdict Percentiles
+}
+
+// ReviewPairsFilter окно по времени назначения и команда автора для матрицы пар.
+type ReviewPairsFilter struct {
+	From     *time.Time
+	To       *time.Time
+	TeamName string
+}
+
+// ReviewPair сколько раз ревьювер был назначен на PR автора.
+type ReviewPair struct {
+	AuthorID   string
+	ReviewerID string
+	Count      int64
+}
+
+// PairMatrix матрица автор×ревьювер: Counts[i][j] — назначения Reviewers[j] на PR Authors[i].
+type PairMatrix struct {
+	Authors   []string
+	Reviewers []string
+	Counts    [][]int64
+	// Top самые частые пары по убыванию.
+	Top []ReviewPair
+}
+
+// NewPairMatrix строит матрицу из списка пар, отсортированного по убыванию Count.
+// В Top попадают первые topN пар.
+func NewPairMatrix(pairs []ReviewPair, topN int) PairMatrix {
+	authorIdx := make(map[string]int)
+	reviewerIdx := make(map[string]int)
+	m := PairMatrix{
+		Authors:   make([]string, 0),
+		Reviewers: make([]string, 0),
+	}
+
+	for _, p := range pairs {
+		if _, ok := authorIdx[p.AuthorID]; !ok {
+			authorIdx[p.AuthorID] = len(m.Authors)
+			m.Authors = append(m.Authors, p.AuthorID)
+		}
+		if _, ok := reviewerIdx[p.ReviewerID]; !ok {
+			reviewerIdx[p.ReviewerID] = len(m.Reviewers)
+			m.Reviewers = append(m.Reviewers, p.ReviewerID)
+		}
+	}
+	sort.Strings(m.Authors)
+	sort.Strings(m.Reviewers)
+	for i, id := range m.Authors {
+		authorIdx[id] = i
+	}
+	for j, id := range m.Reviewers {
+		reviewerIdx[id] = j
+	}
+
+	m.Counts = make([][]int64, len(m.Authors))
+	for i := range m.Counts {
+		m.Counts[i] = make([]int64, len(m.Reviewers))
+	}
+	for _, p := range pairs {
+		m.Counts[authorIdx[p.AuthorID]][reviewerIdx[p.ReviewerID]] = p.Count
+	}
+
+	m.Top = pairs[:min(topN, len(pairs))]
+	return m
+}
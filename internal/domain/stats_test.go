@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewPairMatrix(t *testing.T) {
+	// как из репозитория: по убыванию числа назначений
+	pairs := []ReviewPair{
+		{AuthorID: "u2", ReviewerID: "u3", Count: 7},
+		{AuthorID: "u1", ReviewerID: "u2", Count: 5},
+		{AuthorID: "u2", ReviewerID: "u1", Count: 2},
+		{AuthorID: "u1", ReviewerID: "u3", Count: 1},
+	}
+
+	tests := []struct {
+		name  string
+		pairs []ReviewPair
+		topN  int
+		want  PairMatrix
+	}{
+		{
+			name:  "layout and top truncation",
+			pairs: pairs,
+			topN:  2,
+			want: PairMatrix{
+				Authors:   []string{"u1", "u2"},
+				Reviewers: []string{"u1", "u2", "u3"},
+				// у u1 нет назначений самого себя, у u2 — на u2: там нули
+				Counts: [][]int64{
+					{0, 5, 1},
+					{2, 0, 7},
+				},
+				Top: pairs[:2],
+			},
+		},
+		{
+			name:  "top larger than pairs",
+			pairs: pairs[:1],
+			topN:  10,
+			want: PairMatrix{
+				Authors:   []string{"u2"},
+				Reviewers: []string{"u3"},
+				Counts:    [][]int64{{7}},
+				Top:       pairs[:1],
+			},
+		},
+		{
+			name:  "zero top keeps matrix",
+			pairs: pairs[:1],
+			topN:  0,
+			want: PairMatrix{
+				Authors:   []string{"u2"},
+				Reviewers: []string{"u3"},
+				Counts:    [][]int64{{7}},
+				Top:       []ReviewPair{},
+			},
+		},
+		{
+			name:  "no pairs",
+			pairs: nil,
+			topN:  10,
+			want: PairMatrix{
+				Authors:   []string{},
+				Reviewers: []string{},
+				Counts:    [][]int64{},
+				Top:       []ReviewPair{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewPairMatrix(tt.pairs, tt.topN)
+			// пустые срезы, а не nil: в JSON они должны быть [], а не null
+			if got.Authors == nil || got.Reviewers == nil || got.Counts == nil {
+				t.Fatalf("nil slices in %+v", got)
+			}
+			if len(got.Top) == 0 && len(tt.want.Top) == 0 {
+				got.Top, tt.want.Top = nil, nil
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("NewPairMatrix() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
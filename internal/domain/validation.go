@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrValidation запрос не прошёл проверку полей. Конкретные поля — в *ValidationError.
+var ErrValidation = errors.New("validation failed")
+
+// FieldError ошибка одного поля запроса. Field — путь в JSON (members[1].user_id)
+// или имя query-параметра.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// ValidationError все найденные ошибки полей сразу, чтобы клиент исправил запрос за один заход.
+// errors.Is(err, ErrValidation) для неё истинно.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		parts = append(parts, f.Field+": "+f.Message)
+	}
+	return ErrValidation.Error() + ": " + strings.Join(parts, "; ")
+}
+
+// Is сводит ValidationError к ErrValidation.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+// WebhookSubscription подписка команды на исходящие вебхуки.
+type WebhookSubscription struct {
+	ID         int64
+	TeamName   string
+	URL        string
+	Secret     string
+	EventTypes []EventType
+	IsActive   bool
+	CreatedAt  time.Time
+}
+
+// DeliveryStatus статус доставки вебхука.
+type DeliveryStatus string
+
+const (
+	// DeliveryPending доставка ждёт (очередной) попытки.
+	DeliveryPending DeliveryStatus = "PENDING"
+	// DeliveryDelivered получатель ответил 2xx.
+	DeliveryDelivered DeliveryStatus = "DELIVERED"
+	// DeliveryDead попытки исчерпаны, доставка в dead-letter.
+	DeliveryDead DeliveryStatus = "DEAD"
+)
+
+// WebhookDelivery одна доставка события одному подписчику.
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID int64
+	EventType      EventType
+	Payload        []byte
+	Status         DeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode int
+	LastError      string
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
+
+// PendingDelivery доставка, взятая в работу, вместе с адресом и секретом подписки.
+type PendingDelivery struct {
+	Delivery WebhookDelivery
+	URL      string
+	Secret   string
+}
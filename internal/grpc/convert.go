@@ -0,0 +1,221 @@
+package grpc
+
+import (
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/validation"
+	reviewerv1 "avi_internship_autumn/pkg/api/reviewer/v1"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func userToProto(u domain.User) *reviewerv1.User {
+	return &reviewerv1.User{
+		UserId:     u.ID,
+		Username:   u.Username,
+		TeamName:   u.TeamName,
+		IsActive:   u.IsActive,
+		ChatHandle: u.ChatHandle,
+		Email:      u.Email,
+		EmailOptOut: &reviewerv1.EmailOptOut{
+			Assignments: u.EmailOptOut.Assignments,
+			Digest:      u.EmailOptOut.Digest,
+		},
+	}
+}
+
+func teamToProto(t domain.Team) *reviewerv1.Team {
+	members := make([]*reviewerv1.User, 0, len(t.Members))
+	for _, m := range t.Members {
+		members = append(members, userToProto(m))
+	}
+	return &reviewerv1.Team{
+		TeamName:       t.Name,
+		Members:        members,
+		ChatWebhookUrl: t.ChatWebhookURL,
+	}
+}
+
+func pullRequestToProto(pr domain.PullRequest) *reviewerv1.PullRequest {
+	return &reviewerv1.PullRequest{
+		PullRequestId:     pr.ID,
+		PullRequestName:   pr.Name,
+		AuthorId:          pr.AuthorID,
+		Status:            statusToProto(pr.Status),
+		AssignedReviewers: pr.AssignedReviewers,
+		CreatedAt:         timestampToProto(&pr.CreatedAt),
+		MergedAt:          timestampToProto(pr.MergedAt),
+		ClosedAt:          timestampToProto(pr.ClosedAt),
+	}
+}
+
+func assignmentToProto(a domain.Assignment) *reviewerv1.Assignment {
+	return &reviewerv1.Assignment{
+		PullRequestId:   a.PullRequestID,
+		PullRequestName: a.PullRequestName,
+		AuthorId:        a.AuthorID,
+		Status:          statusToProto(a.Status),
+		ReviewerId:      a.ReviewerID,
+		ReviewerTeam:    a.ReviewerTeam,
+		AssignedAt:      timestampToProto(&a.AssignedAt),
+		Verdict:         verdictToProto(a.Verdict),
+		VerdictAt:       timestampToProto(a.VerdictAt),
+	}
+}
+
+// как и в HTTP, секрет подписки наружу не отдаём
+func webhookSubscriptionToProto(s domain.WebhookSubscription) *reviewerv1.WebhookSubscription {
+	types := make([]string, 0, len(s.EventTypes))
+	for _, t := range s.EventTypes {
+		types = append(types, string(t))
+	}
+	return &reviewerv1.WebhookSubscription{
+		SubscriptionId: s.ID,
+		TeamName:       s.TeamName,
+		Url:            s.URL,
+		EventTypes:     types,
+		IsActive:       s.IsActive,
+		CreatedAt:      timestampToProto(&s.CreatedAt),
+	}
+}
+
+func eventToProto(be app.BusEvent) *reviewerv1.AssignmentEvent {
+	ev := be.Event
+	t := reviewerv1.AssignmentEventType_ASSIGNMENT_EVENT_TYPE_UNSPECIFIED
+	switch ev.Type {
+	case domain.EventReviewerAssigned:
+		t = reviewerv1.AssignmentEventType_ASSIGNMENT_EVENT_TYPE_ASSIGNED
+	case domain.EventReviewerUnassigned:
+		t = reviewerv1.AssignmentEventType_ASSIGNMENT_EVENT_TYPE_UNASSIGNED
+	}
+	return &reviewerv1.AssignmentEvent{
+		Id:                 be.ID,
+		Type:               t,
+		TeamName:           ev.TeamName,
+		ReviewerId:         ev.ReviewerID,
+		ReplacedReviewerId: ev.ReplacedReviewerID,
+		PullRequest:        pullRequestToProto(ev.PullRequest),
+		OccurredAt:         timestampToProto(&ev.OccurredAt),
+	}
+}
+
+func percentilesToProto(p domain.Percentiles) *reviewerv1.Percentiles {
+	return &reviewerv1.Percentiles{
+		Count: p.Count,
+		P50:   durationpb.New(p.P50),
+		P90:   durationpb.New(p.P90),
+		P99:   durationpb.New(p.P99),
+	}
+}
+
+func loadDistributionToProto(d domain.LoadDistribution) *reviewerv1.LoadDistribution {
+	return &reviewerv1.LoadDistribution{
+		Index: &reviewerv1.FairnessIndex{
+			Mean:        d.Index.Mean,
+			StdDev:      d.Index.StdDev,
+			Gini:        d.Index.Gini,
+			MaxMinRatio: d.Index.MaxMinRatio,
+		},
+		Overloaded: d.Overloaded,
+		Underused:  d.Underused,
+	}
+}
+
+func statusToProto(s domain.PRStatus) reviewerv1.PullRequestStatus {
+	switch s {
+	case domain.PRStatusOpen:
+		return reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN
+	case domain.PRStatusMerged:
+		return reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED
+	case domain.PRStatusClosed:
+		return reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_CLOSED
+	default:
+		return reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+	}
+}
+
+// statusFromProto фильтр по статусу: UNSPECIFIED — без фильтра.
+func statusFromProto(v *validation.Validator, field string, s reviewerv1.PullRequestStatus) domain.PRStatus {
+	switch s {
+	case reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED:
+		return ""
+	case reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN:
+		return domain.PRStatusOpen
+	case reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED:
+		return domain.PRStatusMerged
+	case reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_CLOSED:
+		return domain.PRStatusClosed
+	default:
+		v.Add(field, "must be one of OPEN, MERGED, CLOSED")
+		return ""
+	}
+}
+
+func verdictToProto(verdict domain.ReviewVerdict) reviewerv1.ReviewVerdict {
+	switch verdict {
+	case domain.VerdictApproved:
+		return reviewerv1.ReviewVerdict_REVIEW_VERDICT_APPROVED
+	case domain.VerdictChangesRequested:
+		return reviewerv1.ReviewVerdict_REVIEW_VERDICT_CHANGES_REQUESTED
+	default:
+		return reviewerv1.ReviewVerdict_REVIEW_VERDICT_UNSPECIFIED
+	}
+}
+
+func verdictFromProto(v *validation.Validator, field string, verdict reviewerv1.ReviewVerdict) domain.ReviewVerdict {
+	switch verdict {
+	case reviewerv1.ReviewVerdict_REVIEW_VERDICT_APPROVED:
+		return domain.VerdictApproved
+	case reviewerv1.ReviewVerdict_REVIEW_VERDICT_CHANGES_REQUESTED:
+		return domain.VerdictChangesRequested
+	default:
+		v.Add(field, "must be one of APPROVED, CHANGES_REQUESTED")
+		return ""
+	}
+}
+
+func groupingFromProto(v *validation.Validator, field string, g reviewerv1.StatsGrouping) domain.StatsGrouping {
+	switch g {
+	case reviewerv1.StatsGrouping_STATS_GROUPING_UNSPECIFIED:
+		return domain.GroupByNone
+	case reviewerv1.StatsGrouping_STATS_GROUPING_DAY:
+		return domain.GroupByDay
+	case reviewerv1.StatsGrouping_STATS_GROUPING_WEEK:
+		return domain.GroupByWeek
+	default:
+		v.Add(field, "must be one of DAY, WEEK")
+		return domain.GroupByNone
+	}
+}
+
+// timestampToProto nil для отсутствующего или нулевого времени.
+func timestampToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// timeWindow границы from/to фильтра; to не включается.
+func timeWindow(v *validation.Validator, from, to *timestamppb.Timestamp) (*time.Time, *time.Time) {
+	f := timeFromProto(v, "from", from)
+	t := timeFromProto(v, "to", to)
+	if f != nil && t != nil && !f.Before(*t) {
+		v.Add("to", "must be after from")
+	}
+	return f, t
+}
+
+func timeFromProto(v *validation.Validator, field string, ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		v.Add(field, "must be a valid timestamp")
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
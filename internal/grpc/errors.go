@@ -0,0 +1,90 @@
+// Package grpc gRPC API сервиса для внутренних потребителей: те же app.*Service, что и в HTTP.
+package grpc
+
+import (
+	"avi_internship_autumn/internal/domain"
+	apihttp "avi_internship_autumn/internal/http"
+	"avi_internship_autumn/internal/logging"
+	"context"
+	"errors"
+	"log/slog"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain google.rpc.ErrorInfo.domain: вместе с reason однозначно задаёт ошибку.
+const errorDomain = "pr-reviewer"
+
+// grpcCodes код gRPC для каждого кода ErrorResponse. Доменная ошибка сначала классифицируется
+// тем же apihttp.FromDomainError, что и в HTTP, поэтому reason в ErrorInfo совпадает с error.code.
+var grpcCodes = map[apihttp.ErrorCode]codes.Code{
+	apihttp.CodeValidation:           codes.InvalidArgument,
+	apihttp.CodeTeamExists:           codes.AlreadyExists,
+	apihttp.CodePRExists:             codes.AlreadyExists,
+	apihttp.CodePRMerged:             codes.FailedPrecondition,
+	apihttp.CodePRClosed:             codes.FailedPrecondition,
+	apihttp.CodeNotAssigned:          codes.FailedPrecondition,
+	apihttp.CodeNoCandidate:          codes.FailedPrecondition,
+	apihttp.CodeNotFound:             codes.NotFound,
+	apihttp.CodeInvalidSignature:     codes.Unauthenticated,
+	apihttp.CodeUnauthorized:         codes.Unauthenticated,
+	apihttp.CodeForbidden:            codes.PermissionDenied,
+	apihttp.CodeIdempotencyKeyReused: codes.InvalidArgument,
+	apihttp.CodeIdempotencyKeyInUse:  codes.Aborted,
+	apihttp.CodeRateLimited:          codes.ResourceExhausted,
+	apihttp.CodeOverloaded:           codes.Unavailable,
+	apihttp.CodeInternal:             codes.Internal,
+}
+
+// toStatus переводит ошибку сервиса в статус gRPC. Непредвиденные ошибки клиенту не раскрываются
+// и пишутся в лог с request_id, как WriteError в HTTP.
+func toStatus(ctx context.Context, method string, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	// клиент отменил вызов или истёк его дедлайн — это не сбой сервера
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	httpErr := apihttp.FromDomainError(err)
+	body := httpErr.Body.Error
+	code, ok := grpcCodes[body.Code]
+	if !ok {
+		code = codes.Internal
+	}
+
+	requestID := logging.RequestID(ctx)
+	if code == codes.Internal {
+		slog.ErrorContext(ctx, "rpc failed", "method", method, "error", err)
+	}
+
+	info := &errdetails.ErrorInfo{Reason: string(body.Code), Domain: errorDomain}
+	if requestID != "" {
+		info.Metadata = map[string]string{"request_id": requestID}
+	}
+	st := status.New(code, body.Message)
+	if withDetails, detailsErr := st.WithDetails(append([]protoadapt.MessageV1{info}, fieldViolations(err)...)...); detailsErr == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// fieldViolations ошибки полей для INVALID_ARGUMENT — аналог details в ErrorResponse.
+func fieldViolations(err error) []protoadapt.MessageV1 {
+	var ve *domain.ValidationError
+	if !errors.As(err, &ve) {
+		return nil
+	}
+	br := &errdetails.BadRequest{}
+	for _, f := range ve.Fields {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       f.Field,
+			Description: f.Message,
+		})
+	}
+	return []protoadapt.MessageV1{br}
+}
@@ -0,0 +1,409 @@
+package grpc
+
+import (
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/validation"
+	reviewerv1 "avi_internship_autumn/pkg/api/reviewer/v1"
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Сколько самых частых пар отдаёт GetReviewPairs — как top в /stats/pairs.
+const (
+	defaultTopPairs = 10
+	maxTopPairs     = 100
+)
+
+type prServer struct {
+	reviewerv1.UnimplementedPullRequestServiceServer
+	svc app.PRService
+	bus *app.EventBus
+}
+
+// CreatePullRequest как POST /api/v1/pull-requests.
+func (s *prServer) CreatePullRequest(ctx context.Context, req *reviewerv1.CreatePullRequestRequest) (*reviewerv1.CreatePullRequestResponse, error) {
+	var v validation.Validator
+	v.ID("pull_request_id", req.GetPullRequestId())
+	if v.Required("pull_request_name", req.GetPullRequestName()) {
+		v.MaxLen("pull_request_name", req.GetPullRequestName(), validation.MaxNameLen)
+	}
+	v.ID("author_id", req.GetAuthorId())
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.svc.CreatePR(ctx, req.GetPullRequestId(), req.GetPullRequestName(), req.GetAuthorId())
+	if err != nil {
+		return nil, err
+	}
+	return &reviewerv1.CreatePullRequestResponse{PullRequest: pullRequestToProto(pr)}, nil
+}
+
+// MergePullRequest как POST /api/v1/pull-requests/{pull_request_id}/merge.
+func (s *prServer) MergePullRequest(ctx context.Context, req *reviewerv1.MergePullRequestRequest) (*reviewerv1.MergePullRequestResponse, error) {
+	if err := validatePullRequestID(req.GetPullRequestId()); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.svc.MergePR(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, err
+	}
+	return &reviewerv1.MergePullRequestResponse{PullRequest: pullRequestToProto(pr)}, nil
+}
+
+// ReassignReviewer как POST /api/v1/pull-requests/{pull_request_id}/reassign;
+// кто может снять ревьювера (он сам или тимлид его команды), решает сервис.
+func (s *prServer) ReassignReviewer(ctx context.Context, req *reviewerv1.ReassignReviewerRequest) (*reviewerv1.ReassignReviewerResponse, error) {
+	var v validation.Validator
+	v.ID("pull_request_id", req.GetPullRequestId())
+	v.ID("old_user_id", req.GetOldUserId())
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	pr, replacedBy, err := s.svc.ReassignReviewer(ctx, req.GetPullRequestId(), req.GetOldUserId())
+	if err != nil {
+		return nil, err
+	}
+	return &reviewerv1.ReassignReviewerResponse{PullRequest: pullRequestToProto(pr), ReplacedBy: replacedBy}, nil
+}
+
+// ClosePullRequest закрывает PR без merge; в HTTP это делают только вебхуки Git-хостингов.
+func (s *prServer) ClosePullRequest(ctx context.Context, req *reviewerv1.ClosePullRequestRequest) (*reviewerv1.ClosePullRequestResponse, error) {
+	if err := validatePullRequestID(req.GetPullRequestId()); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.svc.ClosePR(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, err
+	}
+	return &reviewerv1.ClosePullRequestResponse{PullRequest: pullRequestToProto(pr)}, nil
+}
+
+// ReopenPullRequest снова открывает закрытый PR.
+func (s *prServer) ReopenPullRequest(ctx context.Context, req *reviewerv1.ReopenPullRequestRequest) (*reviewerv1.ReopenPullRequestResponse, error) {
+	if err := validatePullRequestID(req.GetPullRequestId()); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.svc.ReopenPR(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, err
+	}
+	return &reviewerv1.ReopenPullRequestResponse{PullRequest: pullRequestToProto(pr)}, nil
+}
+
+// SetReviewers приводит ревьюверов открытого PR к списку.
+func (s *prServer) SetReviewers(ctx context.Context, req *reviewerv1.SetReviewersRequest) (*reviewerv1.SetReviewersResponse, error) {
+	var v validation.Validator
+	v.ID("pull_request_id", req.GetPullRequestId())
+	v.MaxItems("reviewer_ids", len(req.GetReviewerIds()), validation.MaxListItems)
+	for i, id := range req.GetReviewerIds() {
+		v.ID(validation.Index("reviewer_ids", i), id)
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.svc.SetReviewers(ctx, req.GetPullRequestId(), req.GetReviewerIds())
+	if err != nil {
+		return nil, err
+	}
+	return &reviewerv1.SetReviewersResponse{PullRequest: pullRequestToProto(pr)}, nil
+}
+
+// SubmitVerdict как POST /api/v1/pull-requests/{pull_request_id}/reviews.
+func (s *prServer) SubmitVerdict(ctx context.Context, req *reviewerv1.SubmitVerdictRequest) (*reviewerv1.SubmitVerdictResponse, error) {
+	var v validation.Validator
+	v.ID("pull_request_id", req.GetPullRequestId())
+	v.ID("reviewer_id", req.GetReviewerId())
+	verdict := verdictFromProto(&v, "verdict", req.GetVerdict())
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.svc.SubmitVerdict(ctx, req.GetPullRequestId(), req.GetReviewerId(), verdict)
+	if err != nil {
+		return nil, err
+	}
+	return &reviewerv1.SubmitVerdictResponse{PullRequest: pullRequestToProto(pr)}, nil
+}
+
+// GetAssignmentStatsByReviewer как by_reviewer в GET /api/v1/stats/assignments.
+func (s *prServer) GetAssignmentStatsByReviewer(ctx context.Context, req *reviewerv1.GetAssignmentStatsRequest) (*reviewerv1.GetAssignmentStatsByReviewerResponse, error) {
+	f, err := assignmentStatsFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.svc.GetAssignmentStatsByReviewer(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	resp := &reviewerv1.GetAssignmentStatsByReviewerResponse{
+		Stats: make([]*reviewerv1.ReviewerAssignmentStats, 0, len(stats)),
+	}
+	for _, st := range stats {
+		resp.Stats = append(resp.Stats, &reviewerv1.ReviewerAssignmentStats{
+			ReviewerId: st.ReviewerID,
+			Period:     timestampToProto(st.Period),
+			Count:      st.Count,
+		})
+	}
+	return resp, nil
+}
+
+// GetAssignmentStatsByPullRequest как by_pr в GET /api/v1/stats/assignments.
+func (s *prServer) GetAssignmentStatsByPullRequest(ctx context.Context, req *reviewerv1.GetAssignmentStatsRequest) (*reviewerv1.GetAssignmentStatsByPullRequestResponse, error) {
+	f, err := assignmentStatsFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.svc.GetAssignmentStatsByPR(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	resp := &reviewerv1.GetAssignmentStatsByPullRequestResponse{
+		Stats: make([]*reviewerv1.PullRequestAssignmentStats, 0, len(stats)),
+	}
+	for _, st := range stats {
+		resp.Stats = append(resp.Stats, &reviewerv1.PullRequestAssignmentStats{
+			PullRequestId: st.PullRequestID,
+			Period:        timestampToProto(st.Period),
+			Count:         st.Count,
+		})
+	}
+	return resp, nil
+}
+
+// GetLatencyStats как GET /api/v1/stats/latency, все разрезы одним списком.
+func (s *prServer) GetLatencyStats(ctx context.Context, req *reviewerv1.GetLatencyStatsRequest) (*reviewerv1.GetLatencyStatsResponse, error) {
+	var v validation.Validator
+	f := domain.LatencyFilter{TeamName: req.GetTeamName()}
+	v.OptionalID("team_name", f.TeamName)
+	f.From, f.To = timeWindow(&v, req.GetFrom(), req.GetTo())
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	stats, err := s.svc.GetLatencyStats(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	resp := &reviewerv1.GetLatencyStatsResponse{Stats: make([]*reviewerv1.LatencyStats, 0, len(stats))}
+	for _, st := range stats {
+		resp.Stats = append(resp.Stats, &reviewerv1.LatencyStats{
+			Dimension:          string(st.Dimension),
+			Key:                st.Key,
+			TimeToMerge:        percentilesToProto(st.TimeToMerge),
+			TimeToFirstVerdict: percentilesToProto(st.TimeToFirstVerdict),
+		})
+	}
+	return resp, nil
+}
+
+// GetFairnessReport как GET /api/v1/stats/fairness.
+func (s *prServer) GetFairnessReport(ctx context.Context, req *reviewerv1.GetFairnessReportRequest) (*reviewerv1.GetFairnessReportResponse, error) {
+	var v validation.Validator
+	v.OptionalID("team_name", req.GetTeamName())
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	reports, err := s.svc.GetFairnessReport(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, err
+	}
+	resp := &reviewerv1.GetFairnessReportResponse{Teams: make([]*reviewerv1.TeamFairness, 0, len(reports))}
+	for _, t := range reports {
+		members := make([]*reviewerv1.ReviewerLoad, 0, len(t.Members))
+		for _, m := range t.Members {
+			members = append(members, &reviewerv1.ReviewerLoad{
+				UserId:   m.UserID,
+				TeamName: m.TeamName,
+				IsActive: m.IsActive,
+				Open:     m.Open,
+				Total:    m.Total,
+			})
+		}
+		resp.Teams = append(resp.Teams, &reviewerv1.TeamFairness{
+			TeamName:   t.TeamName,
+			Members:    members,
+			Open:       loadDistributionToProto(t.Open),
+			Historical: loadDistributionToProto(t.Historical),
+		})
+	}
+	return resp, nil
+}
+
+// GetReviewPairs как GET /api/v1/stats/pairs.
+func (s *prServer) GetReviewPairs(ctx context.Context, req *reviewerv1.GetReviewPairsRequest) (*reviewerv1.GetReviewPairsResponse, error) {
+	var v validation.Validator
+	f := domain.ReviewPairsFilter{TeamName: req.GetTeamName()}
+	v.OptionalID("team_name", f.TeamName)
+	f.From, f.To = timeWindow(&v, req.GetFrom(), req.GetTo())
+	topN := defaultTopPairs
+	if req.Top != nil {
+		topN = int(req.GetTop())
+		v.Check(topN >= 0 && topN <= maxTopPairs, "top", "must be an integer from 0 to "+strconv.Itoa(maxTopPairs))
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	m, err := s.svc.GetReviewPairs(ctx, f, topN)
+	if err != nil {
+		return nil, err
+	}
+	resp := &reviewerv1.GetReviewPairsResponse{
+		Authors:   m.Authors,
+		Reviewers: m.Reviewers,
+		Rows:      make([]*reviewerv1.PairRow, 0, len(m.Counts)),
+		Top:       make([]*reviewerv1.ReviewPair, 0, len(m.Top)),
+	}
+	for _, row := range m.Counts {
+		resp.Rows = append(resp.Rows, &reviewerv1.PairRow{Counts: row})
+	}
+	for _, p := range m.Top {
+		resp.Top = append(resp.Top, &reviewerv1.ReviewPair{AuthorId: p.AuthorID, ReviewerId: p.ReviewerID, Count: p.Count})
+	}
+	return resp, nil
+}
+
+// ExportPullRequests как GET /api/v1/exports/pull-requests: строки уходят клиенту по мере чтения из БД.
+func (s *prServer) ExportPullRequests(req *reviewerv1.ExportRequest, stream reviewerv1.PullRequestService_ExportPullRequestsServer) error {
+	f, err := exportFilter(req)
+	if err != nil {
+		return err
+	}
+	return s.svc.ExportPullRequests(stream.Context(), f, func(pr domain.PullRequest) error {
+		return stream.Send(pullRequestToProto(pr))
+	})
+}
+
+// ExportAssignments как GET /api/v1/exports/assignments.
+func (s *prServer) ExportAssignments(req *reviewerv1.ExportRequest, stream reviewerv1.PullRequestService_ExportAssignmentsServer) error {
+	f, err := exportFilter(req)
+	if err != nil {
+		return err
+	}
+	return s.svc.ExportAssignments(stream.Context(), f, func(a domain.Assignment) error {
+		return stream.Send(assignmentToProto(a))
+	})
+}
+
+// WatchAssignments отдаёт reviewer.assigned и reviewer.unassigned из шины событий.
+// Заголовки ответа уходят сразу после подписки: получив их, клиент знает, что ничего нового не пропустит.
+// Если клиент не успевает читать или сервер останавливается, стрим завершается с UNAVAILABLE —
+// переподключиться стоит с after_id последнего полученного события.
+func (s *prServer) WatchAssignments(req *reviewerv1.WatchAssignmentsRequest, stream reviewerv1.PullRequestService_WatchAssignmentsServer) error {
+	ctx := stream.Context()
+
+	var v validation.Validator
+	v.OptionalID("team_name", req.GetTeamName())
+	v.OptionalID("reviewer_id", req.GetReviewerId())
+	if err := v.Err(); err != nil {
+		return err
+	}
+	if err := authorizeWatch(ctx, req); err != nil {
+		return err
+	}
+
+	sub := s.bus.Subscribe(req.GetAfterId())
+	defer sub.Close()
+
+	if req.GetAfterId() > 0 && sub.Missed {
+		return status.Error(codes.OutOfRange,
+			"events after after_id are no longer available, resubscribe without after_id")
+	}
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for _, be := range sub.Replay {
+		if err := sendAssignmentEvent(stream, req, be); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case be, ok := <-sub.Events:
+			if !ok {
+				return status.Error(codes.Unavailable, "event stream closed, resubscribe with after_id")
+			}
+			if err := sendAssignmentEvent(stream, req, be); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// authorizeWatch админ видит все события, тимлид — своей команды, user-токен — только свои.
+func authorizeWatch(ctx context.Context, req *reviewerv1.WatchAssignmentsRequest) error {
+	p, ok := app.PrincipalFrom(ctx)
+	switch {
+	case !ok:
+		return domain.ErrForbidden
+	case p.IsAdmin():
+		return nil
+	case req.GetTeamName() != "" && p.LeadsTeam(req.GetTeamName()):
+		return nil
+	case req.GetReviewerId() != "" && p.CanActAs(req.GetReviewerId()):
+		return nil
+	default:
+		return domain.ErrForbidden
+	}
+}
+
+func sendAssignmentEvent(stream reviewerv1.PullRequestService_WatchAssignmentsServer, req *reviewerv1.WatchAssignmentsRequest, be app.BusEvent) error {
+	ev := be.Event
+	if ev.Type != domain.EventReviewerAssigned && ev.Type != domain.EventReviewerUnassigned {
+		return nil
+	}
+	if req.GetTeamName() != "" && ev.TeamName != req.GetTeamName() {
+		return nil
+	}
+	if req.GetReviewerId() != "" && ev.ReviewerID != req.GetReviewerId() {
+		return nil
+	}
+	return stream.Send(eventToProto(be))
+}
+
+func validatePullRequestID(id string) error {
+	var v validation.Validator
+	v.ID("pull_request_id", id)
+	return v.Err()
+}
+
+func assignmentStatsFilter(req *reviewerv1.GetAssignmentStatsRequest) (domain.AssignmentStatsFilter, error) {
+	var v validation.Validator
+	f := domain.AssignmentStatsFilter{
+		TeamName: req.GetTeamName(),
+		Status:   statusFromProto(&v, "status", req.GetStatus()),
+		GroupBy:  groupingFromProto(&v, "group_by", req.GetGroupBy()),
+	}
+	v.OptionalID("team_name", f.TeamName)
+	f.From, f.To = timeWindow(&v, req.GetFrom(), req.GetTo())
+	return f, v.Err()
+}
+
+func exportFilter(req *reviewerv1.ExportRequest) (domain.ExportFilter, error) {
+	var v validation.Validator
+	f := domain.ExportFilter{
+		TeamName: req.GetTeamName(),
+		Status:   statusFromProto(&v, "status", req.GetStatus()),
+	}
+	v.OptionalID("team_name", f.TeamName)
+	f.From, f.To = timeWindow(&v, req.GetFrom(), req.GetTo())
+	return f, v.Err()
+}
@@ -0,0 +1,229 @@
+package grpc
+
+import (
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/logging"
+	reviewerv1 "avi_internship_autumn/pkg/api/reviewer/v1"
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+)
+
+// requestIDMetadata ключ metadata с ID запроса — аналог X-Request-ID.
+const requestIDMetadata = "x-request-id"
+
+// Server gRPC-сервер API вместе с health-сервисом, который снимается при остановке.
+type Server struct {
+	*grpc.Server
+	health *health.Server
+}
+
+// NewServer регистрирует TeamService, UserService, PullRequestService и WebhookService поверх сервисов приложения,
+// а также grpc.health.v1 и reflection. heartbeat — период keepalive-пингов, чтобы прокси
+// не рвали простаивающие стримы WatchAssignments. opts встают в цепочку после request ID,
+// но до аутентификации (трейсинг).
+func NewServer(
+	teamSvc app.TeamService,
+	userSvc app.UserService,
+	prSvc app.PRService,
+	webhookSvc app.WebhookService,
+	tokenSvc app.TokenService,
+	bus *app.EventBus,
+	heartbeat time.Duration,
+	opts ...grpc.ServerOption,
+) *Server {
+	serverOpts := []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{Time: heartbeat}),
+		grpc.ChainUnaryInterceptor(unaryRequestID),
+		grpc.ChainStreamInterceptor(streamRequestID),
+	}
+	serverOpts = append(serverOpts, opts...)
+	serverOpts = append(serverOpts,
+		grpc.ChainUnaryInterceptor(unaryAuth(tokenSvc)),
+		grpc.ChainStreamInterceptor(streamAuth(tokenSvc)),
+	)
+
+	srv := grpc.NewServer(serverOpts...)
+	reviewerv1.RegisterTeamServiceServer(srv, &teamServer{svc: teamSvc})
+	reviewerv1.RegisterUserServiceServer(srv, &userServer{svc: userSvc})
+	reviewerv1.RegisterPullRequestServiceServer(srv, &prServer{svc: prSvc, bus: bus})
+	reviewerv1.RegisterWebhookServiceServer(srv, &webhookServer{svc: webhookSvc})
+
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(srv, hs)
+	reflection.Register(srv)
+
+	return &Server{Server: srv, health: hs}
+}
+
+// Drain переводит health-статус в NOT_SERVING перед остановкой, как /readyz в HTTP.
+func (s *Server) Drain() {
+	s.health.Shutdown()
+}
+
+// access кому открыт метод.
+type access int
+
+const (
+	// accessPublic без токена: health-проверки и reflection.
+	accessPublic access = iota
+	// accessAdmin только admin-токены; так же закрыт любой метод, забытый в methodAccess.
+	accessAdmin
+	// accessUser admin- и user-токены; что запрос про себя, проверяет хендлер или сервис.
+	accessUser
+)
+
+// methodAccess как в роутере HTTP: user-токену открыты своя очередь, переназначение своих ревью,
+// свои вердикты и поток своих назначений, остальное — только admin.
+var methodAccess = map[string]access{
+	reviewerv1.TeamService_CreateTeam_FullMethodName:     accessAdmin,
+	reviewerv1.TeamService_GetTeam_FullMethodName:        accessAdmin,
+	reviewerv1.TeamService_SetChatWebhook_FullMethodName: accessAdmin,
+
+	reviewerv1.UserService_SetIsActive_FullMethodName:      accessAdmin,
+	reviewerv1.UserService_SetChatHandle_FullMethodName:    accessAdmin,
+	reviewerv1.UserService_SetEmailSettings_FullMethodName: accessAdmin,
+	reviewerv1.UserService_GetReview_FullMethodName:        accessUser,
+	reviewerv1.UserService_BulkDeactivate_FullMethodName:   accessAdmin,
+
+	reviewerv1.PullRequestService_CreatePullRequest_FullMethodName:               accessAdmin,
+	reviewerv1.PullRequestService_MergePullRequest_FullMethodName:                accessAdmin,
+	reviewerv1.PullRequestService_ReassignReviewer_FullMethodName:                accessUser,
+	reviewerv1.PullRequestService_ClosePullRequest_FullMethodName:                accessAdmin,
+	reviewerv1.PullRequestService_ReopenPullRequest_FullMethodName:               accessAdmin,
+	reviewerv1.PullRequestService_SetReviewers_FullMethodName:                    accessAdmin,
+	reviewerv1.PullRequestService_SubmitVerdict_FullMethodName:                   accessUser,
+	reviewerv1.PullRequestService_GetAssignmentStatsByReviewer_FullMethodName:    accessAdmin,
+	reviewerv1.PullRequestService_GetAssignmentStatsByPullRequest_FullMethodName: accessAdmin,
+	reviewerv1.PullRequestService_GetLatencyStats_FullMethodName:                 accessAdmin,
+	reviewerv1.PullRequestService_GetFairnessReport_FullMethodName:               accessAdmin,
+	reviewerv1.PullRequestService_GetReviewPairs_FullMethodName:                  accessAdmin,
+	reviewerv1.PullRequestService_ExportPullRequests_FullMethodName:              accessAdmin,
+	reviewerv1.PullRequestService_ExportAssignments_FullMethodName:               accessAdmin,
+	reviewerv1.PullRequestService_WatchAssignments_FullMethodName:                accessUser,
+
+	reviewerv1.WebhookService_SetSubscriptionActive_FullMethodName:    accessAdmin,
+	reviewerv1.WebhookService_RotateSubscriptionSecret_FullMethodName: accessAdmin,
+}
+
+func unaryAuth(tokens app.TokenService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, tokens, info.FullMethod)
+		if err != nil {
+			return nil, toStatus(ctx, info.FullMethod, err)
+		}
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, toStatus(ctx, info.FullMethod, err)
+		}
+		return resp, nil
+	}
+}
+
+func streamAuth(tokens app.TokenService) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), tokens, info.FullMethod)
+		if err != nil {
+			return toStatus(ctx, info.FullMethod, err)
+		}
+		if err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx}); err != nil {
+			return toStatus(ctx, info.FullMethod, err)
+		}
+		return nil
+	}
+}
+
+// authenticate проверяет bearer-токен из metadata authorization и кладёт принципала в context.
+func authenticate(ctx context.Context, tokens app.TokenService, method string) (context.Context, error) {
+	level, ok := methodAccess[method]
+	switch {
+	case ok:
+	case strings.HasPrefix(method, "/grpc.health.v1.") || strings.HasPrefix(method, "/grpc.reflection."):
+		level = accessPublic
+	default:
+		level = accessAdmin
+	}
+	if level == accessPublic {
+		return ctx, nil
+	}
+
+	token, ok := bearerToken(ctx)
+	if !ok {
+		return ctx, domain.ErrUnauthorized
+	}
+	principal, err := tokens.Authenticate(ctx, token)
+	if err != nil {
+		return ctx, err
+	}
+	if level == accessAdmin && !principal.IsAdmin() {
+		return ctx, domain.ErrForbidden
+	}
+	return app.WithPrincipal(ctx, principal), nil
+}
+
+// bearerToken достаёт токен из metadata authorization: Bearer <token>.
+func bearerToken(ctx context.Context) (string, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", false
+	}
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// authorizeUser разрешает действие над userID: админу — над любым, user-токену — только над собой.
+func authorizeUser(ctx context.Context, userID string) error {
+	p, ok := app.PrincipalFrom(ctx)
+	if !ok || !p.CanActAs(userID) {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+func unaryRequestID(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx = withRequestID(ctx)
+	return handler(ctx, req)
+}
+
+func streamRequestID(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := withRequestID(ss.Context())
+	return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+}
+
+// withRequestID берёт x-request-id из metadata или генерирует новый и возвращает его в заголовке ответа.
+func withRequestID(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var id string
+	if values := md.Get(requestIDMetadata); len(values) > 0 {
+		id = values[0]
+	}
+	if !logging.ValidRequestID(id) {
+		id = logging.NewRequestID()
+	}
+	// ошибка только если заголовки уже ушли, а это первый перехватчик
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadata, id))
+	return logging.WithRequestID(ctx, id)
+}
+
+// serverStream ServerStream с подменённым context.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,94 @@
+package grpc
+
+import (
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/validation"
+	reviewerv1 "avi_internship_autumn/pkg/api/reviewer/v1"
+	"context"
+)
+
+type teamServer struct {
+	reviewerv1.UnimplementedTeamServiceServer
+	svc app.TeamService
+}
+
+// CreateTeam как POST /api/v1/teams.
+func (s *teamServer) CreateTeam(ctx context.Context, req *reviewerv1.CreateTeamRequest) (*reviewerv1.CreateTeamResponse, error) {
+	t := req.GetTeam()
+
+	var v validation.Validator
+	v.ID("team.team_name", t.GetTeamName())
+	v.HTTPURL("team.chat_webhook_url", t.GetChatWebhookUrl())
+	v.MaxItems("team.members", len(t.GetMembers()), validation.MaxListItems)
+	seen := make(map[string]int, len(t.GetMembers()))
+	for i, m := range t.GetMembers() {
+		field := validation.Index("team.members", i)
+		v.ID(field+".user_id", m.GetUserId())
+		if first, ok := seen[m.GetUserId()]; ok && m.GetUserId() != "" {
+			v.Add(field+".user_id", "duplicates "+validation.Index("team.members", first)+".user_id")
+		} else {
+			seen[m.GetUserId()] = i
+		}
+		if v.Required(field+".username", m.GetUsername()) {
+			v.MaxLen(field+".username", m.GetUsername(), validation.MaxNameLen)
+		}
+		v.MaxLen(field+".chat_handle", m.GetChatHandle(), validation.MaxNameLen)
+		v.Email(field+".email", m.GetEmail())
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	team := domain.Team{
+		Name:           t.GetTeamName(),
+		Members:        make([]domain.User, 0, len(t.GetMembers())),
+		ChatWebhookURL: t.GetChatWebhookUrl(),
+	}
+	for _, m := range t.GetMembers() {
+		team.Members = append(team.Members, domain.User{
+			ID:         m.GetUserId(),
+			Username:   m.GetUsername(),
+			TeamName:   t.GetTeamName(),
+			IsActive:   m.GetIsActive(),
+			ChatHandle: m.GetChatHandle(),
+			Email:      m.GetEmail(),
+		})
+	}
+
+	created, err := s.svc.CreateTeam(ctx, team)
+	if err != nil {
+		return nil, err
+	}
+	return &reviewerv1.CreateTeamResponse{Team: teamToProto(created)}, nil
+}
+
+// GetTeam как GET /api/v1/teams/{team_name}.
+func (s *teamServer) GetTeam(ctx context.Context, req *reviewerv1.GetTeamRequest) (*reviewerv1.GetTeamResponse, error) {
+	var v validation.Validator
+	v.ID("team_name", req.GetTeamName())
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	team, err := s.svc.GetTeam(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, err
+	}
+	return &reviewerv1.GetTeamResponse{Team: teamToProto(team)}, nil
+}
+
+// SetChatWebhook как PUT /api/v1/teams/{team_name}/chat-webhook.
+func (s *teamServer) SetChatWebhook(ctx context.Context, req *reviewerv1.SetChatWebhookRequest) (*reviewerv1.SetChatWebhookResponse, error) {
+	var v validation.Validator
+	v.ID("team_name", req.GetTeamName())
+	v.HTTPURL("chat_webhook_url", req.GetChatWebhookUrl())
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.svc.SetChatWebhook(ctx, req.GetTeamName(), req.GetChatWebhookUrl()); err != nil {
+		return nil, err
+	}
+	return &reviewerv1.SetChatWebhookResponse{}, nil
+}
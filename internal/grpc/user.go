@@ -0,0 +1,112 @@
+package grpc
+
+import (
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/validation"
+	reviewerv1 "avi_internship_autumn/pkg/api/reviewer/v1"
+	"context"
+)
+
+type userServer struct {
+	reviewerv1.UnimplementedUserServiceServer
+	svc app.UserService
+}
+
+// SetIsActive как PUT /api/v1/users/{user_id}/active.
+func (s *userServer) SetIsActive(ctx context.Context, req *reviewerv1.SetIsActiveRequest) (*reviewerv1.SetIsActiveResponse, error) {
+	var v validation.Validator
+	v.ID("user_id", req.GetUserId())
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.svc.SetIsActive(ctx, req.GetUserId(), req.GetIsActive())
+	if err != nil {
+		return nil, err
+	}
+	return &reviewerv1.SetIsActiveResponse{User: userToProto(user)}, nil
+}
+
+// SetChatHandle как PUT /api/v1/users/{user_id}/chat-handle.
+func (s *userServer) SetChatHandle(ctx context.Context, req *reviewerv1.SetChatHandleRequest) (*reviewerv1.SetChatHandleResponse, error) {
+	var v validation.Validator
+	v.ID("user_id", req.GetUserId())
+	v.MaxLen("chat_handle", req.GetChatHandle(), validation.MaxNameLen)
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.svc.SetChatHandle(ctx, req.GetUserId(), req.GetChatHandle())
+	if err != nil {
+		return nil, err
+	}
+	return &reviewerv1.SetChatHandleResponse{User: userToProto(user)}, nil
+}
+
+// SetEmailSettings как PUT /api/v1/users/{user_id}/email-settings.
+func (s *userServer) SetEmailSettings(ctx context.Context, req *reviewerv1.SetEmailSettingsRequest) (*reviewerv1.SetEmailSettingsResponse, error) {
+	var v validation.Validator
+	v.ID("user_id", req.GetUserId())
+	v.Email("email", req.GetEmail())
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.svc.SetEmailSettings(ctx, req.GetUserId(), req.GetEmail(), domain.EmailOptOut{
+		Assignments: req.GetOptOut().GetAssignments(),
+		Digest:      req.GetOptOut().GetDigest(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &reviewerv1.SetEmailSettingsResponse{User: userToProto(user)}, nil
+}
+
+// GetReview как GET /api/v1/users/{user_id}/reviews: user-токен видит только свою очередь.
+func (s *userServer) GetReview(ctx context.Context, req *reviewerv1.GetReviewRequest) (*reviewerv1.GetReviewResponse, error) {
+	var v validation.Validator
+	v.ID("user_id", req.GetUserId())
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+	if err := authorizeUser(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	prs, err := s.svc.GetReviewPRs(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	resp := &reviewerv1.GetReviewResponse{
+		UserId:       req.GetUserId(),
+		PullRequests: make([]*reviewerv1.PullRequest, 0, len(prs)),
+	}
+	for _, pr := range prs {
+		resp.PullRequests = append(resp.PullRequests, pullRequestToProto(pr))
+	}
+	return resp, nil
+}
+
+// BulkDeactivate как POST /api/v1/teams/{team_name}/users/deactivate.
+func (s *userServer) BulkDeactivate(ctx context.Context, req *reviewerv1.BulkDeactivateRequest) (*reviewerv1.BulkDeactivateResponse, error) {
+	var v validation.Validator
+	v.ID("team_name", req.GetTeamName())
+	v.MaxItems("user_ids", len(req.GetUserIds()), validation.MaxListItems)
+	for i, id := range req.GetUserIds() {
+		v.ID(validation.Index("user_ids", i), id)
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	res, err := s.svc.BulkDeactivateTeam(ctx, req.GetTeamName(), req.GetUserIds())
+	if err != nil {
+		return nil, err
+	}
+	return &reviewerv1.BulkDeactivateResponse{
+		TeamName:             res.TeamName,
+		DeactivatedUsers:     res.DeactivatedUsers,
+		AffectedPullRequests: int64(res.AffectedPRs),
+	}, nil
+}
@@ -0,0 +1,46 @@
+package grpc
+
+import (
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/validation"
+	reviewerv1 "avi_internship_autumn/pkg/api/reviewer/v1"
+	"context"
+)
+
+type webhookServer struct {
+	reviewerv1.UnimplementedWebhookServiceServer
+	svc app.WebhookService
+}
+
+// SetSubscriptionActive как PUT /api/v1/webhooks/{subscription_id}/active.
+func (s *webhookServer) SetSubscriptionActive(ctx context.Context, req *reviewerv1.SetSubscriptionActiveRequest) (*reviewerv1.SetSubscriptionActiveResponse, error) {
+	var v validation.Validator
+	v.Check(req.GetSubscriptionId() > 0, "subscription_id", "must be a positive integer")
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	sub, err := s.svc.SetSubscriptionActive(ctx, req.GetSubscriptionId(), req.GetIsActive())
+	if err != nil {
+		return nil, err
+	}
+	return &reviewerv1.SetSubscriptionActiveResponse{Subscription: webhookSubscriptionToProto(sub)}, nil
+}
+
+// RotateSubscriptionSecret как PUT /api/v1/webhooks/{subscription_id}/secret.
+func (s *webhookServer) RotateSubscriptionSecret(ctx context.Context, req *reviewerv1.RotateSubscriptionSecretRequest) (*reviewerv1.RotateSubscriptionSecretResponse, error) {
+	var v validation.Validator
+	v.Check(req.GetSubscriptionId() > 0, "subscription_id", "must be a positive integer")
+	if v.Required("secret", req.GetSecret()) {
+		v.MaxLen("secret", req.GetSecret(), validation.MaxNameLen)
+	}
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	sub, err := s.svc.RotateSecret(ctx, req.GetSubscriptionId(), req.GetSecret())
+	if err != nil {
+		return nil, err
+	}
+	return &reviewerv1.RotateSubscriptionSecretResponse{Subscription: webhookSubscriptionToProto(sub)}, nil
+}
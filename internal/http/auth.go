@@ -0,0 +1,109 @@
+package http
+
+import (
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/domain"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// access кому открыта ручка.
+type access int
+
+const (
+	// accessPublic без токена: вебхуки Git-хостингов проверяют подпись сами.
+	accessPublic access = iota
+	// accessAdmin только admin-токены.
+	accessAdmin
+	// accessUser admin- и user-токены; что user_id запроса свой, проверяет хендлер (authorizeUser) или сервис.
+	accessUser
+)
+
+// authRealm значение realm в WWW-Authenticate.
+const authRealm = "pr-reviewer"
+
+// authenticate проверяет bearer-токен и scope и кладёт принципала в context.
+// Нет токена или он не подошёл — 401 с WWW-Authenticate, мало прав — 403.
+func authenticate(tokens app.TokenService, level access, next http.Handler) http.Handler {
+	if level == accessPublic {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := bearerToken(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+authRealm+`"`)
+			WriteError(w, r, domain.ErrUnauthorized)
+			return
+		}
+
+		principal, err := tokens.Authenticate(r.Context(), token)
+		if err != nil {
+			if errors.Is(err, domain.ErrUnauthorized) {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="`+authRealm+`", error="invalid_token"`)
+			}
+			WriteError(w, r, err)
+			return
+		}
+
+		if level == accessAdmin && !principal.IsAdmin() {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+authRealm+`", error="insufficient_scope", scope="admin"`)
+			WriteError(w, r, domain.ErrForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(app.WithPrincipal(r.Context(), principal)))
+	})
+}
+
+// bearerToken достаёт токен из Authorization: Bearer <token>; схема регистронезависима (RFC 7235).
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// authorizeUser разрешает действие над userID: админу — над любым, user-токену — только над собой.
+func authorizeUser(r *http.Request, userID string) error {
+	p, ok := app.PrincipalFrom(r.Context())
+	if !ok || !p.CanActAs(userID) {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// clientKey кто делает запрос — пространство Idempotency-Key и ключ лимитов: у выпущенного токена —
+// его ID, у JWT — sub, у бутстрап-токена — admin, без аутентификации — IP клиента.
+func clientKey(r *http.Request) string {
+	p, ok := app.PrincipalFrom(r.Context())
+	switch {
+	case !ok:
+		return ipKey(r)
+	case p.TokenID != 0:
+		return "token:" + strconv.FormatInt(p.TokenID, 10)
+	case p.UserID != "":
+		return "user:" + p.UserID
+	default:
+		return "admin"
+	}
+}
+
+// ipKey ключ лимита по адресу соединения, пока принципал ещё не известен.
+func ipKey(r *http.Request) string {
+	return "ip:" + clientIP(r)
+}
+
+// clientIP адрес TCP-соединения. X-Forwarded-For не учитывается: его может подставить любой клиент.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
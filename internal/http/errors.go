@@ -3,8 +3,10 @@ package http
 
 import (
 	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/logging"
 	"encoding/json"
 	"errors"
+	"log/slog"
 	"net/http"
 )
 
@@ -18,18 +20,46 @@ const (
 	CodePRExists ErrorCode = "PR_EXISTS"
 	// CodePRMerged - Pull Request уже смержен
 	CodePRMerged ErrorCode = "PR_MERGED"
+	// CodePRClosed - Pull Request закрыт без merge
+	CodePRClosed ErrorCode = "PR_CLOSED"
 	// CodeNotAssigned - Ревьюер не назначен
 	CodeNotAssigned ErrorCode = "NOT_ASSIGNED"
 	// CodeNoCandidate - Нет доступного активного кандидата
 	CodeNoCandidate ErrorCode = "NO_CANDIDATE"
 	// CodeNotFound - Нет такого ресурса
 	CodeNotFound ErrorCode = "NOT_FOUND"
+	// CodeInvalidSignature - Подпись входящего вебхука не прошла проверку
+	CodeInvalidSignature ErrorCode = "INVALID_SIGNATURE"
+	// CodeValidation - Запрос не прошёл проверку полей, подробности в details
+	CodeValidation ErrorCode = "VALIDATION_ERROR"
+	// CodeUnauthorized - Нет bearer-токена, либо он неизвестен или отозван
+	CodeUnauthorized ErrorCode = "UNAUTHORIZED"
+	// CodeForbidden - Scope токена не разрешает операцию
+	CodeForbidden ErrorCode = "FORBIDDEN"
+	// CodeIdempotencyKeyReused - Idempotency-Key уже использован с другим запросом
+	CodeIdempotencyKeyReused ErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	// CodeIdempotencyKeyInUse - Запрос с этим Idempotency-Key ещё выполняется
+	CodeIdempotencyKeyInUse ErrorCode = "IDEMPOTENCY_KEY_IN_USE"
+	// CodeRateLimited - Клиент превысил лимит запросов, ждать Retry-After секунд
+	CodeRateLimited ErrorCode = "RATE_LIMITED"
+	// CodeOverloaded - Сервер перегружен и сбрасывает запросы, повторить через Retry-After
+	CodeOverloaded ErrorCode = "OVERLOADED"
+	// CodeInternal - Непредвиденная ошибка сервера, причина только в логах по request_id
+	CodeInternal ErrorCode = "INTERNAL_ERROR"
 )
 
 // структура под ErrorResponse из openapi.yml
 type errorBody struct {
-	Code    ErrorCode `json:"code"`
-	Message string    `json:"message"`
+	Code      ErrorCode       `json:"code"`
+	Message   string          `json:"message"`
+	Details   []fieldErrorDTO `json:"details,omitempty"`
+	RequestID string          `json:"request_id,omitempty"`
+}
+
+// fieldErrorDTO ошибка одного поля в details для VALIDATION_ERROR
+type fieldErrorDTO struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 // ErrorResponse структура сообщения об ошибке
@@ -46,6 +76,17 @@ type ErrorHTTP struct {
 // FromDomainError из ошибки домена генерируем ответ
 func FromDomainError(err error) *ErrorHTTP {
 	switch {
+	case errors.Is(err, domain.ErrValidation):
+		return &ErrorHTTP{
+			Status: http.StatusBadRequest, // 400
+			Body: &ErrorResponse{
+				Error: errorBody{
+					Code:    CodeValidation,
+					Message: "request validation failed",
+					Details: fieldErrorsToDTO(err),
+				},
+			},
+		}
 	case errors.Is(err, domain.ErrTeamExists):
 		return &ErrorHTTP{
 			Status: http.StatusBadRequest, // 400
@@ -76,6 +117,16 @@ func FromDomainError(err error) *ErrorHTTP {
 				},
 			},
 		}
+	case errors.Is(err, domain.ErrPRClosed):
+		return &ErrorHTTP{
+			Status: http.StatusConflict, // 409
+			Body: &ErrorResponse{
+				Error: errorBody{
+					Code:    CodePRClosed,
+					Message: "cannot reassign on closed PR",
+				},
+			},
+		}
 	case errors.Is(err, domain.ErrNotAssigned):
 		return &ErrorHTTP{
 			Status: http.StatusConflict, // 409
@@ -106,24 +157,135 @@ func FromDomainError(err error) *ErrorHTTP {
 				},
 			},
 		}
+	case errors.Is(err, domain.ErrInvalidSignature):
+		return &ErrorHTTP{
+			Status: http.StatusUnauthorized, // 401
+			Body: &ErrorResponse{
+				Error: errorBody{
+					Code:    CodeInvalidSignature,
+					Message: "webhook signature verification failed",
+				},
+			},
+		}
+	case errors.Is(err, domain.ErrUnauthorized):
+		return &ErrorHTTP{
+			Status: http.StatusUnauthorized, // 401
+			Body: &ErrorResponse{
+				Error: errorBody{
+					Code:    CodeUnauthorized,
+					Message: "missing, unknown or revoked bearer token",
+				},
+			},
+		}
+	case errors.Is(err, domain.ErrForbidden):
+		return &ErrorHTTP{
+			Status: http.StatusForbidden, // 403
+			Body: &ErrorResponse{
+				Error: errorBody{
+					Code:    CodeForbidden,
+					Message: "token scope does not allow this operation",
+				},
+			},
+		}
+	case errors.Is(err, domain.ErrIdempotencyMismatch):
+		return &ErrorHTTP{
+			Status: http.StatusUnprocessableEntity, // 422
+			Body: &ErrorResponse{
+				Error: errorBody{
+					Code:    CodeIdempotencyKeyReused,
+					Message: "Idempotency-Key was already used with a different request",
+				},
+			},
+		}
+	case errors.Is(err, domain.ErrIdempotencyInProgress):
+		return &ErrorHTTP{
+			Status: http.StatusConflict, // 409
+			Body: &ErrorResponse{
+				Error: errorBody{
+					Code:    CodeIdempotencyKeyInUse,
+					Message: "a request with this Idempotency-Key is still in progress",
+				},
+			},
+		}
+	case errors.Is(err, domain.ErrRateLimited):
+		return &ErrorHTTP{
+			Status: http.StatusTooManyRequests, // 429
+			Body: &ErrorResponse{
+				Error: errorBody{
+					Code:    CodeRateLimited,
+					Message: "rate limit exceeded, retry after Retry-After seconds",
+				},
+			},
+		}
+	case errors.Is(err, domain.ErrOverloaded):
+		return &ErrorHTTP{
+			Status: http.StatusServiceUnavailable, // 503
+			Body: &ErrorResponse{
+				Error: errorBody{
+					Code:    CodeOverloaded,
+					Message: "server is overloaded, retry after Retry-After seconds",
+				},
+			},
+		}
 	default:
-		// Неописанная ошибка будет возвращать 500 без тела
+		// Неописанная ошибка: текст не раскрываем, клиенту остаётся request_id для обращения
 		return &ErrorHTTP{
-			Status: http.StatusInternalServerError,
-			Body:   nil,
+			Status: http.StatusInternalServerError, // 500
+			Body: &ErrorResponse{
+				Error: errorBody{
+					Code:    CodeInternal,
+					Message: "internal server error",
+				},
+			},
 		}
 	}
 }
 
-// WriteError утилита для хендлеров
-func WriteError(w http.ResponseWriter, err error) {
+func fieldErrorsToDTO(err error) []fieldErrorDTO {
+	var ve *domain.ValidationError
+	if !errors.As(err, &ve) {
+		return nil
+	}
+	details := make([]fieldErrorDTO, 0, len(ve.Fields))
+	for _, f := range ve.Fields {
+		details = append(details, fieldErrorDTO{Field: f.Field, Message: f.Message})
+	}
+	return details
+}
+
+// WriteError утилита для хендлеров. Непредвиденные ошибки (INTERNAL_ERROR) клиенту не раскрываются,
+// поэтому пишутся в лог вместе с request_id запроса; тот же ID уходит в теле ответа.
+// Сброс нагрузки (503 OVERLOADED) в лог не идёт: под нагрузкой он бы его и затопил.
+// С Accept: application/problem+json тело — RFC 7807, иначе ErrorResponse.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
 	httpErr := FromDomainError(err)
 
-	if httpErr.Body == nil {
+	ctx := r.Context()
+	requestID := logging.RequestID(ctx)
+	if requestID == "" {
+		// роутер смонтирован без logging.Middleware (тесты, чужой mux) — ID для корреляции выдаём сами
+		requestID = logging.NewRequestID()
+		ctx = logging.WithRequestID(ctx, requestID)
+		w.Header().Set(logging.RequestIDHeader, requestID)
+	}
+
+	if httpErr.Body.Error.Code == CodeInternal {
+		slog.ErrorContext(ctx, "request failed",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", httpErr.Status,
+			"error", err,
+		)
+	}
+
+	if acceptsProblem(r) {
+		w.Header().Set("Content-Type", problemContentType)
 		w.WriteHeader(httpErr.Status)
+		_ = json.NewEncoder(w).Encode(httpErr.Problem(r.URL.Path, requestID))
 		return
 	}
 
+	httpErr.Body.Error.RequestID = requestID
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(httpErr.Status)
 	_ = json.NewEncoder(w).Encode(httpErr.Body)
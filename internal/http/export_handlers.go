@@ -0,0 +1,280 @@
+package http
+
+import (
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/validation"
+	"encoding/csv"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// сбрасываем буфер клиенту каждые N строк, чтобы выгрузка шла потоком
+const exportFlushEvery = 500
+
+type exportFormat string
+
+const (
+	exportCSV    exportFormat = "csv"
+	exportNDJSON exportFormat = "ndjson"
+)
+
+// negotiateExportFormat берёт формат из параметра format, иначе из Accept. По умолчанию CSV.
+func negotiateExportFormat(v *validation.Validator, r *http.Request) exportFormat {
+	switch f := r.URL.Query().Get("format"); f {
+	case "csv":
+		return exportCSV
+	case "ndjson", "jsonl":
+		return exportNDJSON
+	case "":
+	default:
+		v.Add("format", "must be one of csv, ndjson, jsonl")
+		return ""
+	}
+
+	accept := r.Header.Get("Accept")
+	if strings.Contains(accept, "application/x-ndjson") || strings.Contains(accept, "application/jsonl") {
+		return exportNDJSON
+	}
+	return exportCSV
+}
+
+// exportWriter пишет строки выгрузки в CSV (колонки) или NDJSON (объекты).
+type exportWriter struct {
+	format exportFormat
+	rc     *http.ResponseController
+	csv    *csv.Writer
+	json   *json.Encoder
+	rows   int
+}
+
+// startExport пишет заголовки ответа и, для CSV, строку с названиями колонок.
+func startExport(w http.ResponseWriter, format exportFormat, name string, columns []string) *exportWriter {
+	rc := http.NewResponseController(w)
+	// большие выгрузки не укладываются в HTTP_WRITE_TIMEOUT; отвалившийся клиент отменит r.Context()
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	ew := &exportWriter{format: format, rc: rc}
+	if format == exportNDJSON {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+name+`.ndjson"`)
+		w.WriteHeader(http.StatusOK)
+		ew.json = json.NewEncoder(w)
+		return ew
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+name+`.csv"`)
+	w.WriteHeader(http.StatusOK)
+	ew.csv = csv.NewWriter(w)
+	_ = ew.csv.Write(columns)
+	return ew
+}
+
+// Write пишет одну строку: record для CSV, obj для NDJSON.
+func (e *exportWriter) Write(record []string, obj any) error {
+	var err error
+	if e.format == exportNDJSON {
+		err = e.json.Encode(obj)
+	} else {
+		err = e.csv.Write(record)
+	}
+	if err != nil {
+		return err
+	}
+
+	e.rows++
+	if e.rows%exportFlushEvery == 0 {
+		return e.flush()
+	}
+	return nil
+}
+
+func (e *exportWriter) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	return e.rc.Flush()
+}
+
+// Finish дописывает буфер. Если выгрузка оборвалась на середине, статус 200 уже ушёл —
+// рвём соединение, чтобы клиент не принял обрезанный файл за целый.
+func (e *exportWriter) Finish(err error) {
+	if err == nil {
+		err = e.flush()
+	}
+	if err != nil {
+		panic(http.ErrAbortHandler)
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// parseExportFilter разбирает from/to/team/status, как у /stats/assignments.
+func parseExportFilter(v *validation.Validator, r *http.Request) domain.ExportFilter {
+	q := r.URL.Query()
+	f := domain.ExportFilter{
+		TeamName: q.Get("team"),
+		Status:   domain.PRStatus(q.Get("status")),
+	}
+
+	validateStatusParam(v, f.Status)
+	f.From, f.To = parseTimeWindow(v, q)
+	return f
+}
+
+var pullRequestExportColumns = []string{
+	"pull_request_id", "pull_request_name", "author_id", "status",
+	"assigned_reviewers", "created_at", "merged_at", "closed_at",
+}
+
+// ExportPullRequests GET /export/pullRequests?from=...&to=...&team=...&status=...&format=csv|ndjson
+func (h *PRHandler) ExportPullRequests(w http.ResponseWriter, r *http.Request) {
+	var v validation.Validator
+	format := negotiateExportFormat(&v, r)
+	filter := parseExportFilter(&v, r)
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	var ew *exportWriter
+	err := h.svc.ExportPullRequests(r.Context(), filter, func(pr domain.PullRequest) error {
+		if ew == nil {
+			ew = startExport(w, format, "pull_requests", pullRequestExportColumns)
+		}
+		created := pr.CreatedAt
+		return ew.Write([]string{
+			pr.ID,
+			pr.Name,
+			pr.AuthorID,
+			string(pr.Status),
+			strings.Join(pr.AssignedReviewers, ";"),
+			formatTime(&created),
+			formatTime(pr.MergedAt),
+			formatTime(pr.ClosedAt),
+		}, pullRequestToDTO(pr))
+	})
+	finishExport(w, r, ew, format, "pull_requests", pullRequestExportColumns, err)
+}
+
+type assignmentExportDTO struct {
+	PullRequestID   string     `json:"pull_request_id"`
+	PullRequestName string     `json:"pull_request_name"`
+	AuthorID        string     `json:"author_id"`
+	Status          string     `json:"status"`
+	ReviewerID      string     `json:"reviewer_id"`
+	ReviewerTeam    string     `json:"reviewer_team"`
+	AssignedAt      time.Time  `json:"assignedAt"`
+	Verdict         string     `json:"verdict,omitempty"`
+	VerdictAt       *time.Time `json:"verdictAt,omitempty"`
+}
+
+var assignmentExportColumns = []string{
+	"pull_request_id", "pull_request_name", "author_id", "status",
+	"reviewer_id", "reviewer_team", "assigned_at", "verdict", "verdict_at",
+}
+
+// ExportAssignments GET /export/assignments?from=...&to=...&team=...&status=...&format=csv|ndjson
+func (h *PRHandler) ExportAssignments(w http.ResponseWriter, r *http.Request) {
+	var v validation.Validator
+	format := negotiateExportFormat(&v, r)
+	filter := parseExportFilter(&v, r)
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	var ew *exportWriter
+	err := h.svc.ExportAssignments(r.Context(), filter, func(a domain.Assignment) error {
+		if ew == nil {
+			ew = startExport(w, format, "assignments", assignmentExportColumns)
+		}
+		return ew.Write([]string{
+			a.PullRequestID,
+			a.PullRequestName,
+			a.AuthorID,
+			string(a.Status),
+			a.ReviewerID,
+			a.ReviewerTeam,
+			formatTime(&a.AssignedAt),
+			string(a.Verdict),
+			formatTime(a.VerdictAt),
+		}, assignmentExportDTO{
+			PullRequestID:   a.PullRequestID,
+			PullRequestName: a.PullRequestName,
+			AuthorID:        a.AuthorID,
+			Status:          string(a.Status),
+			ReviewerID:      a.ReviewerID,
+			ReviewerTeam:    a.ReviewerTeam,
+			AssignedAt:      a.AssignedAt.UTC(),
+			Verdict:         string(a.Verdict),
+			VerdictAt:       a.VerdictAt,
+		})
+	})
+	finishExport(w, r, ew, format, "assignments", assignmentExportColumns, err)
+}
+
+var statsExportColumns = []string{"user_id", "period", "assignments"}
+
+// ExportStats GET /export/stats — статистика назначений по ревьюверам с теми же фильтрами, что /stats/assignments.
+func (h *PRHandler) ExportStats(w http.ResponseWriter, r *http.Request) {
+	var v validation.Validator
+	format := negotiateExportFormat(&v, r)
+	filter := parseAssignmentStatsFilter(&v, r.URL.Query())
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	// агрегаты небольшие (ревьюверы × периоды), их можно получить целиком
+	stats, err := h.svc.GetAssignmentStatsByReviewer(r.Context(), filter)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	ew := startExport(w, format, "assignment_stats", statsExportColumns)
+	for _, s := range stats {
+		err = ew.Write([]string{
+			s.ReviewerID,
+			formatTime(s.Period),
+			strconv.FormatInt(s.Count, 10),
+		}, assignmentStatsDTO{
+			UserID:      s.ReviewerID,
+			Period:      s.Period,
+			Assignments: s.Count,
+		})
+		if err != nil {
+			break
+		}
+	}
+	ew.Finish(err)
+}
+
+// finishExport завершает выгрузку. Если ни одной строки не было, ответ ещё не начат:
+// ошибку можно отдать обычным образом, а пустую выгрузку — с заголовком CSV.
+func finishExport(w http.ResponseWriter, r *http.Request, ew *exportWriter, format exportFormat, name string, columns []string, err error) {
+	if ew == nil {
+		if err != nil {
+			WriteError(w, r, err)
+			return
+		}
+		ew = startExport(w, format, name, columns)
+	}
+	if err != nil {
+		slog.ErrorContext(r.Context(), "export aborted", "export", name, "error", err)
+	}
+	ew.Finish(err)
+}
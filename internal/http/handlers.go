@@ -3,15 +3,19 @@ package http
 import (
 	"avi_internship_autumn/internal/app"
 	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/validation"
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"time"
 )
 
 type teamMemberDTO struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID     string `json:"user_id"`
+	Username   string `json:"username"`
+	IsActive   bool   `json:"is_active"`
+	ChatHandle string `json:"chat_handle,omitempty"`
+	Email      string `json:"email,omitempty"`
 }
 
 type teamDTO struct {
@@ -20,20 +24,31 @@ type teamDTO struct {
 }
 
 type assignmentStatsDTO struct {
-	UserID      string `json:"user_id"`
-	Assignments int64  `json:"assignments"`
+	UserID      string     `json:"user_id"`
+	Period      *time.Time `json:"period,omitempty"`
+	Assignments int64      `json:"assignments"`
 }
 
 type assignmentStatsPRDTO struct {
-	PullRequestID string `json:"pull_request_id"`
-	Assignments   int64  `json:"assignments"`
+	PullRequestID string     `json:"pull_request_id"`
+	Period        *time.Time `json:"period,omitempty"`
+	Assignments   int64      `json:"assignments"`
 }
 
 type userDTO struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID     string `json:"user_id"`
+	Username   string `json:"username"`
+	TeamName   string `json:"team_name"`
+	IsActive   bool   `json:"is_active"`
+	ChatHandle string `json:"chat_handle,omitempty"`
+	Email      string `json:"email,omitempty"`
+}
+
+type emailSettingsDTO struct {
+	UserID            string `json:"user_id"`
+	Email             string `json:"email"`
+	OptOutAssignments bool   `json:"opt_out_assignments"`
+	OptOutDailyDigest bool   `json:"opt_out_daily_digest"`
 }
 
 type pullRequestDTO struct {
@@ -44,6 +59,7 @@ type pullRequestDTO struct {
 	AssignedReviewers []string   `json:"assigned_reviewers"`
 	CreatedAt         *time.Time `json:"createdAt,omitempty"`
 	MergedAt          *time.Time `json:"mergedAt,omitempty"`
+	ClosedAt          *time.Time `json:"closedAt,omitempty"`
 }
 
 type pullRequestShortDTO struct {
@@ -57,9 +73,11 @@ func teamToDTO(t domain.Team) teamDTO {
 	members := make([]teamMemberDTO, 0, len(t.Members))
 	for _, m := range t.Members {
 		members = append(members, teamMemberDTO{
-			UserID:   m.ID,
-			Username: m.Username,
-			IsActive: m.IsActive,
+			UserID:     m.ID,
+			Username:   m.Username,
+			IsActive:   m.IsActive,
+			ChatHandle: m.ChatHandle,
+			Email:      m.Email,
 		})
 	}
 	return teamDTO{
@@ -68,6 +86,17 @@ func teamToDTO(t domain.Team) teamDTO {
 	}
 }
 
+func userToDTO(u domain.User) userDTO {
+	return userDTO{
+		UserID:     u.ID,
+		Username:   u.Username,
+		TeamName:   u.TeamName,
+		IsActive:   u.IsActive,
+		ChatHandle: u.ChatHandle,
+		Email:      u.Email,
+	}
+}
+
 func pullRequestToDTO(pr domain.PullRequest) pullRequestDTO {
 	dto := pullRequestDTO{
 		PullRequestID:     pr.ID,
@@ -84,6 +113,9 @@ func pullRequestToDTO(pr domain.PullRequest) pullRequestDTO {
 	if pr.MergedAt != nil && !pr.MergedAt.IsZero() {
 		dto.MergedAt = pr.MergedAt
 	}
+	if pr.ClosedAt != nil && !pr.ClosedAt.IsZero() {
+		dto.ClosedAt = pr.ClosedAt
+	}
 
 	return dto
 }
@@ -107,35 +139,62 @@ func NewTeamHandler(svc app.TeamService) *TeamHandler {
 	return &TeamHandler{svc: svc}
 }
 
-// AddTeam POST /team/add
+// AddTeam POST /api/v1/teams (legacy POST /team/add)
 func (h *TeamHandler) AddTeam(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		TeamName string          `json:"team_name"`
-		Members  []teamMemberDTO `json:"members"`
+		TeamName       string          `json:"team_name"`
+		Members        []teamMemberDTO `json:"members"`
+		ChatWebhookURL string          `json:"chat_webhook_url"`
+	}
+
+	if !decodeBody(w, r, &req) {
+		return
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+	var v validation.Validator
+	v.ID("team_name", req.TeamName)
+	v.HTTPURL("chat_webhook_url", req.ChatWebhookURL)
+	v.MaxItems("members", len(req.Members), validation.MaxListItems)
+	seen := make(map[string]int, len(req.Members))
+	for i, m := range req.Members {
+		field := validation.Index("members", i)
+		v.ID(field+".user_id", m.UserID)
+		if first, ok := seen[m.UserID]; ok && m.UserID != "" {
+			v.Add(field+".user_id", "duplicates "+validation.Index("members", first)+".user_id")
+		} else {
+			seen[m.UserID] = i
+		}
+		if v.Required(field+".username", m.Username) {
+			v.MaxLen(field+".username", m.Username, validation.MaxNameLen)
+		}
+		v.MaxLen(field+".chat_handle", m.ChatHandle, validation.MaxNameLen)
+		v.Email(field+".email", m.Email)
+	}
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
 		return
 	}
 
 	team := domain.Team{
-		Name:    req.TeamName,
-		Members: make([]domain.User, 0, len(req.Members)),
+		Name:           req.TeamName,
+		Members:        make([]domain.User, 0, len(req.Members)),
+		ChatWebhookURL: req.ChatWebhookURL,
 	}
 
 	for _, m := range req.Members {
 		team.Members = append(team.Members, domain.User{
-			ID:       m.UserID,
-			Username: m.Username,
-			TeamName: req.TeamName,
-			IsActive: m.IsActive,
+			ID:         m.UserID,
+			Username:   m.Username,
+			TeamName:   req.TeamName,
+			IsActive:   m.IsActive,
+			ChatHandle: m.ChatHandle,
+			Email:      m.Email,
 		})
 	}
 
 	created, err := h.svc.CreateTeam(r.Context(), team)
 	if err != nil {
-		WriteError(w, err)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -150,17 +209,19 @@ func (h *TeamHandler) AddTeam(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
-// GetTeam GET /team/get?team_name=...
+// GetTeam GET /api/v1/teams/{team_name} (legacy GET /team/{team_name}, GET /team/get?team_name=...)
 func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
-	teamName := r.URL.Query().Get("team_name")
-	if teamName == "" {
-		w.WriteHeader(http.StatusBadRequest)
+	teamName := pathOrQuery(r, "team_name")
+	var v validation.Validator
+	v.ID("team_name", teamName)
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
 		return
 	}
 
 	team, err := h.svc.GetTeam(r.Context(), teamName)
 	if err != nil {
-		WriteError(w, err)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -171,6 +232,93 @@ func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
+// TeamUsers GET /api/v1/teams/{team_name}/users?is_active=true|false (legacy GET /team/{team_name}/users) —
+// плоский список участников без лишних полей.
+func (h *TeamHandler) TeamUsers(w http.ResponseWriter, r *http.Request) {
+	teamName := pathOrQuery(r, "team_name")
+	var v validation.Validator
+	v.ID("team_name", teamName)
+
+	var onlyActive *bool
+	if raw := r.URL.Query().Get("is_active"); raw != "" {
+		b, err := strconv.ParseBool(raw)
+		v.Check(err == nil, "is_active", "must be true or false")
+		onlyActive = &b
+	}
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	team, err := h.svc.GetTeam(r.Context(), teamName)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	type teamUserDTO struct {
+		UserID   string `json:"user_id"`
+		Username string `json:"username"`
+		IsActive bool   `json:"is_active"`
+	}
+	resp := struct {
+		TeamName string        `json:"team_name"`
+		Users    []teamUserDTO `json:"users"`
+	}{
+		TeamName: team.Name,
+		Users:    make([]teamUserDTO, 0, len(team.Members)),
+	}
+	for _, m := range team.Members {
+		if onlyActive != nil && m.IsActive != *onlyActive {
+			continue
+		}
+		resp.Users = append(resp.Users, teamUserDTO{UserID: m.ID, Username: m.Username, IsActive: m.IsActive})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// SetChatWebhook PUT /api/v1/teams/{team_name}/chat-webhook (legacy POST /team/setChatWebhook)
+func (h *TeamHandler) SetChatWebhook(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TeamName       string `json:"team_name"`
+		ChatWebhookURL string `json:"chat_webhook_url"`
+	}
+
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	bindPath(r, "team_name", &req.TeamName)
+
+	var v validation.Validator
+	v.ID("team_name", req.TeamName)
+	// пустой url отключает уведомления
+	v.HTTPURL("chat_webhook_url", req.ChatWebhookURL)
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	if err := h.svc.SetChatWebhook(r.Context(), req.TeamName, req.ChatWebhookURL); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	resp := struct {
+		TeamName          string `json:"team_name"`
+		ChatNotifyEnabled bool   `json:"chat_notify_enabled"`
+	}{
+		TeamName:          req.TeamName,
+		ChatNotifyEnabled: req.ChatWebhookURL != "",
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
 // UserHandler обрабатывает HTTP-запросы, связанные с пользователями.
 type UserHandler struct {
 	svc app.UserService
@@ -181,32 +329,113 @@ func NewUserHandler(svc app.UserService) *UserHandler {
 	return &UserHandler{svc: svc}
 }
 
-// SetIsActive POST /users/setIsActive
+// SetIsActive PUT /api/v1/users/{user_id}/active (legacy POST /users/setIsActive)
 func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		UserID   string `json:"user_id"`
 		IsActive bool   `json:"is_active"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	bindPath(r, "user_id", &req.UserID)
+
+	var v validation.Validator
+	v.ID("user_id", req.UserID)
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
 		return
 	}
 
 	user, err := h.svc.SetIsActive(r.Context(), req.UserID, req.IsActive)
 	if err != nil {
-		WriteError(w, err)
+		WriteError(w, r, err)
 		return
 	}
 
 	resp := struct {
 		User userDTO `json:"user"`
 	}{
-		User: userDTO{
-			UserID:   user.ID,
-			Username: user.Username,
-			TeamName: user.TeamName,
-			IsActive: user.IsActive,
+		User: userToDTO(user),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// SetChatHandle PUT /api/v1/users/{user_id}/chat-handle (legacy POST /users/setChatHandle)
+func (h *UserHandler) SetChatHandle(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID     string `json:"user_id"`
+		ChatHandle string `json:"chat_handle"`
+	}
+
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	bindPath(r, "user_id", &req.UserID)
+
+	var v validation.Validator
+	v.ID("user_id", req.UserID)
+	v.MaxLen("chat_handle", req.ChatHandle, validation.MaxNameLen)
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	user, err := h.svc.SetChatHandle(r.Context(), req.UserID, req.ChatHandle)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	resp := struct {
+		User userDTO `json:"user"`
+	}{
+		User: userToDTO(user),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// SetEmailSettings PUT /api/v1/users/{user_id}/email-settings (legacy POST /users/setEmailSettings)
+func (h *UserHandler) SetEmailSettings(w http.ResponseWriter, r *http.Request) {
+	var req emailSettingsDTO
+
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	bindPath(r, "user_id", &req.UserID)
+
+	var v validation.Validator
+	v.ID("user_id", req.UserID)
+	v.Email("email", req.Email)
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	user, err := h.svc.SetEmailSettings(r.Context(), req.UserID, req.Email, domain.EmailOptOut{
+		Assignments: req.OptOutAssignments,
+		Digest:      req.OptOutDailyDigest,
+	})
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	resp := struct {
+		Settings emailSettingsDTO `json:"email_settings"`
+	}{
+		Settings: emailSettingsDTO{
+			UserID:            user.ID,
+			Email:             user.Email,
+			OptOutAssignments: user.EmailOptOut.Assignments,
+			OptOutDailyDigest: user.EmailOptOut.Digest,
 		},
 	}
 
@@ -215,17 +444,24 @@ func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
-// GetReview GET /users/getReview?user_id=...
+// GetReview GET /api/v1/users/{user_id}/reviews (legacy GET /users/getReview?user_id=...)
 func (h *UserHandler) GetReview(w http.ResponseWriter, r *http.Request) {
-	userID := r.URL.Query().Get("user_id")
-	if userID == "" {
-		w.WriteHeader(http.StatusBadRequest)
+	userID := pathOrQuery(r, "user_id")
+	var v validation.Validator
+	v.ID("user_id", userID)
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+	// user-токен видит только свою очередь
+	if err := authorizeUser(r, userID); err != nil {
+		WriteError(w, r, err)
 		return
 	}
 
 	prs, err := h.svc.GetReviewPRs(r.Context(), userID)
 	if err != nil {
-		WriteError(w, err)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -256,7 +492,7 @@ func NewPRHandler(svc app.PRService) *PRHandler {
 	return &PRHandler{svc: svc}
 }
 
-// Create POST /pullRequest/create
+// Create POST /api/v1/pull-requests (legacy POST /pullRequest/create)
 func (h *PRHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		PullRequestID   string `json:"pull_request_id"`
@@ -264,14 +500,24 @@ func (h *PRHandler) Create(w http.ResponseWriter, r *http.Request) {
 		AuthorID        string `json:"author_id"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	var v validation.Validator
+	v.ID("pull_request_id", req.PullRequestID)
+	if v.Required("pull_request_name", req.PullRequestName) {
+		v.MaxLen("pull_request_name", req.PullRequestName, validation.MaxNameLen)
+	}
+	v.ID("author_id", req.AuthorID)
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
 		return
 	}
 
 	pr, err := h.svc.CreatePR(r.Context(), req.PullRequestID, req.PullRequestName, req.AuthorID)
 	if err != nil {
-		WriteError(w, err)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -286,20 +532,28 @@ func (h *PRHandler) Create(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
-// Merge POST /pullRequest/merge
+// Merge POST /api/v1/pull-requests/{pull_request_id}/merge (legacy POST /pullRequest/merge)
 func (h *PRHandler) Merge(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		PullRequestID string `json:"pull_request_id"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+	if err := decodeOptionalBody(r, &req); err != nil {
+		WriteError(w, r, bodyError(err))
+		return
+	}
+	bindPath(r, "pull_request_id", &req.PullRequestID)
+
+	var v validation.Validator
+	v.ID("pull_request_id", req.PullRequestID)
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
 		return
 	}
 
 	pr, err := h.svc.MergePR(r.Context(), req.PullRequestID)
 	if err != nil {
-		WriteError(w, err)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -314,7 +568,7 @@ func (h *PRHandler) Merge(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
-// Reassign POST /pullRequest/reassign
+// Reassign POST /api/v1/pull-requests/{pull_request_id}/reassign (legacy POST /pullRequest/reassign)
 func (h *PRHandler) Reassign(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		PullRequestID string `json:"pull_request_id"`
@@ -323,23 +577,27 @@ func (h *PRHandler) Reassign(w http.ResponseWriter, r *http.Request) {
 		OldReviewerID string `json:"old_reviewer_id"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+	if !decodeBody(w, r, &req) {
 		return
 	}
+	bindPath(r, "pull_request_id", &req.PullRequestID)
 
 	oldID := req.OldUserID
 	if oldID == "" {
 		oldID = req.OldReviewerID
 	}
-	if req.PullRequestID == "" || oldID == "" {
-		w.WriteHeader(http.StatusBadRequest)
+
+	var v validation.Validator
+	v.ID("pull_request_id", req.PullRequestID)
+	v.ID("old_user_id", oldID)
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
 		return
 	}
-
+	// кто может снять ревьюера (он сам или тимлид его команды), решает сервис
 	pr, replacedBy, err := h.svc.ReassignReviewer(r.Context(), req.PullRequestID, oldID)
 	if err != nil {
-		WriteError(w, err)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -356,17 +614,70 @@ func (h *PRHandler) Reassign(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
-// StatsAssignments GET /stats/assignments
+// Review POST /api/v1/pull-requests/{pull_request_id}/reviews (legacy POST /pullRequest/review)
+func (h *PRHandler) Review(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		ReviewerID    string `json:"reviewer_id"`
+		Verdict       string `json:"verdict"`
+	}
+
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	bindPath(r, "pull_request_id", &req.PullRequestID)
+	verdict := domain.ReviewVerdict(req.Verdict)
+
+	var v validation.Validator
+	v.ID("pull_request_id", req.PullRequestID)
+	v.ID("reviewer_id", req.ReviewerID)
+	if v.Required("verdict", req.Verdict) {
+		v.Check(verdict.Valid(), "verdict", "must be one of APPROVED, CHANGES_REQUESTED")
+	}
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	pr, err := h.svc.SubmitVerdict(r.Context(), req.PullRequestID, req.ReviewerID, verdict)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	resp := struct {
+		PR         pullRequestDTO `json:"pr"`
+		ReviewerID string         `json:"reviewer_id"`
+		Verdict    string         `json:"verdict"`
+	}{
+		PR:         pullRequestToDTO(pr),
+		ReviewerID: req.ReviewerID,
+		Verdict:    req.Verdict,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// StatsAssignments GET /stats/assignments?from=...&to=...&team=...&status=...&group_by=day|week
 func (h *PRHandler) StatsAssignments(w http.ResponseWriter, r *http.Request) {
-	byReviewer, err := h.svc.GetAssignmentStatsByReviewer(r.Context())
+	var v validation.Validator
+	filter := parseAssignmentStatsFilter(&v, r.URL.Query())
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	byReviewer, err := h.svc.GetAssignmentStatsByReviewer(r.Context(), filter)
 	if err != nil {
-		WriteError(w, err)
+		WriteError(w, r, err)
 		return
 	}
 
-	byPR, err := h.svc.GetAssignmentStatsByPR(r.Context())
+	byPR, err := h.svc.GetAssignmentStatsByPR(r.Context(), filter)
 	if err != nil {
-		WriteError(w, err)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -381,6 +692,7 @@ func (h *PRHandler) StatsAssignments(w http.ResponseWriter, r *http.Request) {
 	for _, s := range byReviewer {
 		resp.ByReviewer = append(resp.ByReviewer, assignmentStatsDTO{
 			UserID:      s.ReviewerID,
+			Period:      s.Period,
 			Assignments: s.Count,
 		})
 	}
@@ -388,6 +700,7 @@ func (h *PRHandler) StatsAssignments(w http.ResponseWriter, r *http.Request) {
 	for _, s := range byPR {
 		resp.ByPR = append(resp.ByPR, assignmentStatsPRDTO{
 			PullRequestID: s.PullRequestID,
+			Period:        s.Period,
 			Assignments:   s.Count,
 		})
 	}
@@ -397,21 +710,32 @@ func (h *PRHandler) StatsAssignments(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
-// BulkDeactivate POST /users/bulkDeactivate
+// BulkDeactivate POST /api/v1/teams/{team_name}/users/deactivate (legacy POST /users/bulkDeactivate)
 func (h *UserHandler) BulkDeactivate(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		TeamName string   `json:"team_name"`
 		UserIDs  []string `json:"user_ids"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	bindPath(r, "team_name", &req.TeamName)
+
+	var v validation.Validator
+	v.ID("team_name", req.TeamName)
+	v.MaxItems("user_ids", len(req.UserIDs), validation.MaxListItems)
+	for i, id := range req.UserIDs {
+		v.ID(validation.Index("user_ids", i), id)
+	}
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
 		return
 	}
 
 	result, err := h.svc.BulkDeactivateTeam(r.Context(), req.TeamName, req.UserIDs)
 	if err != nil {
-		WriteError(w, err)
+		WriteError(w, r, err)
 		return
 	}
 
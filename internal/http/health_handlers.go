@@ -0,0 +1,93 @@
+package http
+
+import (
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/buildinfo"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// readyTimeout сколько ждём БД в /readyz: проба должна ответить раньше своего таймаута у оркестратора
+const readyTimeout = 2 * time.Second
+
+// HealthHandler отдаёт /healthz, /readyz и /version.
+type HealthHandler struct {
+	health app.HealthService
+}
+
+// NewHealthHandler создаёт хендлер проверок.
+func NewHealthHandler(health app.HealthService) *HealthHandler {
+	return &HealthHandler{health: health}
+}
+
+// Register вешает ручки проверок на mux.
+func (h *HealthHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /healthz", h.Healthz)
+	mux.HandleFunc("GET /readyz", h.Readyz)
+	mux.HandleFunc("GET /version", h.Version)
+}
+
+// Healthz GET /healthz — процесс жив и обслуживает HTTP. Зависимости не проверяются,
+// чтобы недоступная БД не приводила к рестарту контейнера.
+func (h *HealthHandler) Healthz(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(`{"status":"ok"}` + "\n"))
+}
+
+type healthCheckDTO struct {
+	Name  string `json:"name"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// Readyz GET /readyz — 200, если БД отвечает, схема не старше бинаря и сервер не останавливается; иначе 503.
+func (h *HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readyTimeout)
+	defer cancel()
+
+	report := h.health.Ready(ctx)
+
+	resp := struct {
+		Status string           `json:"status"`
+		Checks []healthCheckDTO `json:"checks"`
+	}{
+		Status: "ok",
+		Checks: make([]healthCheckDTO, 0, len(report.Checks)),
+	}
+	for _, c := range report.Checks {
+		resp.Checks = append(resp.Checks, healthCheckDTO{Name: c.Name, OK: c.OK, Error: c.Error})
+	}
+
+	status := http.StatusOK
+	if !report.Ready {
+		resp.Status = "unavailable"
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// Version GET /version — коммит и время сборки.
+func (h *HealthHandler) Version(w http.ResponseWriter, _ *http.Request) {
+	info := buildinfo.Get()
+
+	resp := struct {
+		Commit    string `json:"commit"`
+		BuildTime string `json:"build_time"`
+		GoVersion string `json:"go_version"`
+	}{
+		Commit:    info.Commit,
+		BuildTime: info.BuildTime,
+		GoVersion: info.GoVersion,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,150 @@
+package http
+
+import (
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/validation"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"unicode"
+)
+
+const (
+	idempotencyKeyHeader   = "Idempotency-Key"
+	idempotentReplayHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLen   = 255
+	// тело запроса читается целиком ради хэша, поэтому ограничено
+	maxIdempotentBodyBytes = 1 << 20
+)
+
+// idempotent делает POST-ручку идемпотентной по заголовку Idempotency-Key: первый запрос
+// выполняется и его ответ сохраняется, повтор с тем же телом получает сохранённый ответ
+// с Idempotent-Replayed: true. Без заголовка и для методов, кроме POST, запрос проходит как обычно
+// (PUT и DELETE идемпотентны сами по себе).
+//
+// Не сохраняются ответы 5xx (повтор выполнится заново) и ответы с Cache-Control: no-store —
+// в них секреты, которым не место в БД. Ключи живут в пространстве принципала, поэтому
+// ручка должна стоять за authenticate.
+func idempotent(svc app.IdempotencyService, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(idempotencyKeyHeader)
+		if key == "" || r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if err := validateIdempotencyKey(key); err != nil {
+			WriteError(w, r, err)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBodyBytes))
+		if err != nil {
+			WriteError(w, r, bodyError(err))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := r.Context()
+		scope := clientKey(r)
+		stored, replay, err := svc.Begin(ctx, scope, key, requestHash(r, body))
+		if err != nil {
+			WriteError(w, r, err)
+			return
+		}
+		if replay {
+			writeStoredResponse(w, stored)
+			return
+		}
+
+		rec := &recordingWriter{ResponseWriter: w}
+		// ответ сохраняем и при отмене запроса клиентом: сама операция уже выполнена
+		saveCtx := context.WithoutCancel(ctx)
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			// паника или ответ, который нельзя повторять, — ключ освобождается
+			if err := svc.Release(saveCtx, scope, key); err != nil {
+				slog.ErrorContext(saveCtx, "idempotency: release key", "error", err)
+			}
+		}()
+
+		next.ServeHTTP(rec, r)
+
+		if rec.status == 0 || rec.status >= http.StatusInternalServerError || w.Header().Get("Cache-Control") == "no-store" {
+			return
+		}
+		completed = true
+		err = svc.Complete(saveCtx, scope, key, domain.IdempotentResponse{
+			StatusCode:  rec.status,
+			ContentType: w.Header().Get("Content-Type"),
+			Body:        rec.body.Bytes(),
+		})
+		if err != nil {
+			// ответ клиенту уже ушёл; без сохранённого ответа повтор получит IDEMPOTENCY_KEY_IN_USE до LockTimeout
+			slog.ErrorContext(saveCtx, "idempotency: store response", "error", err)
+		}
+	})
+}
+
+func validateIdempotencyKey(key string) error {
+	var v validation.Validator
+	v.MaxLen(idempotencyKeyHeader, key, maxIdempotencyKeyLen)
+	for _, c := range key {
+		if c > unicode.MaxASCII || !unicode.IsPrint(c) || c == ' ' {
+			v.Add(idempotencyKeyHeader, "must contain only visible ASCII characters")
+			break
+		}
+	}
+	return v.Err()
+}
+
+// requestHash отпечаток запроса: тот же ключ на другую ручку или с другим телом — это уже другой запрос.
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	_, _ = io.WriteString(h, r.Method+" "+r.URL.RequestURI()+"\n")
+	_, _ = h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func writeStoredResponse(w http.ResponseWriter, resp domain.IdempotentResponse) {
+	if resp.ContentType != "" {
+		w.Header().Set("Content-Type", resp.ContentType)
+	}
+	w.Header().Set(idempotentReplayHeader, "true")
+	w.WriteHeader(resp.StatusCode)
+	_, _ = w.Write(resp.Body)
+}
+
+// recordingWriter пишет ответ клиенту и параллельно копит его для сохранения.
+type recordingWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap нужен http.ResponseController.
+func (w *recordingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,336 @@
+package http
+
+import (
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/validation"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// хостинги шлют PR целиком, с диффстатом и ссылками; 5 МБ с запасом
+const maxWebhookBodyBytes = 5 << 20
+
+// IntegrationSecrets секреты для проверки входящих вебхуков.
+// Пустой секрет означает, что интеграция выключена и все запросы получат 401.
+type IntegrationSecrets struct {
+	GitHubWebhookSecret string
+	GitLabWebhookToken  string
+}
+
+type integrationResultDTO struct {
+	Outcome       string `json:"outcome"`
+	PullRequestID string `json:"pull_request_id,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+type identityDTO struct {
+	Provider   string `json:"provider"`
+	Login      string `json:"login"`
+	ExternalID string `json:"external_id,omitempty"`
+	UserID     string `json:"user_id"`
+}
+
+// IntegrationHandler обрабатывает вебхуки Git-хостингов и привязку внешних логинов.
+type IntegrationHandler struct {
+	svc     app.IntegrationService
+	secrets IntegrationSecrets
+}
+
+// NewIntegrationHandler создаёт обработчик интеграций.
+func NewIntegrationHandler(svc app.IntegrationService, secrets IntegrationSecrets) *IntegrationHandler {
+	return &IntegrationHandler{svc: svc, secrets: secrets}
+}
+
+// LinkIdentity POST /integrations/identities/link
+func (h *IntegrationHandler) LinkIdentity(w http.ResponseWriter, r *http.Request) {
+	var req identityDTO
+
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	var v validation.Validator
+	if v.Required("provider", req.Provider) {
+		v.Check(domain.Provider(req.Provider).Valid(), "provider", "must be github or gitlab")
+	}
+	if v.Required("login", req.Login) {
+		v.MaxLen("login", req.Login, validation.MaxNameLen)
+	}
+	if req.ExternalID != "" {
+		_, err := strconv.ParseUint(req.ExternalID, 10, 64)
+		v.Check(err == nil, "external_id", "must be a numeric user id")
+	}
+	v.ID("user_id", req.UserID)
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	err := h.svc.LinkIdentity(r.Context(), domain.Identity{
+		Provider:   domain.Provider(req.Provider),
+		Login:      req.Login,
+		ExternalID: req.ExternalID,
+		UserID:     req.UserID,
+	})
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	resp := struct {
+		Identity identityDTO `json:"identity"`
+	}{
+		Identity: req,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// githubPullRequestEvent нужная нам часть payload события pull_request.
+type githubPullRequestEvent struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Title  string `json:"title"`
+		Draft  bool   `json:"draft"`
+		Merged bool   `json:"merged"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// GitHubWebhook POST /integrations/github/webhook
+func (h *IntegrationHandler) GitHubWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodyBytes))
+	if err != nil {
+		WriteError(w, r, bodyError(err))
+		return
+	}
+
+	if !validGitHubSignature(h.secrets.GitHubWebhookSecret, r.Header.Get("X-Hub-Signature-256"), body) {
+		WriteError(w, r, domain.ErrInvalidSignature)
+		return
+	}
+
+	// ping и прочие события подтверждаем, чтобы GitHub не считал доставку упавшей
+	if r.Header.Get("X-GitHub-Event") != "pull_request" {
+		writeIntegrationResult(w, domain.IntegrationResult{
+			Outcome: domain.OutcomeIgnored,
+			Reason:  "unsupported event",
+		})
+		return
+	}
+
+	var payload githubPullRequestEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		WriteError(w, r, bodyError(err))
+		return
+	}
+
+	ev := domain.ExternalPREvent{
+		Provider:    domain.ProviderGitHub,
+		PullRequest: "github:" + payload.Repository.FullName + "#" + strconv.Itoa(payload.Number),
+		Title:       payload.PullRequest.Title,
+		AuthorLogin: payload.PullRequest.User.Login,
+	}
+
+	switch {
+	case payload.Action == "opened" && !payload.PullRequest.Draft,
+		payload.Action == "ready_for_review":
+		ev.Action = domain.ExternalPROpened
+	case payload.Action == "reopened" && !payload.PullRequest.Draft:
+		ev.Action = domain.ExternalPRReopened
+	case payload.Action == "closed" && payload.PullRequest.Merged:
+		ev.Action = domain.ExternalPRMerged
+	case payload.Action == "closed":
+		ev.Action = domain.ExternalPRClosed
+	default:
+		writeIntegrationResult(w, domain.IntegrationResult{
+			Outcome:       domain.OutcomeIgnored,
+			PullRequestID: ev.PullRequest,
+			Reason:        "unsupported action",
+		})
+		return
+	}
+
+	result, err := h.svc.HandlePREvent(r.Context(), ev)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	writeIntegrationResult(w, result)
+}
+
+// gitlabUser пользователь в payload GitLab.
+type gitlabUser struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+}
+
+// gitlabBoolChange изменение булева поля в changes.
+type gitlabBoolChange struct {
+	Previous bool `json:"previous"`
+	Current  bool `json:"current"`
+}
+
+// gitlabMergeRequestEvent нужная нам часть payload Merge Request Hook.
+type gitlabMergeRequestEvent struct {
+	ObjectKind string     `json:"object_kind"`
+	User       gitlabUser `json:"user"`
+	Project    struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID int `json:"iid"`
+		// AuthorID автор MR; user — тот, кто совершил действие, и это не обязательно автор
+		AuthorID int    `json:"author_id"`
+		Title    string `json:"title"`
+		Action   string `json:"action"`
+		Draft    bool   `json:"draft"`
+	} `json:"object_attributes"`
+	Assignees []gitlabUser `json:"assignees"`
+	Reviewers []gitlabUser `json:"reviewers"`
+	Changes   struct {
+		Assignees json.RawMessage   `json:"assignees"`
+		Reviewers json.RawMessage   `json:"reviewers"`
+		Draft     *gitlabBoolChange `json:"draft"`
+	} `json:"changes"`
+}
+
+// reviewerLogins объединяет assignees и reviewers: в GitLab людей на ревью ставят в оба поля.
+func (e gitlabMergeRequestEvent) reviewerLogins() []string {
+	logins := make([]string, 0, len(e.Assignees)+len(e.Reviewers))
+	for _, u := range e.Assignees {
+		logins = append(logins, u.Username)
+	}
+	for _, u := range e.Reviewers {
+		logins = append(logins, u.Username)
+	}
+	return logins
+}
+
+// GitLabWebhook POST /integrations/gitlab/webhook
+func (h *IntegrationHandler) GitLabWebhook(w http.ResponseWriter, r *http.Request) {
+	if !validGitLabToken(h.secrets.GitLabWebhookToken, r.Header.Get("X-Gitlab-Token")) {
+		WriteError(w, r, domain.ErrInvalidSignature)
+		return
+	}
+
+	var payload gitlabMergeRequestEvent
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxWebhookBodyBytes)).Decode(&payload); err != nil {
+		WriteError(w, r, bodyError(err))
+		return
+	}
+
+	if payload.ObjectKind != "merge_request" {
+		writeIntegrationResult(w, domain.IntegrationResult{
+			Outcome: domain.OutcomeIgnored,
+			Reason:  "unsupported event",
+		})
+		return
+	}
+
+	attrs := payload.ObjectAttributes
+	ev := domain.ExternalPREvent{
+		Provider:       domain.ProviderGitLab,
+		PullRequest:    "gitlab:" + payload.Project.PathWithNamespace + "!" + strconv.Itoa(attrs.IID),
+		Title:          attrs.Title,
+		ReviewerLogins: payload.reviewerLogins(),
+	}
+	// логина автора в payload нет, только author_id; логин инициатора подходит,
+	// лишь если MR открыл или переоткрыл сам автор
+	if attrs.AuthorID > 0 {
+		ev.AuthorExternalID = strconv.Itoa(attrs.AuthorID)
+		if payload.User.ID == attrs.AuthorID {
+			ev.AuthorLogin = payload.User.Username
+		}
+	}
+
+	readyForReview := payload.Changes.Draft != nil && payload.Changes.Draft.Previous && !payload.Changes.Draft.Current
+	reviewersChanged := len(payload.Changes.Assignees) > 0 || len(payload.Changes.Reviewers) > 0
+
+	switch {
+	case attrs.Action == "open" && !attrs.Draft, attrs.Action == "update" && readyForReview:
+		ev.Action = domain.ExternalPROpened
+		ev.SyncReviewers = len(ev.ReviewerLogins) > 0
+	case attrs.Action == "reopen" && !attrs.Draft:
+		ev.Action = domain.ExternalPRReopened
+	case attrs.Action == "update" && reviewersChanged:
+		ev.Action = domain.ExternalPRUpdated
+		ev.SyncReviewers = true
+	case attrs.Action == "merge":
+		ev.Action = domain.ExternalPRMerged
+	case attrs.Action == "close":
+		ev.Action = domain.ExternalPRClosed
+	default:
+		writeIntegrationResult(w, domain.IntegrationResult{
+			Outcome:       domain.OutcomeIgnored,
+			PullRequestID: ev.PullRequest,
+			Reason:        "unsupported action",
+		})
+		return
+	}
+
+	result, err := h.svc.HandlePREvent(r.Context(), ev)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	writeIntegrationResult(w, result)
+}
+
+// validGitLabToken сравнивает X-Gitlab-Token с настроенным секретом за постоянное время.
+func validGitLabToken(secret, header string) bool {
+	if secret == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(secret), []byte(header)) == 1
+}
+
+// validGitHubSignature проверяет заголовок вида "sha256=<hex HMAC-SHA256(secret, body)>".
+func validGitHubSignature(secret, header string, body []byte) bool {
+	if secret == "" {
+		return false
+	}
+
+	got, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+	gotMAC, err := hex.DecodeString(got)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(gotMAC, mac.Sum(nil))
+}
+
+func writeIntegrationResult(w http.ResponseWriter, res domain.IntegrationResult) {
+	resp := integrationResultDTO{
+		Outcome:       string(res.Outcome),
+		PullRequestID: res.PullRequestID,
+		Reason:        res.Reason,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,36 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// Один хендлер обслуживает и /api/v1, и legacy-роут: в v1 идентификатор ресурса лежит в пути,
+// в legacy — в теле или query. Хелперы ниже сводят оба варианта к одному полю.
+
+// pathOrQuery значение из пути v1-роута, иначе из query legacy-роута.
+func pathOrQuery(r *http.Request, name string) string {
+	if v := r.PathValue(name); v != "" {
+		return v
+	}
+	return r.URL.Query().Get(name)
+}
+
+// bindPath подставляет значение из пути поверх поля, прочитанного из тела.
+func bindPath(r *http.Request, name string, dst *string) {
+	if v := r.PathValue(name); v != "" {
+		*dst = v
+	}
+}
+
+// decodeOptionalBody как json.Decode, но пустое тело не ошибка:
+// v1-роутам вроде POST /api/v1/pull-requests/{id}/merge тело не нужно.
+func decodeOptionalBody(r *http.Request, dst any) error {
+	err := json.NewDecoder(r.Body).Decode(dst)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,70 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+)
+
+// problemContentType медиатип ошибок по RFC 7807.
+const problemContentType = "application/problem+json"
+
+// problemTypePrefix префикс type: URI стабилен и однозначно задаёт код ошибки,
+// но не обязан открываться — описание кодов лежит в README.
+const problemTypePrefix = "urn:pr-reviewer:problem:"
+
+// problemTitles краткое описание типа проблемы; по RFC 7807 оно одинаково для всех её случаев.
+var problemTitles = map[ErrorCode]string{
+	CodeTeamExists:           "Team already exists",
+	CodePRExists:             "Pull request already exists",
+	CodePRMerged:             "Pull request is merged",
+	CodePRClosed:             "Pull request is closed",
+	CodeNotAssigned:          "Reviewer is not assigned",
+	CodeNoCandidate:          "No replacement candidate",
+	CodeNotFound:             "Resource not found",
+	CodeInvalidSignature:     "Invalid webhook signature",
+	CodeValidation:           "Request validation failed",
+	CodeUnauthorized:         "Authentication required",
+	CodeForbidden:            "Operation not permitted",
+	CodeIdempotencyKeyReused: "Idempotency key reused",
+	CodeIdempotencyKeyInUse:  "Idempotency key in use",
+	CodeRateLimited:          "Rate limit exceeded",
+	CodeOverloaded:           "Server overloaded",
+	CodeInternal:             "Internal server error",
+}
+
+// Problem тело application/problem+json. code, details и request_id — члены-расширения,
+// те же, что в ErrorResponse, чтобы клиенты могли ветвиться по коду в обоих режимах.
+type Problem struct {
+	Type      string          `json:"type"`
+	Title     string          `json:"title"`
+	Status    int             `json:"status"`
+	Detail    string          `json:"detail,omitempty"`
+	Instance  string          `json:"instance,omitempty"`
+	RequestID string          `json:"request_id,omitempty"`
+	Code      ErrorCode       `json:"code"`
+	Details   []fieldErrorDTO `json:"details,omitempty"`
+}
+
+// Problem переводит ошибку в RFC 7807. instance — путь запроса, в котором она случилась.
+func (e *ErrorHTTP) Problem(instance, requestID string) *Problem {
+	body := e.Body.Error
+	title, ok := problemTitles[body.Code]
+	if !ok {
+		title = http.StatusText(e.Status)
+	}
+	return &Problem{
+		Type:      problemTypePrefix + strings.ToLower(strings.ReplaceAll(string(body.Code), "_", "-")),
+		Title:     title,
+		Status:    e.Status,
+		Detail:    body.Message,
+		Instance:  instance,
+		RequestID: requestID,
+		Code:      body.Code,
+		Details:   body.Details,
+	}
+}
+
+// acceptsProblem клиент явно попросил problem+json; без этого остаётся прежний ErrorResponse.
+func acceptsProblem(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), problemContentType)
+}
@@ -0,0 +1,177 @@
+package http
+
+import (
+	"avi_internship_autumn/internal/domain"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit лимит token bucket: Rate запросов в секунду в среднем и до Burst подряд.
+// Rate <= 0 выключает лимит.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// Limits ограничения нагрузки на API.
+type Limits struct {
+	// IP лимит на адрес соединения, проверяется до токена: перебор токенов и запросы
+	// без токена не доходят до поиска токена в БД. Должен быть не строже Default.
+	IP RateLimit
+	// Default лимит на клиента для всех ручек.
+	Default RateLimit
+	// Heavy отдельный, более строгий лимит тяжёлых ручек; действует вместе с Default.
+	Heavy RateLimit
+	// MaxInFlight сколько запросов обрабатывается одновременно; 0 — без ограничения.
+	MaxInFlight int
+}
+
+// heavyRoutes ручки, которые держат соединение с БД заметно дольше остальных:
+// массовые апдейты, агрегаты по всей истории назначений и выгрузки.
+var heavyRoutes = map[string]bool{
+	"POST /api/v1/teams/{team_name}/users/deactivate": true,
+	"GET /api/v1/stats/assignments":                   true,
+	"GET /api/v1/stats/latency":                       true,
+	"GET /api/v1/stats/fairness":                      true,
+	"GET /api/v1/stats/pairs":                         true,
+	"GET /api/v1/exports/pull-requests":               true,
+	"GET /api/v1/exports/assignments":                 true,
+	"GET /api/v1/exports/stats":                       true,
+}
+
+// limitRate отвечает 429 с Retry-After, когда у клиента (key) кончились токены
+// хотя бы в одном из бакетов. С clientKey должна стоять за authenticate, иначе клиентом будет IP.
+func limitRate(next http.Handler, key func(*http.Request) string, limiters ...*rateLimiter) http.Handler {
+	active := make([]*rateLimiter, 0, len(limiters))
+	for _, l := range limiters {
+		if l != nil {
+			active = append(active, l)
+		}
+	}
+	if len(active) == 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		k := key(r)
+		now := time.Now()
+		for i, l := range active {
+			if ok, wait := l.allow(k, now); !ok {
+				// отклонённый запрос не тратит бюджет бакетов, которые его уже пропустили
+				for _, taken := range active[:i] {
+					taken.refund(k)
+				}
+				w.Header().Set("Retry-After", retryAfter(wait))
+				WriteError(w, r, domain.ErrRateLimited)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// shedLoad пропускает не больше cap(slots) запросов одновременно, лишние сразу получают 503:
+// быстрый отказ дешевле, чем ожидание соединения из пула до таймаута.
+func shedLoad(slots chan struct{}, next http.Handler) http.Handler {
+	if slots == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+			next.ServeHTTP(w, r)
+		default:
+			w.Header().Set("Retry-After", "1")
+			WriteError(w, r, domain.ErrOverloaded)
+		}
+	})
+}
+
+// retryAfter секунды для Retry-After, округлённые вверх: клиент, подождавший столько, получит токен.
+func retryAfter(wait time.Duration) string {
+	return strconv.Itoa(max(1, int(math.Ceil(wait.Seconds()))))
+}
+
+// rateLimitSweepInterval как часто выбрасывать бакеты клиентов, которые давно не приходили.
+const rateLimitSweepInterval = time.Minute
+
+// rateLimiter token bucket на каждого клиента. Бакеты живут в памяти процесса:
+// при нескольких репликах лимит действует на каждую отдельно.
+type rateLimiter struct {
+	rate  float64 // токенов в секунду
+	burst float64
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter nil, если лимит выключен.
+func newRateLimiter(l RateLimit) *rateLimiter {
+	if l.Rate <= 0 {
+		return nil
+	}
+	return &rateLimiter{
+		rate:      l.Rate,
+		burst:     float64(max(1, l.Burst)),
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// allow забирает токен из бакета клиента. Нет токена — false и через сколько он появится.
+func (l *rateLimiter) allow(key string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+}
+
+// refund возвращает в бакет клиента токен, взятый allow.
+func (l *rateLimiter) refund(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b, ok := l.buckets[key]; ok {
+		b.tokens = min(l.burst, b.tokens+1)
+	}
+}
+
+// sweep удаляет бакеты, которые успели наполниться целиком: новый бакет будет таким же.
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	refill := time.Duration(l.burst / l.rate * float64(time.Second))
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= refill {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package http
+
+import (
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/domain"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterAllow(t *testing.T) {
+	l := newRateLimiter(RateLimit{Rate: 2, Burst: 3})
+	start := time.Now()
+
+	steps := []struct {
+		name   string
+		key    string
+		after  time.Duration
+		allow  bool
+		wait   time.Duration
+		tokens float64 // сколько осталось в бакете key после шага
+	}{
+		{name: "new bucket is full", key: "a", after: 0, allow: true, tokens: 2},
+		{name: "burst", key: "a", after: 0, allow: true, tokens: 1},
+		{name: "burst end", key: "a", after: 0, allow: true, tokens: 0},
+		{name: "empty", key: "a", after: 0, allow: false, wait: 500 * time.Millisecond, tokens: 0},
+		{name: "half a token", key: "a", after: 250 * time.Millisecond, allow: false, wait: 250 * time.Millisecond, tokens: 0.5},
+		{name: "refilled token", key: "a", after: 500 * time.Millisecond, allow: true, tokens: 0},
+		{name: "other client has own bucket", key: "b", after: 500 * time.Millisecond, allow: true, tokens: 2},
+		{name: "refill is capped by burst", key: "a", after: 10 * time.Second, allow: true, tokens: 2},
+	}
+	for _, step := range steps {
+		ok, wait := l.allow(step.key, start.Add(step.after))
+		if ok != step.allow || wait != step.wait {
+			t.Fatalf("%s: allow = %v, %v; want %v, %v", step.name, ok, wait, step.allow, step.wait)
+		}
+		if got := l.buckets[step.key].tokens; got != step.tokens {
+			t.Fatalf("%s: %v tokens left, want %v", step.name, got, step.tokens)
+		}
+	}
+}
+
+func TestRateLimiterSweep(t *testing.T) {
+	l := newRateLimiter(RateLimit{Rate: 1, Burst: 10})
+	start := l.lastSweep
+
+	l.allow("idle", start)
+	l.allow("busy", start.Add(55*time.Second))
+
+	// бакет наполняется за 10 с, но раньше rateLimitSweepInterval их не перебирают
+	l.allow("busy", start.Add(rateLimitSweepInterval-time.Second))
+	if len(l.buckets) != 2 {
+		t.Fatalf("swept before interval: %d buckets left", len(l.buckets))
+	}
+
+	l.allow("busy", start.Add(rateLimitSweepInterval))
+	if _, ok := l.buckets["idle"]; ok {
+		t.Fatal("full bucket of an idle client must be swept")
+	}
+	if _, ok := l.buckets["busy"]; !ok {
+		t.Fatal("bucket of an active client must be kept")
+	}
+	if !l.lastSweep.Equal(start.Add(rateLimitSweepInterval)) {
+		t.Fatalf("lastSweep = %v, want %v", l.lastSweep, start.Add(rateLimitSweepInterval))
+	}
+}
+
+func TestNewRateLimiterDisabled(t *testing.T) {
+	if l := newRateLimiter(RateLimit{Rate: 0, Burst: 10}); l != nil {
+		t.Fatalf("zero rate must disable the limiter, got %+v", l)
+	}
+}
+
+// fakeTokens принимает только goodToken как admin и считает обращения к Authenticate.
+// List ждёт release, если он задан, — так запрос висит в хендлере.
+type fakeTokens struct {
+	app.TokenService
+	lookups atomic.Int32
+	entered chan struct{}
+	release chan struct{}
+}
+
+const goodToken = "good-token"
+
+func (f *fakeTokens) Authenticate(_ context.Context, token string) (domain.Principal, error) {
+	f.lookups.Add(1)
+	if token != goodToken {
+		return domain.Principal{}, domain.ErrUnauthorized
+	}
+	return domain.Principal{Scope: domain.ScopeAdmin}, nil
+}
+
+func (f *fakeTokens) List(context.Context) ([]domain.APIToken, error) {
+	if f.release != nil {
+		f.entered <- struct{}{}
+		<-f.release
+	}
+	return nil, nil
+}
+
+func newLimitedRouter(tokens app.TokenService, limits Limits) http.Handler {
+	return NewRouter(nil, nil, nil, nil, nil, tokens, nil, IntegrationSecrets{}, limits, app.NewEventBus(1), time.Second)
+}
+
+func serve(h http.Handler, target, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func errorCode(t *testing.T, rec *httptest.ResponseRecorder) ErrorCode {
+	t.Helper()
+
+	var body struct {
+		Error struct {
+			Code ErrorCode `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode error body %q: %v", rec.Body.String(), err)
+	}
+	return body.Error.Code
+}
+
+func TestRouterRateLimits(t *testing.T) {
+	t.Run("IP limit before token lookup", func(t *testing.T) {
+		tokens := &fakeTokens{}
+		h := newLimitedRouter(tokens, Limits{IP: RateLimit{Rate: 1, Burst: 2}})
+
+		for i, want := range []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests} {
+			rec := serve(h, "/api/v1/tokens", "wrong-token")
+			if rec.Code != want {
+				t.Fatalf("request %d: status %d, want %d", i, rec.Code, want)
+			}
+		}
+		if n := tokens.lookups.Load(); n != 2 {
+			t.Fatalf("rate-limited request must not look up the token: %d lookups", n)
+		}
+	})
+
+	t.Run("heavy route has stricter bucket", func(t *testing.T) {
+		h := newLimitedRouter(&fakeTokens{}, Limits{
+			Default: RateLimit{Rate: 1, Burst: 10},
+			Heavy:   RateLimit{Rate: 0.5, Burst: 1},
+		})
+
+		// top=-1 не проходит валидацию: хендлер отвечает 400, не трогая сервис
+		if rec := serve(h, "/api/v1/stats/pairs?top=-1", goodToken); rec.Code != http.StatusBadRequest {
+			t.Fatalf("first heavy request: status %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+		rec := serve(h, "/api/v1/stats/pairs?top=-1", goodToken)
+		if rec.Code != http.StatusTooManyRequests || errorCode(t, rec) != CodeRateLimited {
+			t.Fatalf("second heavy request: status %d %s, want 429", rec.Code, rec.Body.String())
+		}
+		if got := rec.Header().Get("Retry-After"); got != "2" {
+			t.Fatalf("Retry-After = %q, want time until the next heavy token (2)", got)
+		}
+		// легаси-алиас делит бакет с v1-роутом
+		if rec := serve(h, "/stats/pairs?top=-1", goodToken); rec.Code != http.StatusTooManyRequests {
+			t.Fatalf("legacy heavy route: status %d, want 429", rec.Code)
+		}
+
+		if rec := serve(h, "/api/v1/tokens", goodToken); rec.Code != http.StatusOK {
+			t.Fatalf("regular route must use the default bucket: status %d", rec.Code)
+		}
+	})
+
+	t.Run("heavy rejection keeps default budget", func(t *testing.T) {
+		// токены почти не восполняются: всё решает то, сколько списано
+		h := newLimitedRouter(&fakeTokens{}, Limits{
+			Default: RateLimit{Rate: 0.001, Burst: 2},
+			Heavy:   RateLimit{Rate: 0.001, Burst: 1},
+		})
+
+		if rec := serve(h, "/api/v1/stats/pairs?top=-1", goodToken); rec.Code != http.StatusBadRequest {
+			t.Fatalf("first heavy request: status %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+		for i := 0; i < 3; i++ {
+			if rec := serve(h, "/api/v1/stats/pairs?top=-1", goodToken); rec.Code != http.StatusTooManyRequests {
+				t.Fatalf("heavy request over the limit: status %d, want 429", rec.Code)
+			}
+		}
+
+		// в Default списан только первый тяжёлый запрос: остался ровно один токен
+		if rec := serve(h, "/api/v1/tokens", goodToken); rec.Code != http.StatusOK {
+			t.Fatalf("rejected heavy requests spent the default bucket: status %d", rec.Code)
+		}
+		if rec := serve(h, "/api/v1/tokens", goodToken); rec.Code != http.StatusTooManyRequests {
+			t.Fatalf("default bucket must be empty now: status %d", rec.Code)
+		}
+	})
+
+	t.Run("overloaded", func(t *testing.T) {
+		tokens := &fakeTokens{entered: make(chan struct{}, 2), release: make(chan struct{})}
+		h := newLimitedRouter(tokens, Limits{MaxInFlight: 1})
+
+		done := make(chan int)
+		go func() { done <- serve(h, "/api/v1/tokens", goodToken).Code }()
+		<-tokens.entered
+
+		rec := serve(h, "/api/v1/tokens", goodToken)
+		if rec.Code != http.StatusServiceUnavailable || errorCode(t, rec) != CodeOverloaded ||
+			rec.Header().Get("Retry-After") != "1" {
+			t.Fatalf("request over MaxInFlight: status %d %q, Retry-After %q", rec.Code, rec.Body.String(), rec.Header().Get("Retry-After"))
+		}
+		// без свободного слота до проверки токена дело не доходит
+		if n := tokens.lookups.Load(); n != 1 {
+			t.Fatalf("shed request must not look up the token: %d lookups", n)
+		}
+
+		close(tokens.release)
+		if code := <-done; code != http.StatusOK {
+			t.Fatalf("in-flight request: status %d, want %d", code, http.StatusOK)
+		}
+		if rec := serve(h, "/api/v1/tokens", goodToken); rec.Code != http.StatusOK {
+			t.Fatalf("slot must be released after the request: status %d", rec.Code)
+		}
+	})
+}
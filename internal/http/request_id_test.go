@@ -0,0 +1,99 @@
+package http
+
+import (
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/logging"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// brokenTokens пускает admin, но список токенов падает непредвиденной ошибкой — 500 INTERNAL_ERROR.
+type brokenTokens struct {
+	fakeTokens
+}
+
+func (*brokenTokens) List(context.Context) ([]domain.APIToken, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var logs bytes.Buffer
+	logger, err := logging.New(&logs, logging.Settings{Format: "json", Level: "info"})
+	if err != nil {
+		t.Fatalf("logging.New: %v", err)
+	}
+	prev := slog.Default()
+	slog.SetDefault(logger)
+	t.Cleanup(func() { slog.SetDefault(prev) })
+
+	// как в main.go: logging.Middleware снаружи роутера
+	h := logging.Middleware(newLimitedRouter(&brokenTokens{}, Limits{}))
+
+	tests := []struct {
+		name     string
+		incoming string
+		// echoed входящий ID должен вернуться как есть, иначе — замениться новым
+		echoed bool
+	}{
+		{name: "valid id is echoed", incoming: "abc-123_DEF.456", echoed: true},
+		{name: "missing id is generated", incoming: ""},
+		{name: "id with space is replaced", incoming: "abc 123"},
+		{name: "id with control character is replaced", incoming: "abc\x01"},
+		{name: "non-ASCII id is replaced", incoming: "идентификатор"},
+		{name: "too long id is replaced", incoming: strings.Repeat("a", 129)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs.Reset()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/tokens", nil)
+			req.Header.Set("Authorization", "Bearer "+goodToken)
+			if tt.incoming != "" {
+				req.Header.Set(logging.RequestIDHeader, tt.incoming)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusInternalServerError || errorCode(t, rec) != CodeInternal {
+				t.Fatalf("status %d %s, want 500", rec.Code, rec.Body.String())
+			}
+
+			id := rec.Header().Get(logging.RequestIDHeader)
+			switch {
+			case tt.echoed && id != tt.incoming:
+				t.Fatalf("%s = %q, want incoming %q", logging.RequestIDHeader, id, tt.incoming)
+			case !tt.echoed && (id == tt.incoming || len(id) != 32):
+				t.Fatalf("%s = %q, want a new generated id", logging.RequestIDHeader, id)
+			}
+
+			var body ErrorResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body.Error.RequestID != id {
+				t.Fatalf("body request_id = %q, want %q (err %v)", body.Error.RequestID, id, err)
+			}
+
+			// ровно одна запись об ошибке, и по request_id её можно найти
+			var lines []map[string]any
+			scanner := bufio.NewScanner(&logs)
+			for scanner.Scan() {
+				var line map[string]any
+				if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+					t.Fatalf("log line %q: %v", scanner.Text(), err)
+				}
+				lines = append(lines, line)
+			}
+			if len(lines) != 1 || lines[0]["msg"] != "request failed" || lines[0]["level"] != "ERROR" ||
+				lines[0]["request_id"] != id || lines[0]["status"] != float64(http.StatusInternalServerError) {
+				t.Fatalf("expected one 5xx log line with request_id %q, got %v", id, lines)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package http
 import (
 	"avi_internship_autumn/internal/app"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 // NewRouter собирает http.Handler со всеми эндпоинтами сервиса.
@@ -11,29 +13,139 @@ func NewRouter(
 	teamSvc app.TeamService,
 	userSvc app.UserService,
 	prSvc app.PRService,
+	webhookSvc app.WebhookService,
+	integrationSvc app.IntegrationService,
+	tokenSvc app.TokenService,
+	idempotencySvc app.IdempotencyService,
+	secrets IntegrationSecrets,
+	limits Limits,
+	bus *app.EventBus,
+	heartbeat time.Duration,
 ) http.Handler {
 	mux := http.NewServeMux()
 
 	teamHandler := NewTeamHandler(teamSvc)
 	userHandler := NewUserHandler(userSvc)
 	prHandler := NewPRHandler(prSvc)
+	webhookHandler := NewWebhookHandler(webhookSvc)
+	integrationHandler := NewIntegrationHandler(integrationSvc, secrets)
+	streamHandler := NewStreamHandler(userSvc, bus, heartbeat)
+	tokenHandler := NewTokenHandler(tokenSvc)
 
-	// Teams
-	mux.HandleFunc("/team/add", teamHandler.AddTeam)
-	mux.HandleFunc("/team/get", teamHandler.GetTeam)
+	// Методы заданы в шаблонах: на чужой метод ServeMux сам отвечает 405 с заголовком Allow.
+	// Каждая ручка живёт под /api/v1; старые роуты — алиасы на тот же хендлер с Deprecation/Sunset.
+	// Доступ у алиаса тот же, что у v1-роута: admin — всё, user — только своя очередь, свои ревью и вердикты.
+	routes := []route{
+		// Teams
+		{"POST /api/v1/teams", accessAdmin, teamHandler.AddTeam, []string{"POST /team/add"}},
+		{"GET /api/v1/teams/{team_name}", accessAdmin, teamHandler.GetTeam, []string{"GET /team/get", "GET /team/{team_name}"}},
+		{"GET /api/v1/teams/{team_name}/users", accessAdmin, teamHandler.TeamUsers, []string{"GET /team/{team_name}/users"}},
+		{"PUT /api/v1/teams/{team_name}/chat-webhook", accessAdmin, teamHandler.SetChatWebhook, []string{"POST /team/setChatWebhook"}},
+		{"POST /api/v1/teams/{team_name}/users/deactivate", accessAdmin, userHandler.BulkDeactivate, []string{"POST /users/bulkDeactivate"}},
 
-	// Users
-	mux.HandleFunc("/users/setIsActive", userHandler.SetIsActive)
-	mux.HandleFunc("/users/getReview", userHandler.GetReview)
-	mux.HandleFunc("/users/bulkDeactivate", userHandler.BulkDeactivate)
+		// Users
+		{"PUT /api/v1/users/{user_id}/active", accessAdmin, userHandler.SetIsActive, []string{"POST /users/setIsActive"}},
+		{"PUT /api/v1/users/{user_id}/chat-handle", accessAdmin, userHandler.SetChatHandle, []string{"POST /users/setChatHandle"}},
+		{"PUT /api/v1/users/{user_id}/email-settings", accessAdmin, userHandler.SetEmailSettings, []string{"POST /users/setEmailSettings"}},
+		{"GET /api/v1/users/{user_id}/reviews", accessUser, userHandler.GetReview, []string{"GET /users/getReview"}},
+		{streamRoute, accessUser, streamHandler.ReviewStream, []string{"GET /users/reviewStream"}},
 
-	// PullRequests
-	mux.HandleFunc("/pullRequest/create", prHandler.Create)
-	mux.HandleFunc("/pullRequest/merge", prHandler.Merge)
-	mux.HandleFunc("/pullRequest/reassign", prHandler.Reassign)
+		// PullRequests
+		{"POST /api/v1/pull-requests", accessAdmin, prHandler.Create, []string{"POST /pullRequest/create"}},
+		{"POST /api/v1/pull-requests/{pull_request_id}/merge", accessAdmin, prHandler.Merge, []string{"POST /pullRequest/merge"}},
+		{"POST /api/v1/pull-requests/{pull_request_id}/reassign", accessUser, prHandler.Reassign, []string{"POST /pullRequest/reassign"}},
+		{"POST /api/v1/pull-requests/{pull_request_id}/reviews", accessUser, prHandler.Review, []string{"POST /pullRequest/review"}},
 
-	// Statistics
-	mux.HandleFunc("/stats/assignments", prHandler.StatsAssignments)
+		// Statistics
+		{"GET /api/v1/stats/assignments", accessAdmin, prHandler.StatsAssignments, []string{"GET /stats/assignments"}},
+		{"GET /api/v1/stats/latency", accessAdmin, prHandler.StatsLatency, []string{"GET /stats/latency"}},
+		{"GET /api/v1/stats/fairness", accessAdmin, prHandler.StatsFairness, []string{"GET /stats/fairness"}},
+		{"GET /api/v1/stats/pairs", accessAdmin, prHandler.StatsPairs, []string{"GET /stats/pairs"}},
+
+		// Export
+		{"GET /api/v1/exports/pull-requests", accessAdmin, prHandler.ExportPullRequests, []string{"GET /export/pullRequests"}},
+		{"GET /api/v1/exports/assignments", accessAdmin, prHandler.ExportAssignments, []string{"GET /export/assignments"}},
+		{"GET /api/v1/exports/stats", accessAdmin, prHandler.ExportStats, []string{"GET /export/stats"}},
+
+		// Webhooks
+		{"POST /api/v1/teams/{team_name}/webhooks", accessAdmin, webhookHandler.Subscribe, []string{"POST /webhooks/subscribe"}},
+		{"GET /api/v1/teams/{team_name}/webhooks", accessAdmin, webhookHandler.List, []string{"GET /webhooks/list"}},
+		{"PUT /api/v1/webhooks/{subscription_id}/active", accessAdmin, webhookHandler.SetActive, []string{"POST /webhooks/setActive"}},
+		{"PUT /api/v1/webhooks/{subscription_id}/secret", accessAdmin, webhookHandler.RotateSecret, []string{"POST /webhooks/rotateSecret"}},
+		{"GET /api/v1/webhooks/{subscription_id}/deliveries", accessAdmin, webhookHandler.Deliveries, []string{"GET /webhooks/deliveries"}},
+		{"POST /api/v1/webhook-deliveries/{delivery_id}/replay", accessAdmin, webhookHandler.Replay, []string{"POST /webhooks/replay"}},
+
+		// Integrations
+		{"POST /api/v1/integrations/identities", accessAdmin, integrationHandler.LinkIdentity, []string{"POST /integrations/identities/link"}},
+		{"POST /api/v1/integrations/github/webhook", accessPublic, integrationHandler.GitHubWebhook, []string{"POST /integrations/github/webhook"}},
+		{"POST /api/v1/integrations/gitlab/webhook", accessPublic, integrationHandler.GitLabWebhook, []string{"POST /integrations/gitlab/webhook"}},
+
+		// Tokens
+		{"POST /api/v1/tokens", accessAdmin, tokenHandler.Issue, nil},
+		{"GET /api/v1/tokens", accessAdmin, tokenHandler.List, nil},
+		{"DELETE /api/v1/tokens/{token_id}", accessAdmin, tokenHandler.Revoke, nil},
+	}
+
+	ipLimiter := newRateLimiter(limits.IP)
+	defaultLimiter := newRateLimiter(limits.Default)
+	heavyLimiter := newRateLimiter(limits.Heavy)
+	var inFlight chan struct{}
+	if limits.MaxInFlight > 0 {
+		inFlight = make(chan struct{}, limits.MaxInFlight)
+	}
+
+	// Порядок: лимит IP → общий лимит одновременных запросов → токен → лимит клиента → Idempotency-Key → хендлер.
+	// Поиск токена ходит в БД, поэтому он уже под обоими общими лимитами, а неверный токен тратит бакет IP.
+	for _, rt := range routes {
+		var h http.Handler = rt.handler
+		// у публичных вебхуков нет принципала, к которому привязать Idempotency-Key
+		if rt.access != accessPublic {
+			h = idempotent(idempotencySvc, h)
+		}
+		if heavyRoutes[rt.pattern] {
+			h = limitRate(h, clientKey, defaultLimiter, heavyLimiter)
+		} else {
+			h = limitRate(h, clientKey, defaultLimiter)
+		}
+		h = authenticate(tokenSvc, rt.access, h)
+		// SSE-стрим висит часами без соединения с БД — в общий лимит его не считаем
+		if rt.pattern != streamRoute {
+			h = shedLoad(inFlight, h)
+		}
+		h = limitRate(h, ipKey, ipLimiter)
+		mux.Handle(rt.pattern, h)
+		for _, legacy := range rt.legacy {
+			mux.Handle(legacy, deprecated(h))
+		}
+	}
 
 	return mux
 }
+
+const streamRoute = "GET /api/v1/users/{user_id}/review-stream"
+
+// route одна ручка API: шаблон под /api/v1, кому она открыта и шаблоны её legacy-алиасов.
+type route struct {
+	pattern string
+	access  access
+	handler http.HandlerFunc
+	legacy  []string
+}
+
+// Legacy-роуты объявлены устаревшими с выхода /api/v1 и будут удалены после legacySunset.
+var (
+	legacyDeprecatedAt = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	legacySunset       = time.Date(2027, time.April, 30, 0, 0, 0, 0, time.UTC)
+)
+
+// deprecated помечает ответ legacy-роута заголовками Deprecation (RFC 9745) и Sunset (RFC 8594).
+func deprecated(next http.Handler) http.Handler {
+	deprecation := "@" + strconv.FormatInt(legacyDeprecatedAt.Unix(), 10)
+	sunset := legacySunset.Format(http.TimeFormat)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", deprecation)
+		w.Header().Set("Sunset", sunset)
+		next.ServeHTTP(w, r)
+	})
+}
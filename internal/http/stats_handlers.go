@@ -0,0 +1,333 @@
+package http
+
+import (
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/validation"
+	"encoding/csv"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type percentilesDTO struct {
+	Count      int64   `json:"count"`
+	P50Seconds float64 `json:"p50_seconds"`
+	P90Seconds float64 `json:"p90_seconds"`
+	P99Seconds float64 `json:"p99_seconds"`
+}
+
+type latencyStatsDTO struct {
+	Key                string         `json:"key,omitempty"`
+	TimeToMerge        percentilesDTO `json:"time_to_merge"`
+	TimeToFirstVerdict percentilesDTO `json:"time_to_first_verdict"`
+}
+
+func percentilesToDTO(p domain.Percentiles) percentilesDTO {
+	return percentilesDTO{
+		Count:      p.Count,
+		P50Seconds: p.P50.Seconds(),
+		P90Seconds: p.P90.Seconds(),
+		P99Seconds: p.P99.Seconds(),
+	}
+}
+
+func latencyStatsToDTO(s domain.LatencyStats) latencyStatsDTO {
+	return latencyStatsDTO{
+		Key:                s.Key,
+		TimeToMerge:        percentilesToDTO(s.TimeToMerge),
+		TimeToFirstVerdict: percentilesToDTO(s.TimeToFirstVerdict),
+	}
+}
+
+// StatsLatency GET /stats/latency?from=...&to=...&team=...
+func (h *PRHandler) StatsLatency(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	var v validation.Validator
+	from, to := parseTimeWindow(&v, q)
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	stats, err := h.svc.GetLatencyStats(r.Context(), domain.LatencyFilter{
+		From:     from,
+		To:       to,
+		TeamName: q.Get("team"),
+	})
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	resp := struct {
+		Overall    latencyStatsDTO   `json:"overall"`
+		ByTeam     []latencyStatsDTO `json:"by_team"`
+		ByAuthor   []latencyStatsDTO `json:"by_author"`
+		ByReviewer []latencyStatsDTO `json:"by_reviewer"`
+	}{
+		ByTeam:     make([]latencyStatsDTO, 0),
+		ByAuthor:   make([]latencyStatsDTO, 0),
+		ByReviewer: make([]latencyStatsDTO, 0),
+	}
+
+	for _, s := range stats {
+		dto := latencyStatsToDTO(s)
+		switch s.Dimension {
+		case domain.LatencyOverall:
+			resp.Overall = dto
+		case domain.LatencyByTeam:
+			resp.ByTeam = append(resp.ByTeam, dto)
+		case domain.LatencyByAuthor:
+			resp.ByAuthor = append(resp.ByAuthor, dto)
+		case domain.LatencyByReviewer:
+			resp.ByReviewer = append(resp.ByReviewer, dto)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+type fairnessIndexDTO struct {
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stddev"`
+	Gini   float64 `json:"gini"`
+	// null, если у кого-то из активных ноль назначений, а у других нет
+	MaxMinRatio *float64 `json:"max_min_ratio"`
+}
+
+type loadDistributionDTO struct {
+	Fairness   fairnessIndexDTO `json:"fairness"`
+	Overloaded []string         `json:"overloaded"`
+	Underused  []string         `json:"underused"`
+}
+
+type reviewerLoadDTO struct {
+	UserID           string `json:"user_id"`
+	IsActive         bool   `json:"is_active"`
+	OpenAssignments  int64  `json:"open_assignments"`
+	TotalAssignments int64  `json:"total_assignments"`
+}
+
+type teamFairnessDTO struct {
+	TeamName   string              `json:"team_name"`
+	Members    []reviewerLoadDTO   `json:"members"`
+	Open       loadDistributionDTO `json:"open"`
+	Historical loadDistributionDTO `json:"historical"`
+}
+
+func loadDistributionToDTO(d domain.LoadDistribution) loadDistributionDTO {
+	dto := loadDistributionDTO{
+		Fairness: fairnessIndexDTO{
+			Mean:   d.Index.Mean,
+			StdDev: d.Index.StdDev,
+			Gini:   d.Index.Gini,
+		},
+		Overloaded: d.Overloaded,
+		Underused:  d.Underused,
+	}
+	if !math.IsInf(d.Index.MaxMinRatio, 1) {
+		ratio := d.Index.MaxMinRatio
+		dto.Fairness.MaxMinRatio = &ratio
+	}
+	return dto
+}
+
+func teamFairnessToDTO(t domain.TeamFairness) teamFairnessDTO {
+	dto := teamFairnessDTO{
+		TeamName:   t.TeamName,
+		Members:    make([]reviewerLoadDTO, 0, len(t.Members)),
+		Open:       loadDistributionToDTO(t.Open),
+		Historical: loadDistributionToDTO(t.Historical),
+	}
+	for _, m := range t.Members {
+		dto.Members = append(dto.Members, reviewerLoadDTO{
+			UserID:           m.UserID,
+			IsActive:         m.IsActive,
+			OpenAssignments:  m.Open,
+			TotalAssignments: m.Total,
+		})
+	}
+	return dto
+}
+
+// StatsFairness GET /stats/fairness?team=...
+func (h *PRHandler) StatsFairness(w http.ResponseWriter, r *http.Request) {
+	reports, err := h.svc.GetFairnessReport(r.Context(), r.URL.Query().Get("team"))
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	resp := struct {
+		Teams []teamFairnessDTO `json:"teams"`
+	}{
+		Teams: make([]teamFairnessDTO, 0, len(reports)),
+	}
+	for _, t := range reports {
+		resp.Teams = append(resp.Teams, teamFairnessToDTO(t))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+const (
+	defaultTopPairs = 10
+	maxTopPairs     = 100
+)
+
+type reviewPairDTO struct {
+	AuthorID    string `json:"author_id"`
+	ReviewerID  string `json:"reviewer_id"`
+	Assignments int64  `json:"assignments"`
+}
+
+// StatsPairs GET /stats/pairs?team=...&from=...&to=...&top=...&format=json|csv
+func (h *PRHandler) StatsPairs(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	var v validation.Validator
+	from, to := parseTimeWindow(&v, q)
+
+	topN := defaultTopPairs
+	if raw := q.Get("top"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		v.Check(err == nil && n >= 0 && n <= maxTopPairs,
+			"top", "must be an integer from 0 to "+strconv.Itoa(maxTopPairs))
+		topN = n
+	}
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	matrix, err := h.svc.GetReviewPairs(r.Context(), domain.ReviewPairsFilter{
+		From:     from,
+		To:       to,
+		TeamName: q.Get("team"),
+	}, topN)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	if wantsCSV(r) {
+		writePairsCSV(w, matrix)
+		return
+	}
+
+	resp := struct {
+		Authors   []string        `json:"authors"`
+		Reviewers []string        `json:"reviewers"`
+		Matrix    [][]int64       `json:"matrix"`
+		TopPairs  []reviewPairDTO `json:"top_pairs"`
+	}{
+		Authors:   matrix.Authors,
+		Reviewers: matrix.Reviewers,
+		Matrix:    matrix.Counts,
+		TopPairs:  make([]reviewPairDTO, 0, len(matrix.Top)),
+	}
+	for _, p := range matrix.Top {
+		resp.TopPairs = append(resp.TopPairs, reviewPairDTO{
+			AuthorID:    p.AuthorID,
+			ReviewerID:  p.ReviewerID,
+			Assignments: p.Count,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// writePairsCSV пишет матрицу таблицей: строки — авторы, колонки — ревьюверы.
+func writePairsCSV(w http.ResponseWriter, m domain.PairMatrix) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="review_pairs.csv"`)
+	w.WriteHeader(http.StatusOK)
+
+	cw := csv.NewWriter(w)
+	_ = cw.Write(append([]string{`author \ reviewer`}, m.Reviewers...))
+	for i, author := range m.Authors {
+		row := make([]string, 0, len(m.Reviewers)+1)
+		row = append(row, author)
+		for _, c := range m.Counts[i] {
+			row = append(row, strconv.FormatInt(c, 10))
+		}
+		_ = cw.Write(row)
+	}
+	cw.Flush()
+}
+
+// wantsCSV выбирает CSV по параметру format=csv или по Accept: text/csv.
+func wantsCSV(r *http.Request) bool {
+	if f := r.URL.Query().Get("format"); f != "" {
+		return f == "csv"
+	}
+	return strings.Contains(r.Header.Get("Accept"), "text/csv")
+}
+
+// parseAssignmentStatsFilter разбирает фильтр статистики назначений из query.
+func parseAssignmentStatsFilter(v *validation.Validator, q url.Values) domain.AssignmentStatsFilter {
+	f := domain.AssignmentStatsFilter{
+		TeamName: q.Get("team"),
+		Status:   domain.PRStatus(q.Get("status")),
+		GroupBy:  domain.StatsGrouping(q.Get("group_by")),
+	}
+
+	validateStatusParam(v, f.Status)
+	v.Check(f.GroupBy.Valid(), "group_by", "must be day or week")
+	f.From, f.To = parseTimeWindow(v, q)
+	return f
+}
+
+// validateStatusParam необязательный фильтр status.
+func validateStatusParam(v *validation.Validator, status domain.PRStatus) {
+	switch status {
+	case "", domain.PRStatusOpen, domain.PRStatusMerged, domain.PRStatusClosed:
+	default:
+		v.Add("status", "must be one of OPEN, MERGED, CLOSED")
+	}
+}
+
+// parseTimeWindow разбирает from/to: RFC 3339 или дата 2006-01-02 (начало суток UTC); to не включается.
+// Отсутствующая или неразобранная граница — nil, ошибки копятся в v.
+func parseTimeWindow(v *validation.Validator, q url.Values) (from, to *time.Time) {
+	for _, p := range []struct {
+		key string
+		dst **time.Time
+	}{
+		{"from", &from},
+		{"to", &to},
+	} {
+		raw := q.Get(p.key)
+		if raw == "" {
+			continue
+		}
+		t, err := parseTimeParam(raw)
+		if err != nil {
+			v.Add(p.key, "must be an RFC 3339 timestamp or a YYYY-MM-DD date")
+			continue
+		}
+		*p.dst = &t
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		v.Add("to", "must be after from")
+	}
+	return from, to
+}
+
+func parseTimeParam(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, v)
+}
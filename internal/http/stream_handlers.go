@@ -0,0 +1,168 @@
+package http
+
+import (
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/validation"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// через сколько клиенту EventSource переподключаться после обрыва, мс
+const sseRetryMillis = 3000
+
+type reviewStreamEventDTO struct {
+	Event              string         `json:"event"`
+	ReviewerID         string         `json:"reviewer_id,omitempty"`
+	ReplacedReviewerID string         `json:"replaced_reviewer_id,omitempty"`
+	PullRequest        pullRequestDTO `json:"pull_request"`
+	OccurredAt         time.Time      `json:"occurredAt"`
+}
+
+// StreamHandler отдаёт события по SSE.
+type StreamHandler struct {
+	users     app.UserService
+	bus       *app.EventBus
+	heartbeat time.Duration
+}
+
+// NewStreamHandler создаёт обработчик SSE-стримов. heartbeat — период комментариев-пингов,
+// чтобы прокси и балансировщики не рвали простаивающее соединение.
+func NewStreamHandler(users app.UserService, bus *app.EventBus, heartbeat time.Duration) *StreamHandler {
+	return &StreamHandler{users: users, bus: bus, heartbeat: heartbeat}
+}
+
+// ReviewStream GET /api/v1/users/{user_id}/review-stream (legacy GET /users/reviewStream?user_id=...)
+//
+// При первом подключении (или если пропущенные события уже не восстановить) первым
+// приходит event: snapshot с текущей очередью, как в /users/getReview. Дальше —
+// reviewer.assigned, reviewer.unassigned и pull_request.merged, касающиеся пользователя.
+// При переподключении с Last-Event-ID пропущенные события досылаются из истории.
+func (h *StreamHandler) ReviewStream(w http.ResponseWriter, r *http.Request) {
+	userID := pathOrQuery(r, "user_id")
+	var v validation.Validator
+	v.ID("user_id", userID)
+
+	var lastEventID uint64
+	if raw := r.Header.Get("Last-Event-ID"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 64)
+		v.Check(err == nil, "Last-Event-ID", "must be a non-negative integer")
+		lastEventID = id
+	}
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+	if err := authorizeUser(r, userID); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	// подписываемся до снапшота, чтобы не потерять события между ними
+	sub := h.bus.Subscribe(lastEventID)
+	defer sub.Close()
+
+	var snapshot []pullRequestShortDTO
+	if lastEventID == 0 || sub.Missed {
+		prs, err := h.users.GetReviewPRs(r.Context(), userID)
+		if err != nil {
+			WriteError(w, r, err)
+			return
+		}
+		snapshot = make([]pullRequestShortDTO, 0, len(prs))
+		for _, pr := range prs {
+			snapshot = append(snapshot, pullRequestToShortDTO(pr))
+		}
+	}
+
+	rc := http.NewResponseController(w)
+	// стрим живёт дольше HTTP_WRITE_TIMEOUT
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // nginx не должен буферизовать стрим
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", sseRetryMillis); err != nil {
+		return
+	}
+	if snapshot != nil {
+		data, _ := json.Marshal(struct {
+			UserID       string                `json:"user_id"`
+			PullRequests []pullRequestShortDTO `json:"pull_requests"`
+		}{
+			UserID:       userID,
+			PullRequests: snapshot,
+		})
+		if _, err := fmt.Fprintf(w, "event: snapshot\ndata: %s\n\n", data); err != nil {
+			return
+		}
+	}
+	for _, be := range sub.Replay {
+		if err := writeReviewEvent(w, userID, be); err != nil {
+			return
+		}
+	}
+	// последний просмотренный id шины, включая чужие события
+	seen := sub.LastID
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case be, ok := <-sub.Events:
+			if !ok {
+				// отстали от шины — клиент переподключится с Last-Event-ID и дочитает
+				return
+			}
+			if err := writeReviewEvent(w, userID, be); err != nil {
+				return
+			}
+			seen = be.ID
+		case <-ticker.C:
+			// блок без data событие не порождает, но id в нём двигает Last-Event-ID клиента:
+			// после переподключения не придётся перебирать чужие события из истории
+			if _, err := fmt.Fprintf(w, ": heartbeat\nid: %d\n\n", seen); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// writeReviewEvent пишет событие, если оно касается очереди пользователя.
+func writeReviewEvent(w http.ResponseWriter, userID string, be app.BusEvent) error {
+	ev := be.Event
+	if !ev.ConcernsReviewer(userID) {
+		return nil
+	}
+
+	data, err := json.Marshal(reviewStreamEventDTO{
+		Event:              string(ev.Type),
+		ReviewerID:         ev.ReviewerID,
+		ReplacedReviewerID: ev.ReplacedReviewerID,
+		PullRequest:        pullRequestToDTO(ev.PullRequest),
+		OccurredAt:         ev.OccurredAt.UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", be.ID, ev.Type, data)
+	return err
+}
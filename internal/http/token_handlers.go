@@ -0,0 +1,141 @@
+package http
+
+import (
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/validation"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type apiTokenDTO struct {
+	TokenID   int64      `json:"token_id"`
+	Scope     string     `json:"scope"`
+	UserID    string     `json:"user_id,omitempty"`
+	Name      string     `json:"name"`
+	CreatedAt time.Time  `json:"createdAt"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+}
+
+func apiTokenToDTO(t domain.APIToken) apiTokenDTO {
+	return apiTokenDTO{
+		TokenID:   t.ID,
+		Scope:     string(t.Scope),
+		UserID:    t.UserID,
+		Name:      t.Name,
+		CreatedAt: t.CreatedAt,
+		RevokedAt: t.RevokedAt,
+	}
+}
+
+// TokenHandler обрабатывает выпуск и отзыв токенов API. Все ручки — только для admin-токенов.
+type TokenHandler struct {
+	svc app.TokenService
+}
+
+// NewTokenHandler создаёт обработчик токенов.
+func NewTokenHandler(svc app.TokenService) *TokenHandler {
+	return &TokenHandler{svc: svc}
+}
+
+// Issue POST /api/v1/tokens — токен в ответе показывается один раз, в БД лежит только его хэш.
+func (h *TokenHandler) Issue(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Scope  string `json:"scope"`
+		UserID string `json:"user_id"`
+		Name   string `json:"name"`
+	}
+
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	scope := domain.Scope(req.Scope)
+	var v validation.Validator
+	if v.Required("scope", req.Scope) {
+		v.Check(scope.Valid(), "scope", "must be admin or user")
+	}
+	switch scope {
+	case domain.ScopeUser:
+		v.ID("user_id", req.UserID)
+	case domain.ScopeAdmin:
+		v.Check(req.UserID == "", "user_id", "must be empty for admin scope")
+	}
+	v.MaxLen("name", req.Name, validation.MaxNameLen)
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	token, secret, err := h.svc.Issue(r.Context(), domain.APIToken{
+		Scope:  scope,
+		UserID: req.UserID,
+		Name:   req.Name,
+	})
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	resp := struct {
+		Token  apiTokenDTO `json:"token"`
+		Secret string      `json:"secret"`
+	}{
+		Token:  apiTokenToDTO(token),
+		Secret: secret,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// List GET /api/v1/tokens — все токены, включая отозванные, без секретов.
+func (h *TokenHandler) List(w http.ResponseWriter, r *http.Request) {
+	tokens, err := h.svc.List(r.Context())
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	resp := struct {
+		Tokens []apiTokenDTO `json:"tokens"`
+	}{
+		Tokens: make([]apiTokenDTO, 0, len(tokens)),
+	}
+	for _, t := range tokens {
+		resp.Tokens = append(resp.Tokens, apiTokenToDTO(t))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// Revoke DELETE /api/v1/tokens/{token_id}
+func (h *TokenHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("token_id"), 10, 64)
+	if err != nil || id <= 0 {
+		WriteError(w, r, validation.Invalid("token_id", "must be a positive integer"))
+		return
+	}
+
+	token, err := h.svc.Revoke(r.Context(), id)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	resp := struct {
+		Token apiTokenDTO `json:"token"`
+	}{
+		Token: apiTokenToDTO(token),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"avi_internship_autumn/internal/validation"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+)
+
+// decodeBody разбирает JSON-тело в dst; при ошибке сразу отвечает VALIDATION_ERROR.
+func decodeBody(w http.ResponseWriter, r *http.Request, dst any) bool {
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		WriteError(w, r, bodyError(err))
+		return false
+	}
+	return true
+}
+
+// bodyError переводит ошибку json-декодера в ошибку поля. Поле известно только
+// при несовпадении типа, остальное относится к телу целиком.
+func bodyError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	var maxErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return validation.Invalid(typeErr.Field, "must be "+jsonKind(typeErr.Type))
+	case errors.As(err, &maxErr):
+		return validation.Invalid("body", "must be at most "+strconv.FormatInt(maxErr.Limit, 10)+" bytes")
+	case errors.Is(err, io.EOF):
+		return validation.Invalid("body", "is required")
+	default:
+		return validation.Invalid("body", "must be valid JSON")
+	}
+}
+
+func jsonKind(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Struct, reflect.Map:
+		return "an object"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	default:
+		return "a " + t.Kind().String()
+	}
+}
@@ -0,0 +1,335 @@
+package http
+
+import (
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/validation"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultDeliveriesLimit = 50
+	maxDeliveriesLimit     = 500
+)
+
+type webhookSubscriptionDTO struct {
+	SubscriptionID int64     `json:"subscription_id"`
+	TeamName       string    `json:"team_name"`
+	URL            string    `json:"url"`
+	EventTypes     []string  `json:"event_types"`
+	IsActive       bool      `json:"is_active"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type webhookDeliveryDTO struct {
+	DeliveryID     int64           `json:"delivery_id"`
+	SubscriptionID int64           `json:"subscription_id"`
+	EventType      string          `json:"event_type"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"nextAttemptAt"`
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	Payload        json.RawMessage `json:"payload"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	DeliveredAt    *time.Time      `json:"deliveredAt,omitempty"`
+}
+
+// секрет наружу не отдаём — он известен только тому, кто создал подписку
+func webhookSubscriptionToDTO(s domain.WebhookSubscription) webhookSubscriptionDTO {
+	types := make([]string, 0, len(s.EventTypes))
+	for _, t := range s.EventTypes {
+		types = append(types, string(t))
+	}
+	return webhookSubscriptionDTO{
+		SubscriptionID: s.ID,
+		TeamName:       s.TeamName,
+		URL:            s.URL,
+		EventTypes:     types,
+		IsActive:       s.IsActive,
+		CreatedAt:      s.CreatedAt,
+	}
+}
+
+func webhookDeliveryToDTO(d domain.WebhookDelivery) webhookDeliveryDTO {
+	return webhookDeliveryDTO{
+		DeliveryID:     d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventType:      string(d.EventType),
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		Payload:        json.RawMessage(d.Payload),
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt,
+	}
+}
+
+// WebhookHandler обрабатывает HTTP-запросы управления вебхуками.
+type WebhookHandler struct {
+	svc app.WebhookService
+}
+
+// NewWebhookHandler создаёт обработчик вебхуков.
+func NewWebhookHandler(svc app.WebhookService) *WebhookHandler {
+	return &WebhookHandler{svc: svc}
+}
+
+// Subscribe POST /api/v1/teams/{team_name}/webhooks (legacy POST /webhooks/subscribe)
+func (h *WebhookHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TeamName   string   `json:"team_name"`
+		URL        string   `json:"url"`
+		Secret     string   `json:"secret"`
+		EventTypes []string `json:"event_types"`
+	}
+
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	bindPath(r, "team_name", &req.TeamName)
+
+	sub := domain.WebhookSubscription{
+		TeamName:   req.TeamName,
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: make([]domain.EventType, 0, len(req.EventTypes)),
+	}
+
+	var v validation.Validator
+	v.ID("team_name", req.TeamName)
+	if v.Required("url", req.URL) {
+		v.HTTPURL("url", req.URL)
+	}
+	if v.Required("secret", req.Secret) {
+		v.MaxLen("secret", req.Secret, validation.MaxNameLen)
+	}
+	v.MaxItems("event_types", len(req.EventTypes), validation.MaxListItems)
+	for i, t := range req.EventTypes {
+		et := domain.EventType(t)
+		v.Check(et.Valid(), validation.Index("event_types", i), "unknown event type")
+		sub.EventTypes = append(sub.EventTypes, et)
+	}
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	created, err := h.svc.Subscribe(r.Context(), sub)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	resp := struct {
+		Subscription webhookSubscriptionDTO `json:"subscription"`
+	}{
+		Subscription: webhookSubscriptionToDTO(created),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// List GET /api/v1/teams/{team_name}/webhooks (legacy GET /webhooks/list?team_name=...)
+func (h *WebhookHandler) List(w http.ResponseWriter, r *http.Request) {
+	teamName := pathOrQuery(r, "team_name")
+	var v validation.Validator
+	v.ID("team_name", teamName)
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	subs, err := h.svc.ListSubscriptions(r.Context(), teamName)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	resp := struct {
+		TeamName      string                   `json:"team_name"`
+		Subscriptions []webhookSubscriptionDTO `json:"subscriptions"`
+	}{
+		TeamName:      teamName,
+		Subscriptions: make([]webhookSubscriptionDTO, 0, len(subs)),
+	}
+	for _, s := range subs {
+		resp.Subscriptions = append(resp.Subscriptions, webhookSubscriptionToDTO(s))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// Deliveries GET /api/v1/webhooks/{subscription_id}/deliveries?limit=...
+// (legacy GET /webhooks/deliveries?subscription_id=...&limit=...)
+func (h *WebhookHandler) Deliveries(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	var v validation.Validator
+	subscriptionID, err := strconv.ParseInt(pathOrQuery(r, "subscription_id"), 10, 64)
+	v.Check(err == nil, "subscription_id", "must be an integer")
+
+	limit := defaultDeliveriesLimit
+	if raw := q.Get("limit"); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		v.Check(err == nil && limit > 0 && limit <= maxDeliveriesLimit,
+			"limit", "must be an integer from 1 to "+strconv.Itoa(maxDeliveriesLimit))
+	}
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	deliveries, err := h.svc.ListDeliveries(r.Context(), subscriptionID, limit)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	resp := struct {
+		SubscriptionID int64                `json:"subscription_id"`
+		Deliveries     []webhookDeliveryDTO `json:"deliveries"`
+	}{
+		SubscriptionID: subscriptionID,
+		Deliveries:     make([]webhookDeliveryDTO, 0, len(deliveries)),
+	}
+	for _, d := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, webhookDeliveryToDTO(d))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// Replay POST /api/v1/webhook-deliveries/{delivery_id}/replay (legacy POST /webhooks/replay)
+func (h *WebhookHandler) Replay(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		DeliveryID int64 `json:"delivery_id"`
+	}
+
+	if err := decodeOptionalBody(r, &req); err != nil {
+		WriteError(w, r, bodyError(err))
+		return
+	}
+	if raw := r.PathValue("delivery_id"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			WriteError(w, r, validation.Invalid("delivery_id", "must be an integer"))
+			return
+		}
+		req.DeliveryID = id
+	}
+	if req.DeliveryID <= 0 {
+		WriteError(w, r, validation.Invalid("delivery_id", "must be a positive integer"))
+		return
+	}
+
+	delivery, err := h.svc.ReplayDelivery(r.Context(), req.DeliveryID)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	resp := struct {
+		Delivery webhookDeliveryDTO `json:"delivery"`
+	}{
+		Delivery: webhookDeliveryToDTO(delivery),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// SetActive PUT /api/v1/webhooks/{subscription_id}/active (legacy POST /webhooks/setActive)
+// Выключение переводит недоставленные события подписки в DEAD.
+func (h *WebhookHandler) SetActive(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		SubscriptionID int64 `json:"subscription_id"`
+		IsActive       bool  `json:"is_active"`
+	}
+
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if err := bindSubscriptionID(r, &req.SubscriptionID); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	sub, err := h.svc.SetSubscriptionActive(r.Context(), req.SubscriptionID, req.IsActive)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+	writeSubscription(w, sub)
+}
+
+// RotateSecret PUT /api/v1/webhooks/{subscription_id}/secret (legacy POST /webhooks/rotateSecret)
+func (h *WebhookHandler) RotateSecret(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		SubscriptionID int64  `json:"subscription_id"`
+		Secret         string `json:"secret"`
+	}
+
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if err := bindSubscriptionID(r, &req.SubscriptionID); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	var v validation.Validator
+	if v.Required("secret", req.Secret) {
+		v.MaxLen("secret", req.Secret, validation.MaxNameLen)
+	}
+	if err := v.Err(); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	sub, err := h.svc.RotateSecret(r.Context(), req.SubscriptionID, req.Secret)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+	writeSubscription(w, sub)
+}
+
+// bindSubscriptionID берёт subscription_id из пути v1-роута; у legacy-алиаса он приходит в теле.
+func bindSubscriptionID(r *http.Request, id *int64) error {
+	if raw := r.PathValue("subscription_id"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return validation.Invalid("subscription_id", "must be an integer")
+		}
+		*id = parsed
+	}
+	if *id <= 0 {
+		return validation.Invalid("subscription_id", "must be a positive integer")
+	}
+	return nil
+}
+
+func writeSubscription(w http.ResponseWriter, sub domain.WebhookSubscription) {
+	resp := struct {
+		Subscription webhookSubscriptionDTO `json:"subscription"`
+	}{
+		Subscription: webhookSubscriptionToDTO(sub),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,60 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// RequestIDHeader заголовок, в котором ID приходит от клиента или прокси и возвращается в ответе.
+const RequestIDHeader = "X-Request-ID"
+
+// длиннее не принимаем: ID попадает в каждую строку лога
+const maxRequestIDLen = 128
+
+type requestIDKey struct{}
+
+// WithRequestID кладёт ID в context.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID достаёт ID из context; пусто, если запрос пришёл не через Middleware.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Middleware берёт X-Request-ID из запроса или генерирует новый, возвращает его в ответе
+// и кладёт в context — дальше он доступен сервисам и репозиториям.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !ValidRequestID(id) {
+			id = NewRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(WithRequestID(r.Context(), id)))
+	})
+}
+
+// ValidRequestID пропускает только печатный ASCII без пробелов, чтобы ID не ломал логи.
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// NewRequestID случайный ID для запроса, пришедшего без X-Request-ID.
+func NewRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
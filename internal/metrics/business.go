@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"avi_internship_autumn/internal/repository"
+)
+
+// сколько даём запросам в БД на один скрейп
+const scrapeTimeout = 5 * time.Second
+
+// businessCollector считает срезы по данным в момент скрейпа: так метрики
+// одинаковы на всех репликах и не расходятся с БД после рестарта.
+type businessCollector struct {
+	prs repository.PRRepository
+
+	openPRs             *prometheus.Desc
+	openAssignments     *prometheus.Desc
+	historicAssignments *prometheus.Desc
+}
+
+func newBusinessCollector(prs repository.PRRepository) *businessCollector {
+	return &businessCollector{
+		prs: prs,
+		openPRs: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "open_pull_requests"),
+			"Open pull requests by author's team.",
+			[]string{"team"}, nil,
+		),
+		openAssignments: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "reviewer_open_assignments"),
+			"Open pull requests assigned to the reviewer.",
+			[]string{"team", "reviewer"}, nil,
+		),
+		historicAssignments: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "reviewer_assignments"),
+			"All assignments of the reviewer that are still on record.",
+			[]string{"team", "reviewer"}, nil,
+		),
+	}
+}
+
+func (c *businessCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.openPRs
+	ch <- c.openAssignments
+	ch <- c.historicAssignments
+}
+
+func (c *businessCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), scrapeTimeout)
+	defer cancel()
+
+	openByTeam, err := c.prs.CountOpenByTeam(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "metrics: count open PRs", "error", err)
+		ch <- prometheus.NewInvalidMetric(c.openPRs, err)
+	}
+	for team, n := range openByTeam {
+		ch <- prometheus.MustNewConstMetric(c.openPRs, prometheus.GaugeValue, float64(n), team)
+	}
+
+	loads, err := c.prs.ListReviewerLoads(ctx, "")
+	if err != nil {
+		slog.ErrorContext(ctx, "metrics: list reviewer loads", "error", err)
+		ch <- prometheus.NewInvalidMetric(c.openAssignments, err)
+		return
+	}
+	for _, l := range loads {
+		ch <- prometheus.MustNewConstMetric(c.openAssignments, prometheus.GaugeValue, float64(l.Open), l.TeamName, l.UserID)
+		ch <- prometheus.MustNewConstMetric(c.historicAssignments, prometheus.GaugeValue, float64(l.Total), l.TeamName, l.UserID)
+	}
+}
@@ -0,0 +1,99 @@
+// Package metrics собирает метрики сервиса в формате Prometheus:
+// HTTP по маршрутам, пул соединений к БД и бизнес-метрики по PR и ревьюверам.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"avi_internship_autumn/internal/repository"
+)
+
+const namespace = "pr_reviewer"
+
+// Metrics реестр и все коллекторы сервиса.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	reassignments    *prometheus.CounterVec
+	noCandidate      *prometheus.CounterVec
+	bulkDeactivation *prometheus.CounterVec
+	bulkUsers        prometheus.Counter
+	bulkAffectedPRs  prometheus.Counter
+}
+
+// New регистрирует метрики. db нужен для статистики пула соединений,
+// prs — для бизнес-метрик, которые считаются запросом в момент скрейпа.
+func New(db *sql.DB, prs repository.PRRepository) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "HTTP requests by route, method and status code.",
+		}, []string{"route", "method", "code"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by route and method.",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+		}, []string{"route", "method"}),
+
+		reassignments: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reassignments_total",
+			Help:      "Reviewer reassignment attempts by outcome (ok or error code).",
+		}, []string{"outcome"}),
+		noCandidate: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "no_candidate_total",
+			Help:      "Operations that found no active candidate reviewer.",
+		}, []string{"operation"}),
+		bulkDeactivation: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "bulk_deactivations_total",
+			Help:      "Bulk team deactivation calls by outcome.",
+		}, []string{"outcome"}),
+		bulkUsers: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "bulk_deactivated_users_total",
+			Help:      "Users deactivated by bulk deactivation.",
+		}),
+		bulkAffectedPRs: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "bulk_deactivation_affected_prs_total",
+			Help:      "Open pull requests whose reviewers were changed by bulk deactivation.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		collectors.NewDBStatsCollector(db, "postgres"),
+		newBusinessCollector(prs),
+		m.httpRequests,
+		m.httpDuration,
+		m.reassignments,
+		m.noCandidate,
+		m.bulkDeactivation,
+		m.bulkUsers,
+		m.bulkAffectedPRs,
+	)
+
+	return m
+}
+
+// Handler отдаёт метрики для скрейпа (GET /metrics).
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
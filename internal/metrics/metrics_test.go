@@ -0,0 +1,154 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/domain"
+	apihttp "avi_internship_autumn/internal/http"
+	"avi_internship_autumn/internal/repository"
+)
+
+// fakePRs отдаёт бизнес-коллектору фиксированные срезы.
+type fakePRs struct {
+	repository.PRRepository
+}
+
+func (fakePRs) CountOpenByTeam(context.Context) (map[string]int64, error) {
+	return map[string]int64{"backend": 2}, nil
+}
+
+func (fakePRs) ListReviewerLoads(context.Context, string) ([]domain.ReviewerLoad, error) {
+	return []domain.ReviewerLoad{{UserID: "u2", TeamName: "backend", IsActive: true, Open: 1, Total: 4}}, nil
+}
+
+// fakePRService переназначает u2 и не находит замену остальным.
+type fakePRService struct {
+	app.PRService
+}
+
+func (fakePRService) ReassignReviewer(_ context.Context, prID, oldReviewerID string) (domain.PullRequest, string, error) {
+	if oldReviewerID != "u2" {
+		return domain.PullRequest{}, "", domain.ErrNoCandidate
+	}
+	return domain.PullRequest{ID: prID, AuthorID: "u1", Status: domain.PRStatusOpen}, "u3", nil
+}
+
+// fakeUserService деактивирует двух пользователей и трогает три PR.
+type fakeUserService struct {
+	app.UserService
+}
+
+func (fakeUserService) BulkDeactivateTeam(_ context.Context, teamName string, _ []string) (domain.BulkDeactivateResult, error) {
+	return domain.BulkDeactivateResult{TeamName: teamName, DeactivatedUsers: 2, AffectedPRs: 3}, nil
+}
+
+// adminTokens пускает любой токен с правами admin.
+type adminTokens struct {
+	app.TokenService
+}
+
+func (adminTokens) Authenticate(context.Context, string) (domain.Principal, error) {
+	return domain.Principal{Scope: domain.ScopeAdmin}, nil
+}
+
+func TestMetrics(t *testing.T) {
+	// DBStats не ходит в базу: хватает пула, который ни разу не подключался
+	db, err := sql.Open("postgres", "postgres://localhost:1/none?sslmode=disable")
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	m := New(db, fakePRs{})
+	router := apihttp.NewRouter(nil, m.InstrumentUserService(fakeUserService{}), m.InstrumentPRService(fakePRService{}),
+		nil, nil, adminTokens{}, nil, apihttp.IntegrationSecrets{}, apihttp.Limits{}, app.NewEventBus(1), time.Second)
+	// как в main.go: метрики сразу над роутером
+	h := m.Middleware(router)
+
+	requests := []struct {
+		method, target, body string
+		code                 int
+	}{
+		{http.MethodPost, "/api/v1/pull-requests/pr-1/reassign", `{"old_user_id":"u2"}`, http.StatusOK},
+		{http.MethodPost, "/api/v1/pull-requests/pr-1/reassign", `{"old_user_id":"u4"}`, http.StatusConflict},
+		{http.MethodPost, "/pullRequest/reassign", `{"pull_request_id":"pr-1","old_user_id":"u2"}`, http.StatusOK},
+		{http.MethodPost, "/api/v1/teams/backend/users/deactivate", `{}`, http.StatusOK},
+		{http.MethodGet, "/no/such/route", "", http.StatusNotFound},
+	}
+	for _, r := range requests {
+		req := httptest.NewRequest(r.method, r.target, strings.NewReader(r.body))
+		req.Header.Set("Authorization", "Bearer token")
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != r.code {
+			t.Fatalf("%s %s: status %d %s, want %d", r.method, r.target, rec.Code, rec.Body.String(), r.code)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("scrape status %d", rec.Code)
+	}
+	body, _ := io.ReadAll(rec.Body)
+	scraped := string(body)
+
+	tests := []struct {
+		name string
+		line string
+	}{
+		// метка route — шаблон маршрута, а не путь с идентификаторами
+		{"pattern route", `pr_reviewer_http_requests_total{code="200",method="POST",route="/api/v1/pull-requests/{pull_request_id}/reassign"} 1`},
+		{"pattern route error", `pr_reviewer_http_requests_total{code="409",method="POST",route="/api/v1/pull-requests/{pull_request_id}/reassign"} 1`},
+		{"legacy route", `pr_reviewer_http_requests_total{code="200",method="POST",route="/pullRequest/reassign"} 1`},
+		{"unmatched route", `pr_reviewer_http_requests_total{code="404",method="GET",route="unmatched"} 1`},
+		{"duration", `pr_reviewer_http_request_duration_seconds_count{method="POST",route="/api/v1/teams/{team_name}/users/deactivate"} 1`},
+		{"reassign ok", `pr_reviewer_reassignments_total{outcome="ok"} 2`},
+		{"reassign no candidate", `pr_reviewer_reassignments_total{outcome="no_candidate"} 1`},
+		{"no candidate", `pr_reviewer_no_candidate_total{operation="reassign"} 1`},
+		{"bulk deactivation", `pr_reviewer_bulk_deactivations_total{outcome="ok"} 1`},
+		{"bulk users", `pr_reviewer_bulk_deactivated_users_total 2`},
+		{"bulk prs", `pr_reviewer_bulk_deactivation_affected_prs_total 3`},
+		{"open prs", `pr_reviewer_open_pull_requests{team="backend"} 2`},
+		{"open assignments", `pr_reviewer_reviewer_open_assignments{reviewer="u2",team="backend"} 1`},
+		{"historic assignments", `pr_reviewer_reviewer_assignments{reviewer="u2",team="backend"} 4`},
+		{"db stats", `go_sql_max_open_connections{db_name="postgres"} 0`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(scraped, tt.line+"\n") {
+				t.Fatalf("scrape has no line %q:\n%s", tt.line, scraped)
+			}
+		})
+	}
+}
+
+func TestOutcome(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, "ok"},
+		{domain.ErrNoCandidate, "no_candidate"},
+		{domain.ErrNotAssigned, "not_assigned"},
+		{domain.ErrPRMerged, "pr_merged"},
+		{domain.ErrForbidden, "forbidden"},
+		{io.EOF, "error"},
+	}
+	for _, tt := range tests {
+		if got := outcome(tt.err); got != tt.want {
+			t.Fatalf("outcome(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/domain"
+)
+
+// InstrumentPRService оборачивает сервис PR счётчиками переназначений.
+func (m *Metrics) InstrumentPRService(svc app.PRService) app.PRService {
+	return &prService{PRService: svc, m: m}
+}
+
+// InstrumentUserService оборачивает сервис пользователей счётчиками массовой деактивации.
+func (m *Metrics) InstrumentUserService(svc app.UserService) app.UserService {
+	return &userService{UserService: svc, m: m}
+}
+
+type prService struct {
+	app.PRService
+	m *Metrics
+}
+
+func (s *prService) ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (domain.PullRequest, string, error) {
+	pr, replacedBy, err := s.PRService.ReassignReviewer(ctx, prID, oldReviewerID)
+
+	s.m.reassignments.WithLabelValues(outcome(err)).Inc()
+	if errors.Is(err, domain.ErrNoCandidate) {
+		s.m.noCandidate.WithLabelValues("reassign").Inc()
+	}
+	return pr, replacedBy, err
+}
+
+type userService struct {
+	app.UserService
+	m *Metrics
+}
+
+func (s *userService) BulkDeactivateTeam(ctx context.Context, teamName string, userIDs []string) (domain.BulkDeactivateResult, error) {
+	res, err := s.UserService.BulkDeactivateTeam(ctx, teamName, userIDs)
+
+	s.m.bulkDeactivation.WithLabelValues(outcome(err)).Inc()
+	s.m.bulkUsers.Add(float64(res.DeactivatedUsers))
+	s.m.bulkAffectedPRs.Add(float64(res.AffectedPRs))
+	return res, err
+}
+
+// outcome метка результата: ok, доменная ошибка в виде кода или error для прочих.
+func outcome(err error) string {
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.Is(err, domain.ErrNoCandidate):
+		return "no_candidate"
+	case errors.Is(err, domain.ErrNotAssigned):
+		return "not_assigned"
+	case errors.Is(err, domain.ErrPRMerged):
+		return "pr_merged"
+	case errors.Is(err, domain.ErrPRClosed):
+		return "pr_closed"
+	case errors.Is(err, domain.ErrNotFound):
+		return "not_found"
+	case errors.Is(err, domain.ErrForbidden):
+		return "forbidden"
+	default:
+		return "error"
+	}
+}
@@ -4,13 +4,15 @@ package repository
 import (
 	"avi_internship_autumn/internal/domain"
 	"context"
+	"time"
 )
 
 // TeamRepository определяет операции над хранилищем команд.
 type TeamRepository interface {
-	Create(ctx context.Context, teamName string) error
+	Create(ctx context.Context, team domain.Team) error
 	Exists(ctx context.Context, teamName string) (bool, error)
 	Get(ctx context.Context, teamName string) (domain.Team, error)
+	SetChatWebhookURL(ctx context.Context, teamName, url string) error
 }
 
 // UserRepository определяет операции над хранилищем пользователей.
@@ -19,6 +21,9 @@ type UserRepository interface {
 	GetByID(ctx context.Context, id string) (domain.User, error)
 	ListByTeam(ctx context.Context, teamName string) ([]domain.User, error)
 	UpdateIsActive(ctx context.Context, id string, isActive bool) (domain.User, error)
+	UpdateChatHandle(ctx context.Context, id string, handle string) (domain.User, error)
+	UpdateEmailSettings(ctx context.Context, id, email string, optOut domain.EmailOptOut) (domain.User, error)
+	ListDigestRecipients(ctx context.Context) ([]domain.User, error)
 	BulkDeactivateInTeam(ctx context.Context, teamName string, userIDs []string) (int64, error)
 }
 
@@ -28,13 +33,70 @@ type PRRepository interface {
 	Create(ctx context.Context, pr domain.PullRequest) error
 	GetForUpdate(ctx context.Context, id string) (domain.PullRequest, error)
 	UpdateStatusMerged(ctx context.Context, id string) error
+	UpdateStatusClosed(ctx context.Context, id string) error
+	UpdateStatusReopened(ctx context.Context, id string) error
 	ListReviewerPRs(ctx context.Context, reviewerID string) ([]domain.PullRequest, error)
 
 	GetReviewers(ctx context.Context, prID string) ([]string, error)
 	AddReviewer(ctx context.Context, prID, reviewerID string) error
 	RemoveReviewer(ctx context.Context, prID, reviewerID string) error
+	SetVerdict(ctx context.Context, prID, reviewerID string, verdict domain.ReviewVerdict) error
 
-	GetAssignmentStatsByReviewer(ctx context.Context) ([]domain.AssignmentStats, error)
-	GetAssignmentStatsByPR(ctx context.Context) ([]domain.PullRequestAssignmentStats, error)
+	GetAssignmentStatsByReviewer(ctx context.Context, f domain.AssignmentStatsFilter) ([]domain.AssignmentStats, error)
+	GetAssignmentStatsByPR(ctx context.Context, f domain.AssignmentStatsFilter) ([]domain.PullRequestAssignmentStats, error)
 	ListOpenPRsByReviewers(ctx context.Context, reviewerIDs []string) ([]domain.PullRequest, error)
+	GetLatencyStats(ctx context.Context, f domain.LatencyFilter) ([]domain.LatencyStats, error)
+	ListReviewerLoads(ctx context.Context, teamName string) ([]domain.ReviewerLoad, error)
+	CountOpenByTeam(ctx context.Context) (map[string]int64, error)
+	ListReviewPairs(ctx context.Context, f domain.ReviewPairsFilter) ([]domain.ReviewPair, error)
+
+	StreamPullRequests(ctx context.Context, f domain.ExportFilter, fn func(domain.PullRequest) error) error
+	StreamAssignments(ctx context.Context, f domain.ExportFilter, fn func(domain.Assignment) error) error
+}
+
+// WebhookRepository определяет операции над подписками и доставками вебхуков.
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, s domain.WebhookSubscription) (domain.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context, teamName string) ([]domain.WebhookSubscription, error)
+	ListActiveSubscriptions(ctx context.Context, teamName string, eventType domain.EventType) ([]domain.WebhookSubscription, error)
+	SetSubscriptionActive(ctx context.Context, id int64, active bool) (domain.WebhookSubscription, error)
+	RotateSecret(ctx context.Context, id int64, secret string) (domain.WebhookSubscription, error)
+
+	EnqueueDelivery(ctx context.Context, subscriptionID int64, eventType domain.EventType, payload []byte) error
+	ListDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]domain.WebhookDelivery, error)
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]domain.PendingDelivery, error)
+	MarkDelivered(ctx context.Context, id int64, statusCode int) error
+	MarkFailed(ctx context.Context, id int64, attempts int, statusCode int, lastErr string, nextAttemptAt time.Time, dead bool) error
+	ResetDelivery(ctx context.Context, id int64) (domain.WebhookDelivery, error)
+}
+
+// IdentityRepository определяет операции над связками внешних логинов с пользователями.
+type IdentityRepository interface {
+	Upsert(ctx context.Context, id domain.Identity) error
+	ResolveUserID(ctx context.Context, provider domain.Provider, login string) (string, error)
+	ResolveUserIDByExternalID(ctx context.Context, provider domain.Provider, externalID string) (string, error)
+}
+
+// SchemaRepository проверяет доступность БД и версию схемы.
+type SchemaRepository interface {
+	Ping(ctx context.Context) error
+	SchemaVersion(ctx context.Context) (int, error)
+}
+
+// TokenRepository определяет операции над bearer-токенами API. Токены ищутся по хэшу.
+type TokenRepository interface {
+	Create(ctx context.Context, t domain.APIToken, hash string) (domain.APIToken, error)
+	GetByHash(ctx context.Context, hash string) (domain.APIToken, error)
+	List(ctx context.Context) ([]domain.APIToken, error)
+	Revoke(ctx context.Context, id int64) (domain.APIToken, error)
+}
+
+// IdempotencyRepository хранит ключи идемпотентности POST-запросов.
+type IdempotencyRepository interface {
+	// Reserve занимает ключ под новый запрос на ttl. Если ключ уже занят живой записью, возвращает её
+	// и reserved=false. Незавершённая запись старше lockTimeout считается брошенной и занимается заново.
+	Reserve(ctx context.Context, scope, key, requestHash string, ttl, lockTimeout time.Duration) (rec domain.IdempotencyRecord, reserved bool, err error)
+	Complete(ctx context.Context, scope, key string, resp domain.IdempotentResponse) error
+	Release(ctx context.Context, scope, key string) error
+	DeleteExpired(ctx context.Context) (int64, error)
 }
@@ -0,0 +1,129 @@
+package pg
+
+import (
+	"avi_internship_autumn/internal/domain"
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+// Выгрузки читают строки курсором database/sql и сразу отдают их в fn:
+// в памяти держится одна строка, а не весь результат.
+
+func exportFilterArgs(f domain.ExportFilter) []any {
+	var from, to sql.NullTime
+	if f.From != nil {
+		from = sql.NullTime{Time: *f.From, Valid: true}
+	}
+	if f.To != nil {
+		to = sql.NullTime{Time: *f.To, Valid: true}
+	}
+	return []any{from, to, f.TeamName, string(f.Status)}
+}
+
+// StreamPullRequests отдаёт PR (с ревьюверами) в порядке создания. Ошибка из fn прерывает чтение.
+func (r *prRepo) StreamPullRequests(ctx context.Context, f domain.ExportFilter, fn func(domain.PullRequest) error) error {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT p.pull_request_id,
+               p.pull_request_name,
+               p.author_id,
+               p.status,
+               p.created_at,
+               p.merged_at,
+               p.closed_at,
+               ARRAY(
+                   SELECT r.reviewer_id
+                   FROM pr_reviewers r
+                   WHERE r.pull_request_id = p.pull_request_id
+                   ORDER BY r.reviewer_id
+               ) AS reviewers
+        FROM pull_requests p
+        JOIN users u ON u.user_id = p.author_id
+        WHERE ($1::timestamptz IS NULL OR p.created_at >= $1)
+          AND ($2::timestamptz IS NULL OR p.created_at < $2)
+          AND ($3::text = '' OR u.team_name = $3)
+          AND ($4::text = '' OR p.status = $4)
+        ORDER BY p.created_at, p.pull_request_id
+    `, exportFilterArgs(f)...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var reviewers pq.StringArray
+		pr, err := scanPullRequest(withExtraDest(rows, &reviewers))
+		if err != nil {
+			return err
+		}
+		pr.AssignedReviewers = reviewers
+		if err := fn(pr); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// StreamAssignments отдаёт назначения в порядке времени назначения. Ошибка из fn прерывает чтение.
+func (r *prRepo) StreamAssignments(ctx context.Context, f domain.ExportFilter, fn func(domain.Assignment) error) error {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT p.pull_request_id,
+               p.pull_request_name,
+               p.author_id,
+               p.status,
+               r.reviewer_id,
+               u.team_name,
+               r.assigned_at,
+               r.verdict,
+               r.verdict_at
+        FROM pr_reviewers r
+        JOIN pull_requests p ON p.pull_request_id = r.pull_request_id
+        JOIN users u ON u.user_id = r.reviewer_id
+        WHERE ($1::timestamptz IS NULL OR r.assigned_at >= $1)
+          AND ($2::timestamptz IS NULL OR r.assigned_at < $2)
+          AND ($3::text = '' OR u.team_name = $3)
+          AND ($4::text = '' OR p.status = $4)
+        ORDER BY r.assigned_at, p.pull_request_id, r.reviewer_id
+    `, exportFilterArgs(f)...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var (
+			a         domain.Assignment
+			status    string
+			verdict   sql.NullString
+			verdictAt sql.NullTime
+		)
+		if err := rows.Scan(
+			&a.PullRequestID,
+			&a.PullRequestName,
+			&a.AuthorID,
+			&status,
+			&a.ReviewerID,
+			&a.ReviewerTeam,
+			&a.AssignedAt,
+			&verdict,
+			&verdictAt,
+		); err != nil {
+			return err
+		}
+		a.Status = domain.PRStatus(status)
+		a.Verdict = domain.ReviewVerdict(verdict.String)
+		if verdictAt.Valid {
+			t := verdictAt.Time
+			a.VerdictAt = &t
+		}
+		if err := fn(a); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,123 @@
+package pg
+
+import (
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type idempotencyRepo struct {
+	db *sql.DB
+}
+
+// NewIdempotencyRepository создаёт репозиторий ключей идемпотентности на базе PostgreSQL.
+func NewIdempotencyRepository(db *sql.DB) repository.IdempotencyRepository {
+	return &idempotencyRepo{db: db}
+}
+
+const idempotencyColumns = `scope, idem_key, request_hash, status_code, content_type, body, created_at, expires_at`
+
+func scanIdempotencyRecord(s prRowScanner) (domain.IdempotencyRecord, error) {
+	var rec domain.IdempotencyRecord
+	var statusCode sql.NullInt64
+	var contentType string
+	var body []byte
+
+	if err := s.Scan(&rec.Scope, &rec.Key, &rec.RequestHash, &statusCode, &contentType, &body,
+		&rec.CreatedAt, &rec.ExpiresAt); err != nil {
+		return domain.IdempotencyRecord{}, err
+	}
+
+	if statusCode.Valid {
+		rec.Response = &domain.IdempotentResponse{
+			StatusCode:  int(statusCode.Int64),
+			ContentType: contentType,
+			Body:        body,
+		}
+	}
+	return rec, nil
+}
+
+// Reserve вставляет ключ или перезаписывает истёкший/брошенный одним запросом, поэтому
+// два параллельных запроса с одним ключом не могут оба его занять.
+func (r *idempotencyRepo) Reserve(
+	ctx context.Context,
+	scope, key, requestHash string,
+	ttl, lockTimeout time.Duration,
+) (domain.IdempotencyRecord, bool, error) {
+	// запись могут удалить между INSERT и SELECT (Release, чистка) — тогда пробуем ещё раз
+	for range 2 {
+		row := r.db.QueryRowContext(ctx, `
+            INSERT INTO idempotency_keys (scope, idem_key, request_hash, expires_at)
+            VALUES ($1, $2, $3, now() + make_interval(secs => $4))
+            ON CONFLICT (scope, idem_key) DO UPDATE
+            SET request_hash = EXCLUDED.request_hash,
+                status_code  = NULL,
+                content_type = '',
+                body         = NULL,
+                created_at   = now(),
+                expires_at   = EXCLUDED.expires_at
+            WHERE idempotency_keys.expires_at <= now()
+               OR (idempotency_keys.status_code IS NULL
+                   AND idempotency_keys.created_at <= now() - make_interval(secs => $5))
+            RETURNING `+idempotencyColumns,
+			scope, key, requestHash, ttl.Seconds(), lockTimeout.Seconds())
+
+		rec, err := scanIdempotencyRecord(row)
+		if err == nil {
+			return rec, true, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return domain.IdempotencyRecord{}, false, err
+		}
+
+		row = r.db.QueryRowContext(ctx, `
+            SELECT `+idempotencyColumns+`
+            FROM idempotency_keys
+            WHERE scope = $1 AND idem_key = $2
+        `, scope, key)
+
+		rec, err = scanIdempotencyRecord(row)
+		if err == nil {
+			return rec, false, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return domain.IdempotencyRecord{}, false, err
+		}
+	}
+	return domain.IdempotencyRecord{}, false, domain.ErrIdempotencyInProgress
+}
+
+// Complete сохраняет ответ для повторов.
+func (r *idempotencyRepo) Complete(ctx context.Context, scope, key string, resp domain.IdempotentResponse) error {
+	_, err := r.db.ExecContext(ctx, `
+        UPDATE idempotency_keys
+        SET status_code = $3, content_type = $4, body = $5
+        WHERE scope = $1 AND idem_key = $2 AND status_code IS NULL
+    `, scope, key, resp.StatusCode, resp.ContentType, resp.Body)
+	return err
+}
+
+// Release освобождает ключ, ответ на который сохранять нельзя: повтор выполнится заново.
+func (r *idempotencyRepo) Release(ctx context.Context, scope, key string) error {
+	_, err := r.db.ExecContext(ctx, `
+        DELETE FROM idempotency_keys
+        WHERE scope = $1 AND idem_key = $2 AND status_code IS NULL
+    `, scope, key)
+	return err
+}
+
+// DeleteExpired удаляет истёкшие ключи и возвращает их количество.
+func (r *idempotencyRepo) DeleteExpired(ctx context.Context) (int64, error) {
+	res, err := r.db.ExecContext(ctx, `
+        DELETE FROM idempotency_keys
+        WHERE expires_at <= now()
+    `)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,88 @@
+package pg
+
+import (
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+type identityRepo struct {
+	db *sql.DB
+}
+
+// NewIdentityRepository создаёт репозиторий внешних логинов на базе PostgreSQL.
+func NewIdentityRepository(db *sql.DB) repository.IdentityRepository {
+	return &identityRepo{db: db}
+}
+
+// Upsert привязывает логин провайдера к пользователю (перепривязывает, если уже был).
+// Пустой ExternalID не затирает ранее сохранённый; занятый другим логином ExternalID
+// переходит к этому логину — на хостинге логин можно переименовать, а id остаётся.
+func (r *identityRepo) Upsert(ctx context.Context, id domain.Identity) error {
+	// снятие ExternalID со старого логина и привязка — одной транзакцией,
+	// иначе при сбое между ними id пропадёт у обоих логинов
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if id.ExternalID != "" {
+		_, err := tx.ExecContext(ctx, `
+            UPDATE user_identities
+            SET external_id = NULL
+            WHERE provider = $1 AND external_id = $2 AND login <> $3
+        `, string(id.Provider), id.ExternalID, id.Login)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+        INSERT INTO user_identities (provider, login, external_id, user_id)
+        VALUES ($1, $2, NULLIF($3, ''), $4)
+        ON CONFLICT (provider, login) DO UPDATE
+        SET user_id     = EXCLUDED.user_id,
+            external_id = COALESCE(EXCLUDED.external_id, user_identities.external_id)
+    `, string(id.Provider), id.Login, id.ExternalID, id.UserID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ResolveUserID возвращает user_id по логину провайдера или domain.ErrNotFound.
+func (r *identityRepo) ResolveUserID(ctx context.Context, provider domain.Provider, login string) (string, error) {
+	var userID string
+	err := r.db.QueryRowContext(ctx, `
+        SELECT user_id
+        FROM user_identities
+        WHERE provider = $1 AND login = $2
+    `, string(provider), login).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.ErrNotFound
+		}
+		return "", err
+	}
+	return userID, nil
+}
+
+// ResolveUserIDByExternalID возвращает user_id по id пользователя на хостинге или domain.ErrNotFound.
+func (r *identityRepo) ResolveUserIDByExternalID(ctx context.Context, provider domain.Provider, externalID string) (string, error) {
+	var userID string
+	err := r.db.QueryRowContext(ctx, `
+        SELECT user_id
+        FROM user_identities
+        WHERE provider = $1 AND external_id = $2
+    `, string(provider), externalID).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.ErrNotFound
+		}
+		return "", err
+	}
+	return userID, nil
+}
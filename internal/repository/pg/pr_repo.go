@@ -7,6 +7,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/lib/pq"
 )
@@ -24,6 +25,7 @@ func scanPullRequest(s prRowScanner) (domain.PullRequest, error) {
 	var statusStr string
 	var createdAt sql.NullTime
 	var mergedAt sql.NullTime
+	var closedAt sql.NullTime
 
 	if err := s.Scan(
 		&pr.ID,
@@ -32,6 +34,7 @@ func scanPullRequest(s prRowScanner) (domain.PullRequest, error) {
 		&statusStr,
 		&createdAt,
 		&mergedAt,
+		&closedAt,
 	); err != nil {
 		return domain.PullRequest{}, err
 	}
@@ -44,12 +47,16 @@ func scanPullRequest(s prRowScanner) (domain.PullRequest, error) {
 		t := mergedAt.Time
 		pr.MergedAt = &t
 	}
+	if closedAt.Valid {
+		t := closedAt.Time
+		pr.ClosedAt = &t
+	}
 
 	return pr, nil
 }
 
-// Вспомогательная функция для сканирования статистики вида (id, count)
-func scanStats[T any](rows *sql.Rows, mapper func(id string, count int64) T) ([]T, error) {
+// Вспомогательная функция для сканирования статистики вида (id, period, count)
+func scanStats[T any](rows *sql.Rows, mapper func(id string, period *time.Time, count int64) T) ([]T, error) {
 	defer func() {
 		_ = rows.Close()
 	}()
@@ -58,13 +65,19 @@ func scanStats[T any](rows *sql.Rows, mapper func(id string, count int64) T) ([]
 
 	for rows.Next() {
 		var id string
+		var period sql.NullTime
 		var cnt int64
 
-		if err := rows.Scan(&id, &cnt); err != nil {
+		if err := rows.Scan(&id, &period, &cnt); err != nil {
 			return nil, err
 		}
 
-		result = append(result, mapper(id, cnt))
+		var p *time.Time
+		if period.Valid {
+			t := period.Time.UTC()
+			p = &t
+		}
+		result = append(result, mapper(id, p, cnt))
 	}
 
 	if err := rows.Err(); err != nil {
@@ -112,7 +125,8 @@ func (r *prRepo) GetForUpdate(ctx context.Context, id string) (domain.PullReques
                author_id,
                status,
                created_at,
-               merged_at
+               merged_at,
+               closed_at
         FROM pull_requests
         WHERE pull_request_id = $1
     `, id)
@@ -128,13 +142,57 @@ func (r *prRepo) GetForUpdate(ctx context.Context, id string) (domain.PullReques
 	return pr, nil
 }
 
-// UpdateStatusMerged ставит PR в статус MERGED и проставляет merged_at (если ещё не стоял).
+// UpdateStatusMerged ставит открытый PR в статус MERGED и проставляет merged_at (если ещё не стоял).
+// Если PR нет или он уже не OPEN — domain.ErrNotFound (сервис проверяет статус заранее).
 func (r *prRepo) UpdateStatusMerged(ctx context.Context, id string) error {
 	res, err := r.db.ExecContext(ctx, `
         UPDATE pull_requests
         SET status   = 'MERGED',
             merged_at = COALESCE(merged_at, now())
         WHERE pull_request_id = $1
+          AND status = 'OPEN'
+    `, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err == nil && affected == 0 {
+		return domain.ErrNotFound
+	}
+	return err
+}
+
+// UpdateStatusClosed закрывает открытый PR без merge.
+// Если PR нет или он уже не OPEN — domain.ErrNotFound (сервис проверяет статус заранее).
+func (r *prRepo) UpdateStatusClosed(ctx context.Context, id string) error {
+	res, err := r.db.ExecContext(ctx, `
+        UPDATE pull_requests
+        SET status    = 'CLOSED',
+            closed_at = now()
+        WHERE pull_request_id = $1
+          AND status = 'OPEN'
+    `, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err == nil && affected == 0 {
+		return domain.ErrNotFound
+	}
+	return err
+}
+
+// UpdateStatusReopened возвращает закрытый PR в статус OPEN.
+// Если PR нет или он не CLOSED — domain.ErrNotFound.
+func (r *prRepo) UpdateStatusReopened(ctx context.Context, id string) error {
+	res, err := r.db.ExecContext(ctx, `
+        UPDATE pull_requests
+        SET status    = 'OPEN',
+            closed_at = NULL
+        WHERE pull_request_id = $1
+          AND status = 'CLOSED'
     `, id)
 	if err != nil {
 		return err
@@ -155,7 +213,8 @@ func (r *prRepo) ListReviewerPRs(ctx context.Context, reviewerID string) ([]doma
                p.author_id,
                p.status,
                p.created_at,
-               p.merged_at
+               p.merged_at,
+               p.closed_at
         FROM pull_requests p
         JOIN pr_reviewers r ON r.pull_request_id = p.pull_request_id
         WHERE r.reviewer_id = $1
@@ -174,30 +233,10 @@ func (r *prRepo) ListReviewerPRs(ctx context.Context, reviewerID string) ([]doma
 	var prs []domain.PullRequest
 
 	for rows.Next() {
-		var pr domain.PullRequest
-		var statusStr string
-		var createdAt sql.NullTime
-		var mergedAt sql.NullTime
-
-		if err := rows.Scan(
-			&pr.ID,
-			&pr.Name,
-			&pr.AuthorID,
-			&statusStr,
-			&createdAt,
-			&mergedAt,
-		); err != nil {
+		pr, err := scanPullRequest(rows)
+		if err != nil {
 			return nil, err
 		}
-
-		pr.Status = domain.PRStatus(statusStr)
-		if createdAt.Valid {
-			pr.CreatedAt = createdAt.Time
-		}
-		if mergedAt.Valid {
-			t := mergedAt.Time
-			pr.MergedAt = &t
-		}
 		// AssignedReviewers тут не подтягиваем — для /users/getReview это не нужно
 		prs = append(prs, pr)
 	}
@@ -243,12 +282,18 @@ func (r *prRepo) GetReviewers(ctx context.Context, prID string) ([]string, error
 	return reviewers, nil
 }
 
-// AddReviewer добавляет связку PR–reviewer.
+// AddReviewer добавляет связку PR–reviewer и записывает назначение в журнал.
 func (r *prRepo) AddReviewer(ctx context.Context, prID, reviewerID string) error {
 	_, err := r.db.ExecContext(ctx, `
-        INSERT INTO pr_reviewers (pull_request_id, reviewer_id)
-        VALUES ($1, $2)
-        ON CONFLICT DO NOTHING
+        WITH added AS (
+            INSERT INTO pr_reviewers (pull_request_id, reviewer_id)
+            VALUES ($1, $2)
+            ON CONFLICT DO NOTHING
+            RETURNING pull_request_id, reviewer_id, assigned_at
+        )
+        INSERT INTO review_assignment_history (pull_request_id, reviewer_id, assigned_at)
+        SELECT pull_request_id, reviewer_id, assigned_at
+        FROM added
     `, prID, reviewerID)
 	return err
 }
@@ -269,40 +314,100 @@ func (r *prRepo) RemoveReviewer(ctx context.Context, prID, reviewerID string) er
 	return nil
 }
 
-// GetAssignmentStatsByReviewer возвращает число назначений по каждому ревьюверу.
-func (r *prRepo) GetAssignmentStatsByReviewer(ctx context.Context) ([]domain.AssignmentStats, error) {
+// SetVerdict записывает вердикт ревьювера. Повторный вердикт перезаписывает прошлый,
+// но first_verdict_at остаётся от первого — по нему считается задержка ревью.
+// Если ревьювер не назначен на PR — domain.ErrNotAssigned.
+func (r *prRepo) SetVerdict(ctx context.Context, prID, reviewerID string, verdict domain.ReviewVerdict) error {
+	res, err := r.db.ExecContext(ctx, `
+        UPDATE pr_reviewers
+        SET verdict          = $3,
+            verdict_at       = now(),
+            first_verdict_at = COALESCE(first_verdict_at, now())
+        WHERE pull_request_id = $1 AND reviewer_id = $2
+    `, prID, reviewerID, string(verdict))
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrNotAssigned
+	}
+	return nil
+}
+
+// assignmentStatsArgs параметры общего WHERE статистики: $1 from, $2 to, $3 команда, $4 статус, $5 шаг.
+// NULL/пустая строка означают «без ограничения», date_trunc(NULL, ...) даёт NULL вместо периода.
+func assignmentStatsArgs(f domain.AssignmentStatsFilter) []any {
+	var from, to sql.NullTime
+	if f.From != nil {
+		from = sql.NullTime{Time: *f.From, Valid: true}
+	}
+	if f.To != nil {
+		to = sql.NullTime{Time: *f.To, Valid: true}
+	}
+	var step sql.NullString
+	if f.GroupBy != domain.GroupByNone {
+		step = sql.NullString{String: string(f.GroupBy), Valid: true}
+	}
+	return []any{from, to, f.TeamName, string(f.Status), step}
+}
+
+// GetAssignmentStatsByReviewer возвращает число назначений по каждому ревьюверу (и периоду, если задана группировка).
+func (r *prRepo) GetAssignmentStatsByReviewer(ctx context.Context, f domain.AssignmentStatsFilter) ([]domain.AssignmentStats, error) {
 	rows, err := r.db.QueryContext(ctx, `
-        SELECT reviewer_id, COUNT(*) AS cnt
-        FROM pr_reviewers
-        GROUP BY reviewer_id
-        ORDER BY cnt DESC, reviewer_id
-    `)
+        SELECT r.reviewer_id,
+               date_trunc($5::text, r.assigned_at, 'UTC') AS period,
+               COUNT(*) AS cnt
+        FROM pr_reviewers r
+        JOIN pull_requests p ON p.pull_request_id = r.pull_request_id
+        JOIN users u ON u.user_id = r.reviewer_id
+        WHERE ($1::timestamptz IS NULL OR r.assigned_at >= $1)
+          AND ($2::timestamptz IS NULL OR r.assigned_at < $2)
+          AND ($3::text = '' OR u.team_name = $3)
+          AND ($4::text = '' OR p.status = $4)
+        GROUP BY r.reviewer_id, period
+        ORDER BY period NULLS FIRST, cnt DESC, r.reviewer_id
+    `, assignmentStatsArgs(f)...)
 	if err != nil {
 		return nil, err
 	}
-	return scanStats(rows, func(id string, count int64) domain.AssignmentStats {
+	return scanStats(rows, func(id string, period *time.Time, count int64) domain.AssignmentStats {
 		return domain.AssignmentStats{
 			ReviewerID: id,
+			Period:     period,
 			Count:      count,
 		}
 	})
 }
 
-// GetAssignmentStatsByPR возвращает число назначений по каждому PR.
-func (r *prRepo) GetAssignmentStatsByPR(ctx context.Context) ([]domain.PullRequestAssignmentStats, error) {
+// GetAssignmentStatsByPR возвращает число назначений по каждому PR (и периоду, если задана группировка).
+func (r *prRepo) GetAssignmentStatsByPR(ctx context.Context, f domain.AssignmentStatsFilter) ([]domain.PullRequestAssignmentStats, error) {
 	rows, err := r.db.QueryContext(ctx, `
-        SELECT pull_request_id, COUNT(*) AS cnt
-        FROM pr_reviewers
-        GROUP BY pull_request_id
-        ORDER BY cnt DESC, pull_request_id
-    `)
+        SELECT r.pull_request_id,
+               date_trunc($5::text, r.assigned_at, 'UTC') AS period,
+               COUNT(*) AS cnt
+        FROM pr_reviewers r
+        JOIN pull_requests p ON p.pull_request_id = r.pull_request_id
+        JOIN users u ON u.user_id = r.reviewer_id
+        WHERE ($1::timestamptz IS NULL OR r.assigned_at >= $1)
+          AND ($2::timestamptz IS NULL OR r.assigned_at < $2)
+          AND ($3::text = '' OR u.team_name = $3)
+          AND ($4::text = '' OR p.status = $4)
+        GROUP BY r.pull_request_id, period
+        ORDER BY period NULLS FIRST, cnt DESC, r.pull_request_id
+    `, assignmentStatsArgs(f)...)
 	if err != nil {
 		return nil, err
 	}
 
-	return scanStats(rows, func(id string, count int64) domain.PullRequestAssignmentStats {
+	return scanStats(rows, func(id string, period *time.Time, count int64) domain.PullRequestAssignmentStats {
 		return domain.PullRequestAssignmentStats{
 			PullRequestID: id,
+			Period:        period,
 			Count:         count,
 		}
 	})
@@ -321,7 +426,8 @@ func (r *prRepo) ListOpenPRsByReviewers(ctx context.Context, reviewerIDs []strin
                p.author_id,
                p.status,
                p.created_at,
-               p.merged_at
+               p.merged_at,
+               p.closed_at
         FROM pull_requests p
         JOIN pr_reviewers r ON r.pull_request_id = p.pull_request_id
         WHERE p.status = 'OPEN'
@@ -352,3 +458,207 @@ func (r *prRepo) ListOpenPRsByReviewers(ctx context.Context, reviewerIDs []strin
 
 	return prs, nil
 }
+
+// GetLatencyStats считает p50/p90/p99 времени до merge и до первого вердикта
+// по всем PR окна и в разрезах команда/автор/ревьювер. Всё агрегируется в Postgres.
+func (r *prRepo) GetLatencyStats(ctx context.Context, f domain.LatencyFilter) ([]domain.LatencyStats, error) {
+	var from, to sql.NullTime
+	if f.From != nil {
+		from = sql.NullTime{Time: *f.From, Valid: true}
+	}
+	if f.To != nil {
+		to = sql.NullTime{Time: *f.To, Valid: true}
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+        WITH prs AS (
+            SELECT p.pull_request_id,
+                   p.author_id,
+                   u.team_name,
+                   EXTRACT(EPOCH FROM p.merged_at - p.created_at)::float8 AS to_merge,
+                   EXTRACT(EPOCH FROM v.first_verdict_at - p.created_at)::float8 AS to_verdict
+            FROM pull_requests p
+            JOIN users u ON u.user_id = p.author_id
+            LEFT JOIN LATERAL (
+                SELECT MIN(r.first_verdict_at) AS first_verdict_at
+                FROM pr_reviewers r
+                WHERE r.pull_request_id = p.pull_request_id
+            ) v ON TRUE
+            WHERE ($1::timestamptz IS NULL OR p.created_at >= $1)
+              AND ($2::timestamptz IS NULL OR p.created_at < $2)
+              AND ($3::text = '' OR u.team_name = $3)
+        ),
+        samples AS (
+            SELECT 'overall' AS dim, '' AS key, to_merge, to_verdict FROM prs
+            UNION ALL
+            SELECT 'team', team_name, to_merge, to_verdict FROM prs
+            UNION ALL
+            SELECT 'author', author_id, to_merge, to_verdict FROM prs
+            UNION ALL
+            SELECT 'reviewer', r.reviewer_id, prs.to_merge,
+                   EXTRACT(EPOCH FROM r.first_verdict_at - r.assigned_at)::float8
+            FROM prs
+            JOIN pr_reviewers r ON r.pull_request_id = prs.pull_request_id
+        )
+        SELECT dim, key,
+               COUNT(to_merge),
+               percentile_cont(ARRAY[0.5, 0.9, 0.99]) WITHIN GROUP (ORDER BY to_merge),
+               COUNT(to_verdict),
+               percentile_cont(ARRAY[0.5, 0.9, 0.99]) WITHIN GROUP (ORDER BY to_verdict)
+        FROM samples
+        GROUP BY dim, key
+        ORDER BY dim, key
+    `, from, to, f.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	result := make([]domain.LatencyStats, 0)
+	for rows.Next() {
+		var (
+			s                    domain.LatencyStats
+			dim                  string
+			mergeCnt, verdictCnt int64
+			mergeP, verdictP     pq.Float64Array
+		)
+		if err := rows.Scan(&dim, &s.Key, &mergeCnt, &mergeP, &verdictCnt, &verdictP); err != nil {
+			return nil, err
+		}
+		s.Dimension = domain.LatencyDimension(dim)
+		s.TimeToMerge = toPercentiles(mergeCnt, mergeP)
+		s.TimeToFirstVerdict = toPercentiles(verdictCnt, verdictP)
+		result = append(result, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListReviewerLoads возвращает нагрузку каждого участника команды (или всех команд при пустом teamName),
+// включая тех, у кого назначений нет. Открытые считаются по текущим назначениям,
+// все — по журналу, где остаются и снятые reassign'ом. Отсортировано по команде и user_id.
+func (r *prRepo) ListReviewerLoads(ctx context.Context, teamName string) ([]domain.ReviewerLoad, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT u.user_id,
+               u.team_name,
+               u.is_active,
+               (SELECT COUNT(*)
+                FROM pr_reviewers r
+                JOIN pull_requests p ON p.pull_request_id = r.pull_request_id
+                WHERE r.reviewer_id = u.user_id AND p.status = 'OPEN') AS open_cnt,
+               (SELECT COUNT(*)
+                FROM review_assignment_history h
+                WHERE h.reviewer_id = u.user_id) AS total_cnt
+        FROM users u
+        WHERE $1::text = '' OR u.team_name = $1
+        ORDER BY u.team_name, u.user_id
+    `, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	loads := make([]domain.ReviewerLoad, 0)
+	for rows.Next() {
+		var l domain.ReviewerLoad
+		if err := rows.Scan(&l.UserID, &l.TeamName, &l.IsActive, &l.Open, &l.Total); err != nil {
+			return nil, err
+		}
+		loads = append(loads, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return loads, nil
+}
+
+// CountOpenByTeam возвращает число открытых PR по командам авторов.
+func (r *prRepo) CountOpenByTeam(ctx context.Context) (map[string]int64, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT u.team_name, COUNT(*)
+        FROM pull_requests p
+        JOIN users u ON u.user_id = p.author_id
+        WHERE p.status = 'OPEN'
+        GROUP BY u.team_name
+    `)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	counts := make(map[string]int64)
+	for rows.Next() {
+		var team string
+		var n int64
+		if err := rows.Scan(&team, &n); err != nil {
+			return nil, err
+		}
+		counts[team] = n
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// ListReviewPairs возвращает пары автор–ревьювер с числом назначений, самые частые первыми.
+func (r *prRepo) ListReviewPairs(ctx context.Context, f domain.ReviewPairsFilter) ([]domain.ReviewPair, error) {
+	var from, to sql.NullTime
+	if f.From != nil {
+		from = sql.NullTime{Time: *f.From, Valid: true}
+	}
+	if f.To != nil {
+		to = sql.NullTime{Time: *f.To, Valid: true}
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT p.author_id, r.reviewer_id, COUNT(*) AS cnt
+        FROM pr_reviewers r
+        JOIN pull_requests p ON p.pull_request_id = r.pull_request_id
+        JOIN users u ON u.user_id = p.author_id
+        WHERE ($1::timestamptz IS NULL OR r.assigned_at >= $1)
+          AND ($2::timestamptz IS NULL OR r.assigned_at < $2)
+          AND ($3::text = '' OR u.team_name = $3)
+        GROUP BY p.author_id, r.reviewer_id
+        ORDER BY cnt DESC, p.author_id, r.reviewer_id
+    `, from, to, f.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	pairs := make([]domain.ReviewPair, 0)
+	for rows.Next() {
+		var p domain.ReviewPair
+		if err := rows.Scan(&p.AuthorID, &p.ReviewerID, &p.Count); err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return pairs, nil
+}
+
+// toPercentiles переводит массив [p50, p90, p99] в секундах; на пустой выборке Postgres отдаёт NULL.
+func toPercentiles(count int64, secs pq.Float64Array) domain.Percentiles {
+	p := domain.Percentiles{Count: count}
+	if len(secs) != 3 {
+		return p
+	}
+	p.P50 = time.Duration(secs[0] * float64(time.Second))
+	p.P90 = time.Duration(secs[1] * float64(time.Second))
+	p.P99 = time.Duration(secs[2] * float64(time.Second))
+	return p
+}
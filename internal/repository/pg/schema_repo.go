@@ -0,0 +1,31 @@
+package pg
+
+import (
+	"avi_internship_autumn/internal/repository"
+	"context"
+	"database/sql"
+)
+
+type schemaRepo struct {
+	db *sql.DB
+}
+
+// NewSchemaRepository возвращает postgres-реализацию SchemaRepository.
+func NewSchemaRepository(db *sql.DB) repository.SchemaRepository {
+	return &schemaRepo{db: db}
+}
+
+// Ping проверяет, что до БД есть живое соединение.
+func (r *schemaRepo) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+// SchemaVersion номер последней применённой миграции из schema_migrations.
+func (r *schemaRepo) SchemaVersion(ctx context.Context) (int, error) {
+	var version int
+	err := r.db.QueryRowContext(ctx, `
+        SELECT COALESCE(MAX(version), 0)
+        FROM schema_migrations
+    `).Scan(&version)
+	return version, err
+}
@@ -18,11 +18,30 @@ func NewTeamRepository(db *sql.DB) repository.TeamRepository {
 }
 
 // Create вставляет новую команду в таблицу teams.
-func (r *teamRepo) Create(ctx context.Context, teamName string) error {
+func (r *teamRepo) Create(ctx context.Context, team domain.Team) error {
 	_, err := r.db.ExecContext(ctx, `
-        INSERT INTO teams (team_name)
-        VALUES ($1)
-    `, teamName)
+        INSERT INTO teams (team_name, chat_webhook_url)
+        VALUES ($1, NULLIF($2, ''))
+    `, team.Name, team.ChatWebhookURL)
+	return err
+}
+
+// SetChatWebhookURL меняет адрес incoming-webhook чата команды (пустая строка — отключить).
+// Если команды нет — domain.ErrNotFound.
+func (r *teamRepo) SetChatWebhookURL(ctx context.Context, teamName, url string) error {
+	res, err := r.db.ExecContext(ctx, `
+        UPDATE teams
+        SET chat_webhook_url = NULLIF($2, '')
+        WHERE team_name = $1
+    `, teamName, url)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err == nil && affected == 0 {
+		return domain.ErrNotFound
+	}
 	return err
 }
 
@@ -45,11 +64,12 @@ func (r *teamRepo) Exists(ctx context.Context, teamName string) (bool, error) {
 func (r *teamRepo) Get(ctx context.Context, teamName string) (domain.Team, error) {
 	// Сначала убеждаемся, что команда существует
 	var name string
+	var chatWebhookURL sql.NullString
 	err := r.db.QueryRowContext(ctx, `
-        SELECT team_name
+        SELECT team_name, chat_webhook_url
         FROM teams
         WHERE team_name = $1
-    `, teamName).Scan(&name)
+    `, teamName).Scan(&name, &chatWebhookURL)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.Team{}, domain.ErrNotFound
@@ -59,7 +79,8 @@ func (r *teamRepo) Get(ctx context.Context, teamName string) (domain.Team, error
 
 	// Забираем всех юзеров этой команды
 	rows, err := r.db.QueryContext(ctx, `
-        SELECT user_id, username, is_active
+        SELECT user_id, username, team_name, is_active, chat_handle,
+               email, email_opt_out_assignments, email_opt_out_digest
         FROM users
         WHERE team_name = $1
         ORDER BY user_id
@@ -76,11 +97,10 @@ func (r *teamRepo) Get(ctx context.Context, teamName string) (domain.Team, error
 
 	members := make([]domain.User, 0)
 	for rows.Next() {
-		var u domain.User
-		if err := rows.Scan(&u.ID, &u.Username, &u.IsActive); err != nil {
+		u, err := scanUser(rows)
+		if err != nil {
 			return domain.Team{}, err
 		}
-		u.TeamName = teamName
 		members = append(members, u)
 	}
 	if err := rows.Err(); err != nil {
@@ -88,7 +108,8 @@ func (r *teamRepo) Get(ctx context.Context, teamName string) (domain.Team, error
 	}
 
 	return domain.Team{
-		Name:    teamName,
-		Members: members,
+		Name:           teamName,
+		Members:        members,
+		ChatWebhookURL: chatWebhookURL.String,
 	}, nil
 }
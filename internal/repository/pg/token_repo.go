@@ -0,0 +1,119 @@
+package pg
+
+import (
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+)
+
+type tokenRepo struct {
+	db *sql.DB
+}
+
+// NewTokenRepository создаёт репозиторий токенов API на базе PostgreSQL.
+func NewTokenRepository(db *sql.DB) repository.TokenRepository {
+	return &tokenRepo{db: db}
+}
+
+const tokenColumns = `token_id, scope, user_id, name, created_at, revoked_at`
+
+func scanToken(s prRowScanner) (domain.APIToken, error) {
+	var t domain.APIToken
+	var scope string
+	var userID sql.NullString
+	var revokedAt sql.NullTime
+
+	if err := s.Scan(&t.ID, &scope, &userID, &t.Name, &t.CreatedAt, &revokedAt); err != nil {
+		return domain.APIToken{}, err
+	}
+
+	t.Scope = domain.Scope(scope)
+	t.UserID = userID.String
+	if revokedAt.Valid {
+		t.RevokedAt = &revokedAt.Time
+	}
+	return t, nil
+}
+
+// Create сохраняет токен по его хэшу.
+func (r *tokenRepo) Create(ctx context.Context, t domain.APIToken, hash string) (domain.APIToken, error) {
+	var userID sql.NullString
+	if t.UserID != "" {
+		userID = sql.NullString{String: t.UserID, Valid: true}
+	}
+
+	row := r.db.QueryRowContext(ctx, `
+        INSERT INTO api_tokens (token_hash, scope, user_id, name)
+        VALUES ($1, $2, $3, $4)
+        RETURNING `+tokenColumns,
+		hash, string(t.Scope), userID, t.Name)
+
+	return scanToken(row)
+}
+
+// GetByHash находит токен по хэшу, в том числе отозванный; неизвестный — domain.ErrNotFound.
+func (r *tokenRepo) GetByHash(ctx context.Context, hash string) (domain.APIToken, error) {
+	row := r.db.QueryRowContext(ctx, `
+        SELECT `+tokenColumns+`
+        FROM api_tokens
+        WHERE token_hash = $1
+    `, hash)
+
+	t, err := scanToken(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.APIToken{}, domain.ErrNotFound
+		}
+		return domain.APIToken{}, err
+	}
+	return t, nil
+}
+
+// List возвращает все токены, новые первыми.
+func (r *tokenRepo) List(ctx context.Context) ([]domain.APIToken, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT `+tokenColumns+`
+        FROM api_tokens
+        ORDER BY token_id DESC
+    `)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	tokens := make([]domain.APIToken, 0)
+	for rows.Next() {
+		t, err := scanToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// Revoke отзывает токен. Повторный отзыв не сдвигает revoked_at. Нет токена — domain.ErrNotFound.
+func (r *tokenRepo) Revoke(ctx context.Context, id int64) (domain.APIToken, error) {
+	row := r.db.QueryRowContext(ctx, `
+        UPDATE api_tokens
+        SET revoked_at = COALESCE(revoked_at, now())
+        WHERE token_id = $1
+        RETURNING `+tokenColumns,
+		id)
+
+	t, err := scanToken(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.APIToken{}, domain.ErrNotFound
+		}
+		return domain.APIToken{}, err
+	}
+	return t, nil
+}
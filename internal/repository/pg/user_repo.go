@@ -19,18 +19,44 @@ func NewUserRepository(db *sql.DB) repository.UserRepository {
 	return &userRepo{db: db}
 }
 
+// scanUser сканирует колонки user_id, username, team_name, is_active, chat_handle,
+// email, email_opt_out_assignments, email_opt_out_digest.
+func scanUser(s prRowScanner) (domain.User, error) {
+	var u domain.User
+	var chatHandle, email sql.NullString
+
+	if err := s.Scan(
+		&u.ID,
+		&u.Username,
+		&u.TeamName,
+		&u.IsActive,
+		&chatHandle,
+		&email,
+		&u.EmailOptOut.Assignments,
+		&u.EmailOptOut.Digest,
+	); err != nil {
+		return domain.User{}, err
+	}
+	u.ChatHandle = chatHandle.String
+	u.Email = email.String
+	return u, nil
+}
+
 // Upsert создаёт или обновляет пользователя.
-// Если user_id уже есть — обновляем username, team_name и is_active.
+// Если user_id уже есть — обновляем username, team_name и is_active,
+// chat_handle и email — только если переданы (пустые не затирают сохранённые).
 func (r *userRepo) Upsert(ctx context.Context, u domain.User) error {
 	_, err := r.db.ExecContext(ctx, `
-        INSERT INTO users (user_id, username, team_name, is_active)
-        VALUES ($1, $2, $3, $4)
+        INSERT INTO users (user_id, username, team_name, is_active, chat_handle, email)
+        VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''))
         ON CONFLICT (user_id) DO UPDATE
         SET username = EXCLUDED.username,
             team_name = EXCLUDED.team_name,
             is_active = EXCLUDED.is_active,
+            chat_handle = COALESCE(EXCLUDED.chat_handle, users.chat_handle),
+            email = COALESCE(EXCLUDED.email, users.email),
             updated_at = now()
-    `, u.ID, u.Username, u.TeamName, u.IsActive)
+    `, u.ID, u.Username, u.TeamName, u.IsActive, u.ChatHandle, u.Email)
 	return err
 }
 
@@ -61,12 +87,12 @@ func (r *userRepo) BulkDeactivateInTeam(ctx context.Context, teamName string, us
 
 // GetByID возвращает пользователя по id или domain.ErrNotFound.
 func (r *userRepo) GetByID(ctx context.Context, id string) (domain.User, error) {
-	var u domain.User
-	err := r.db.QueryRowContext(ctx, `
-        SELECT user_id, username, team_name, is_active
+	u, err := scanUser(r.db.QueryRowContext(ctx, `
+        SELECT user_id, username, team_name, is_active, chat_handle,
+               email, email_opt_out_assignments, email_opt_out_digest
         FROM users
         WHERE user_id = $1
-    `, id).Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive)
+    `, id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.User{}, domain.ErrNotFound
@@ -79,7 +105,8 @@ func (r *userRepo) GetByID(ctx context.Context, id string) (domain.User, error)
 // ListByTeam возвращает всех пользователей команды.
 func (r *userRepo) ListByTeam(ctx context.Context, teamName string) ([]domain.User, error) {
 	rows, err := r.db.QueryContext(ctx, `
-        SELECT user_id, username, team_name, is_active
+        SELECT user_id, username, team_name, is_active, chat_handle,
+               email, email_opt_out_assignments, email_opt_out_digest
         FROM users
         WHERE team_name = $1
         ORDER BY user_id
@@ -96,8 +123,8 @@ func (r *userRepo) ListByTeam(ctx context.Context, teamName string) ([]domain.Us
 
 	users := make([]domain.User, 0)
 	for rows.Next() {
-		var u domain.User
-		if err := rows.Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive); err != nil {
+		u, err := scanUser(rows)
+		if err != nil {
 			return nil, err
 		}
 		users = append(users, u)
@@ -112,14 +139,56 @@ func (r *userRepo) ListByTeam(ctx context.Context, teamName string) ([]domain.Us
 // UpdateIsActive обновляет флаг активности и возвращает обновлённого пользователя.
 // Если user_id нет — domain.ErrNotFound.
 func (r *userRepo) UpdateIsActive(ctx context.Context, id string, isActive bool) (domain.User, error) {
-	var u domain.User
-	err := r.db.QueryRowContext(ctx, `
+	u, err := scanUser(r.db.QueryRowContext(ctx, `
         UPDATE users
         SET is_active = $2,
             updated_at = now()
         WHERE user_id = $1
-        RETURNING user_id, username, team_name, is_active
-    `, id, isActive).Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive)
+        RETURNING user_id, username, team_name, is_active, chat_handle,
+                  email, email_opt_out_assignments, email_opt_out_digest
+    `, id, isActive))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.User{}, domain.ErrNotFound
+		}
+		return domain.User{}, err
+	}
+	return u, nil
+}
+
+// UpdateChatHandle меняет ник пользователя в чате (пустая строка — убрать).
+// Если user_id нет — domain.ErrNotFound.
+func (r *userRepo) UpdateChatHandle(ctx context.Context, id string, handle string) (domain.User, error) {
+	u, err := scanUser(r.db.QueryRowContext(ctx, `
+        UPDATE users
+        SET chat_handle = NULLIF($2, ''),
+            updated_at = now()
+        WHERE user_id = $1
+        RETURNING user_id, username, team_name, is_active, chat_handle,
+                  email, email_opt_out_assignments, email_opt_out_digest
+    `, id, handle))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.User{}, domain.ErrNotFound
+		}
+		return domain.User{}, err
+	}
+	return u, nil
+}
+
+// UpdateEmailSettings меняет email пользователя и его отписки от писем.
+// Если user_id нет — domain.ErrNotFound.
+func (r *userRepo) UpdateEmailSettings(ctx context.Context, id, email string, optOut domain.EmailOptOut) (domain.User, error) {
+	u, err := scanUser(r.db.QueryRowContext(ctx, `
+        UPDATE users
+        SET email = NULLIF($2, ''),
+            email_opt_out_assignments = $3,
+            email_opt_out_digest = $4,
+            updated_at = now()
+        WHERE user_id = $1
+        RETURNING user_id, username, team_name, is_active, chat_handle,
+                  email, email_opt_out_assignments, email_opt_out_digest
+    `, id, email, optOut.Assignments, optOut.Digest))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.User{}, domain.ErrNotFound
@@ -128,3 +197,35 @@ func (r *userRepo) UpdateIsActive(ctx context.Context, id string, isActive bool)
 	}
 	return u, nil
 }
+
+// ListDigestRecipients возвращает активных пользователей с email, не отписавшихся от дайджеста.
+func (r *userRepo) ListDigestRecipients(ctx context.Context) ([]domain.User, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT user_id, username, team_name, is_active, chat_handle,
+               email, email_opt_out_assignments, email_opt_out_digest
+        FROM users
+        WHERE is_active
+          AND email IS NOT NULL
+          AND NOT email_opt_out_digest
+        ORDER BY user_id
+    `)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	users := make([]domain.User, 0)
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
@@ -0,0 +1,364 @@
+package pg
+
+import (
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type webhookRepo struct {
+	db *sql.DB
+}
+
+// NewWebhookRepository создаёт репозиторий вебхуков на базе PostgreSQL.
+func NewWebhookRepository(db *sql.DB) repository.WebhookRepository {
+	return &webhookRepo{db: db}
+}
+
+func scanSubscription(s prRowScanner) (domain.WebhookSubscription, error) {
+	var sub domain.WebhookSubscription
+	var eventTypes []string
+
+	if err := s.Scan(
+		&sub.ID,
+		&sub.TeamName,
+		&sub.URL,
+		&sub.Secret,
+		pq.Array(&eventTypes),
+		&sub.IsActive,
+		&sub.CreatedAt,
+	); err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+
+	sub.EventTypes = make([]domain.EventType, 0, len(eventTypes))
+	for _, et := range eventTypes {
+		sub.EventTypes = append(sub.EventTypes, domain.EventType(et))
+	}
+	return sub, nil
+}
+
+func scanDelivery(s prRowScanner) (domain.WebhookDelivery, error) {
+	var d domain.WebhookDelivery
+	var eventType, status string
+	var statusCode sql.NullInt64
+	var lastErr sql.NullString
+	var deliveredAt sql.NullTime
+
+	if err := s.Scan(
+		&d.ID,
+		&d.SubscriptionID,
+		&eventType,
+		&d.Payload,
+		&status,
+		&d.Attempts,
+		&d.NextAttemptAt,
+		&statusCode,
+		&lastErr,
+		&d.CreatedAt,
+		&deliveredAt,
+	); err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	d.EventType = domain.EventType(eventType)
+	d.Status = domain.DeliveryStatus(status)
+	d.LastStatusCode = int(statusCode.Int64)
+	d.LastError = lastErr.String
+	if deliveredAt.Valid {
+		t := deliveredAt.Time
+		d.DeliveredAt = &t
+	}
+	return d, nil
+}
+
+// extraDestScanner дописывает к Scan дополнительные колонки после стандартного набора.
+type extraDestScanner struct {
+	s     prRowScanner
+	extra []any
+}
+
+func (e extraDestScanner) Scan(dest ...any) error {
+	return e.s.Scan(append(dest, e.extra...)...)
+}
+
+func withExtraDest(s prRowScanner, extra ...any) prRowScanner {
+	return extraDestScanner{s: s, extra: extra}
+}
+
+func eventTypesToStrings(types []domain.EventType) []string {
+	out := make([]string, 0, len(types))
+	for _, t := range types {
+		out = append(out, string(t))
+	}
+	return out
+}
+
+// CreateSubscription сохраняет подписку и возвращает её с присвоенным id.
+func (r *webhookRepo) CreateSubscription(ctx context.Context, s domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	row := r.db.QueryRowContext(ctx, `
+        INSERT INTO webhook_subscriptions (team_name, url, secret, event_types)
+        VALUES ($1, $2, $3, $4)
+        RETURNING subscription_id, team_name, url, secret, event_types, is_active, created_at
+    `, s.TeamName, s.URL, s.Secret, pq.Array(eventTypesToStrings(s.EventTypes)))
+
+	return scanSubscription(row)
+}
+
+// ListSubscriptions возвращает все подписки команды.
+func (r *webhookRepo) ListSubscriptions(ctx context.Context, teamName string) ([]domain.WebhookSubscription, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT subscription_id, team_name, url, secret, event_types, is_active, created_at
+        FROM webhook_subscriptions
+        WHERE team_name = $1
+        ORDER BY subscription_id
+    `, teamName)
+	if err != nil {
+		return nil, err
+	}
+	return collectSubscriptions(rows)
+}
+
+// ListActiveSubscriptions возвращает активные подписки команды на конкретный тип события.
+func (r *webhookRepo) ListActiveSubscriptions(ctx context.Context, teamName string, eventType domain.EventType) ([]domain.WebhookSubscription, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT subscription_id, team_name, url, secret, event_types, is_active, created_at
+        FROM webhook_subscriptions
+        WHERE team_name = $1
+          AND is_active
+          AND $2 = ANY(event_types)
+        ORDER BY subscription_id
+    `, teamName, string(eventType))
+	if err != nil {
+		return nil, err
+	}
+	return collectSubscriptions(rows)
+}
+
+// SetSubscriptionActive включает или выключает подписку.
+// При выключении её доставки в PENDING сразу переводятся в DEAD тем же запросом,
+// чтобы диспетчер не слал их по адресу, который больше не ждёт событий.
+// Если подписки нет — domain.ErrNotFound.
+func (r *webhookRepo) SetSubscriptionActive(ctx context.Context, id int64, active bool) (domain.WebhookSubscription, error) {
+	row := r.db.QueryRowContext(ctx, `
+        WITH sub AS (
+            UPDATE webhook_subscriptions
+            SET is_active = $2
+            WHERE subscription_id = $1
+            RETURNING subscription_id, team_name, url, secret, event_types, is_active, created_at
+        ), dead AS (
+            UPDATE webhook_deliveries d
+            SET status     = 'DEAD',
+                last_error = 'subscription deactivated'
+            FROM sub
+            WHERE NOT $2
+              AND d.subscription_id = sub.subscription_id
+              AND d.status = 'PENDING'
+        )
+        SELECT subscription_id, team_name, url, secret, event_types, is_active, created_at
+        FROM sub
+    `, id, active)
+
+	return subscriptionOrNotFound(scanSubscription(row))
+}
+
+// RotateSecret заменяет секрет подписи; доставки, ещё не отправленные, подпишутся уже новым.
+// Если подписки нет — domain.ErrNotFound.
+func (r *webhookRepo) RotateSecret(ctx context.Context, id int64, secret string) (domain.WebhookSubscription, error) {
+	row := r.db.QueryRowContext(ctx, `
+        UPDATE webhook_subscriptions
+        SET secret = $2
+        WHERE subscription_id = $1
+        RETURNING subscription_id, team_name, url, secret, event_types, is_active, created_at
+    `, id, secret)
+
+	return subscriptionOrNotFound(scanSubscription(row))
+}
+
+func subscriptionOrNotFound(sub domain.WebhookSubscription, err error) (domain.WebhookSubscription, error) {
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.WebhookSubscription{}, domain.ErrNotFound
+		}
+		return domain.WebhookSubscription{}, err
+	}
+	return sub, nil
+}
+
+func collectSubscriptions(rows *sql.Rows) ([]domain.WebhookSubscription, error) {
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	subs := make([]domain.WebhookSubscription, 0)
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+// EnqueueDelivery ставит доставку в очередь со статусом PENDING.
+func (r *webhookRepo) EnqueueDelivery(ctx context.Context, subscriptionID int64, eventType domain.EventType, payload []byte) error {
+	_, err := r.db.ExecContext(ctx, `
+        INSERT INTO webhook_deliveries (subscription_id, event_type, payload, status)
+        VALUES ($1, $2, $3, 'PENDING')
+    `, subscriptionID, string(eventType), payload)
+	return err
+}
+
+// ListDeliveries возвращает последние доставки подписки, новые сверху.
+func (r *webhookRepo) ListDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]domain.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        SELECT delivery_id, subscription_id, event_type, payload, status, attempts,
+               next_attempt_at, last_status_code, last_error, created_at, delivered_at
+        FROM webhook_deliveries
+        WHERE subscription_id = $1
+        ORDER BY created_at DESC, delivery_id DESC
+        LIMIT $2
+    `, subscriptionID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	deliveries := make([]domain.WebhookDelivery, 0)
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// ClaimDueDeliveries забирает в работу доставки, у которых подошло время попытки.
+// Забранным доставкам сдвигается next_attempt_at на lease, поэтому
+// параллельный диспетчер (или упавший посреди отправки) не отправит их повторно раньше срока.
+func (r *webhookRepo) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]domain.PendingDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, `
+        WITH due AS (
+            SELECT delivery_id
+            FROM webhook_deliveries
+            WHERE status = 'PENDING'
+              AND next_attempt_at <= now()
+            ORDER BY next_attempt_at
+            LIMIT $1
+            FOR UPDATE SKIP LOCKED
+        )
+        UPDATE webhook_deliveries d
+        SET next_attempt_at = now() + make_interval(secs => $2)
+        FROM due, webhook_subscriptions s
+        WHERE d.delivery_id = due.delivery_id
+          AND s.subscription_id = d.subscription_id
+          AND s.is_active
+        RETURNING d.delivery_id, d.subscription_id, d.event_type, d.payload, d.status, d.attempts,
+                  d.next_attempt_at, d.last_status_code, d.last_error, d.created_at, d.delivered_at,
+                  s.url, s.secret
+    `, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var claimed []domain.PendingDelivery
+	for rows.Next() {
+		var p domain.PendingDelivery
+		d, err := scanDelivery(withExtraDest(rows, &p.URL, &p.Secret))
+		if err != nil {
+			return nil, err
+		}
+		p.Delivery = d
+		claimed = append(claimed, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+// MarkDelivered отмечает доставку успешной.
+func (r *webhookRepo) MarkDelivered(ctx context.Context, id int64, statusCode int) error {
+	_, err := r.db.ExecContext(ctx, `
+        UPDATE webhook_deliveries
+        SET status           = 'DELIVERED',
+            attempts         = attempts + 1,
+            last_status_code = $2,
+            last_error       = NULL,
+            delivered_at     = now()
+        WHERE delivery_id = $1
+    `, id, statusCode)
+	return err
+}
+
+// MarkFailed фиксирует неудачную попытку: либо планирует следующую, либо переводит в DEAD.
+func (r *webhookRepo) MarkFailed(
+	ctx context.Context,
+	id int64,
+	attempts int,
+	statusCode int,
+	lastErr string,
+	nextAttemptAt time.Time,
+	dead bool,
+) error {
+	status := domain.DeliveryPending
+	if dead {
+		status = domain.DeliveryDead
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+        UPDATE webhook_deliveries
+        SET status           = $2,
+            attempts         = $3,
+            last_status_code = NULLIF($4, 0),
+            last_error       = $5,
+            next_attempt_at  = $6
+        WHERE delivery_id = $1
+    `, id, string(status), attempts, statusCode, lastErr, nextAttemptAt)
+	return err
+}
+
+// ResetDelivery возвращает доставку в очередь с нулевым счётчиком попыток (replay);
+// ошибка прошлой попытки сбрасывается, чтобы не висеть на доставке в PENDING.
+// Если доставки нет — domain.ErrNotFound.
+func (r *webhookRepo) ResetDelivery(ctx context.Context, id int64) (domain.WebhookDelivery, error) {
+	row := r.db.QueryRowContext(ctx, `
+        UPDATE webhook_deliveries
+        SET status          = 'PENDING',
+            attempts        = 0,
+            next_attempt_at = now(),
+            delivered_at    = NULL,
+            last_error      = NULL
+        WHERE delivery_id = $1
+        RETURNING delivery_id, subscription_id, event_type, payload, status, attempts,
+                  next_attempt_at, last_status_code, last_error, created_at, delivered_at
+    `, id)
+
+	d, err := scanDelivery(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.WebhookDelivery{}, domain.ErrNotFound
+		}
+		return domain.WebhookDelivery{}, err
+	}
+	return d, nil
+}
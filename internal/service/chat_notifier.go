@@ -0,0 +1,165 @@
+package service
+
+import (
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/repository"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ChatNotifier пишет в канал команды (Slack/Mattermost incoming webhook),
+// когда на PR назначают ревьюверов. Реализует app.EventPublisher.
+type ChatNotifier struct {
+	teams   repository.TeamRepository
+	client  *http.Client
+	timeout time.Duration
+}
+
+// NewChatNotifier создаёт нотификатор. client можно подменить в тестах.
+func NewChatNotifier(teams repository.TeamRepository, client *http.Client, timeout time.Duration) *ChatNotifier {
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &ChatNotifier{
+		teams:   teams,
+		client:  client,
+		timeout: timeout,
+	}
+}
+
+// chatMessage формат incoming webhook, который понимают и Slack, и Mattermost.
+type chatMessage struct {
+	Text        string           `json:"text"`
+	Attachments []chatAttachment `json:"attachments,omitempty"`
+}
+
+type chatAttachment struct {
+	Fallback string      `json:"fallback"`
+	Color    string      `json:"color,omitempty"`
+	Title    string      `json:"title"`
+	Fields   []chatField `json:"fields"`
+}
+
+type chatField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+// Publish реагирует на создание PR (упоминает всех назначенных) и на замену ревьювера.
+// Отправка идёт в фоне, чтобы медленный чат не тормозил API; ошибки только логируются.
+func (n *ChatNotifier) Publish(ctx context.Context, ev domain.Event) {
+	switch {
+	case ev.Type == domain.EventPRCreated && len(ev.PullRequest.AssignedReviewers) > 0:
+	case ev.Type == domain.EventReviewerAssigned && ev.ReplacedReviewerID != "":
+	default:
+		return
+	}
+
+	go func() {
+		sendCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), n.timeout)
+		defer cancel()
+
+		if err := n.notify(sendCtx, ev); err != nil {
+			slog.ErrorContext(sendCtx, "chat: notify team", "team", ev.TeamName, "pull_request_id", ev.PullRequest.ID, "error", err)
+		}
+	}()
+}
+
+func (n *ChatNotifier) notify(ctx context.Context, ev domain.Event) error {
+	team, err := n.teams.Get(ctx, ev.TeamName)
+	if err != nil {
+		return err
+	}
+	if team.ChatWebhookURL == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(renderChatMessage(ev, team))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, team.ChatWebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func renderChatMessage(ev domain.Event, team domain.Team) chatMessage {
+	byID := make(map[string]domain.User, len(team.Members))
+	for _, m := range team.Members {
+		byID[m.ID] = m
+	}
+
+	pr := ev.PullRequest
+	author := displayName(byID, pr.AuthorID)
+
+	mentions := make([]string, 0, len(pr.AssignedReviewers))
+	for _, id := range pr.AssignedReviewers {
+		mentions = append(mentions, mention(byID, id))
+	}
+
+	var text string
+	if ev.Type == domain.EventPRCreated {
+		text = fmt.Sprintf("%s, please review *%s* by %s", strings.Join(mentions, ", "), pr.Name, author)
+	} else {
+		text = fmt.Sprintf("%s, you are now reviewing *%s* by %s (instead of %s)",
+			mention(byID, ev.ReviewerID), pr.Name, author, displayName(byID, ev.ReplacedReviewerID))
+	}
+
+	return chatMessage{
+		Text: text,
+		Attachments: []chatAttachment{{
+			Fallback: text,
+			Color:    "#36a64f",
+			Title:    pr.Name + " (" + pr.ID + ")",
+			Fields: []chatField{
+				{Title: "Author", Value: author, Short: true},
+				{Title: "Reviewers", Value: strings.Join(mentions, ", "), Short: true},
+			},
+		}},
+	}
+}
+
+// mention упоминание в формате чата: "@handle", готовые "<@U123>" и "@handle" оставляем как есть.
+// Без ника упоминать некого — подставляем имя.
+func mention(byID map[string]domain.User, id string) string {
+	u, ok := byID[id]
+	if !ok || u.ChatHandle == "" {
+		return displayName(byID, id)
+	}
+	if strings.HasPrefix(u.ChatHandle, "@") || strings.HasPrefix(u.ChatHandle, "<") {
+		return u.ChatHandle
+	}
+	return "@" + u.ChatHandle
+}
+
+func displayName(byID map[string]domain.User, id string) string {
+	if u, ok := byID[id]; ok && u.Username != "" {
+		return u.Username
+	}
+	return id
+}
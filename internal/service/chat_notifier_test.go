@@ -0,0 +1,171 @@
+package service
+
+import (
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/repository"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// chatTeam: у автора нет ника, ники ревьюверов записаны в трёх принятых формах.
+func chatTeam(webhookURL string) domain.Team {
+	return domain.Team{
+		Name: "backend",
+		Members: []domain.User{
+			{ID: "u1", Username: "Alice"},
+			{ID: "u2", Username: "Bob", ChatHandle: "bob"},
+			{ID: "u3", Username: "Carol", ChatHandle: "<@U03>"},
+			{ID: "u4", Username: "Dave", ChatHandle: "@dave"},
+			{ID: "u5", Username: "Eve"},
+		},
+		ChatWebhookURL: webhookURL,
+	}
+}
+
+func TestRenderChatMessage(t *testing.T) {
+	pr := domain.PullRequest{ID: "pr-1", Name: "Add search", AuthorID: "u1"}
+	withReviewers := func(ids ...string) domain.PullRequest {
+		p := pr
+		p.AssignedReviewers = ids
+		return p
+	}
+
+	tests := []struct {
+		name      string
+		ev        domain.Event
+		text      string
+		reviewers string
+	}{
+		{
+			name:      "created",
+			ev:        domain.Event{Type: domain.EventPRCreated, PullRequest: withReviewers("u2", "u3")},
+			text:      "@bob, <@U03>, please review *Add search* by Alice",
+			reviewers: "@bob, <@U03>",
+		},
+		{
+			// без ника упоминаем по имени, вне команды — по id
+			name:      "reviewers without handle",
+			ev:        domain.Event{Type: domain.EventPRCreated, PullRequest: withReviewers("u5", "u9")},
+			text:      "Eve, u9, please review *Add search* by Alice",
+			reviewers: "Eve, u9",
+		},
+		{
+			name: "reviewer replaced",
+			ev: domain.Event{
+				Type:               domain.EventReviewerAssigned,
+				PullRequest:        withReviewers("u3", "u4"),
+				ReviewerID:         "u4",
+				ReplacedReviewerID: "u2",
+			},
+			text:      "@dave, you are now reviewing *Add search* by Alice (instead of Bob)",
+			reviewers: "<@U03>, @dave",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := chatMessage{
+				Text: tt.text,
+				Attachments: []chatAttachment{{
+					Fallback: tt.text,
+					Color:    "#36a64f",
+					Title:    "Add search (pr-1)",
+					Fields: []chatField{
+						{Title: "Author", Value: "Alice", Short: true},
+						{Title: "Reviewers", Value: tt.reviewers, Short: true},
+					},
+				}},
+			}
+			if got := renderChatMessage(tt.ev, chatTeam("")); !reflect.DeepEqual(got, want) {
+				t.Fatalf("renderChatMessage() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+// fakeTeams отдаёт команды из map и сообщает в gets, какую команду запросили.
+type fakeTeams struct {
+	repository.TeamRepository
+	teams map[string]domain.Team
+	gets  chan string
+}
+
+func (f *fakeTeams) Get(_ context.Context, teamName string) (domain.Team, error) {
+	f.gets <- teamName
+	team, ok := f.teams[teamName]
+	if !ok {
+		return domain.Team{}, domain.ErrNotFound
+	}
+	return team, nil
+}
+
+func TestChatNotifierPublish(t *testing.T) {
+	received := make(chan chatMessage, 10)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected request %s with Content-Type %q", r.Method, r.Header.Get("Content-Type"))
+		}
+		var msg chatMessage
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			t.Errorf("decode chat message: %v", err)
+		}
+		received <- msg
+	}))
+	defer receiver.Close()
+
+	silent := chatTeam("")
+	silent.Name = "silent"
+	teams := &fakeTeams{
+		teams: map[string]domain.Team{"backend": chatTeam(receiver.URL), "silent": silent},
+		gets:  make(chan string, 10),
+	}
+	n := NewChatNotifier(teams, receiver.Client(), time.Second)
+
+	pr := domain.PullRequest{ID: "pr-1", Name: "Add search", AuthorID: "u1", AssignedReviewers: []string{"u2"}}
+	wait := func(what string, ch <-chan string) string {
+		t.Helper()
+		select {
+		case v := <-ch:
+			return v
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %s", what)
+			return ""
+		}
+	}
+
+	// без ревьюверов, первичное назначение и прочие события в чат не идут: команду даже не читают
+	ctx := context.Background()
+	n.Publish(ctx, domain.Event{Type: domain.EventPRCreated, TeamName: "backend", PullRequest: domain.PullRequest{ID: "pr-0"}})
+	n.Publish(ctx, domain.Event{Type: domain.EventReviewerAssigned, TeamName: "backend", PullRequest: pr, ReviewerID: "u2"})
+	n.Publish(ctx, domain.Event{Type: domain.EventPRMerged, TeamName: "backend", PullRequest: pr})
+	select {
+	case name := <-teams.gets:
+		t.Fatalf("skipped event loaded team %q", name)
+	default:
+	}
+
+	// у команды нет webhook: команду прочитали, но запроса нет
+	n.Publish(ctx, domain.Event{Type: domain.EventPRCreated, TeamName: "silent", PullRequest: pr})
+	if name := wait("team lookup", teams.gets); name != "silent" {
+		t.Fatalf("loaded team %q, want silent", name)
+	}
+
+	n.Publish(ctx, domain.Event{Type: domain.EventPRCreated, TeamName: "backend", PullRequest: pr})
+	if name := wait("team lookup", teams.gets); name != "backend" {
+		t.Fatalf("loaded team %q, want backend", name)
+	}
+	select {
+	case msg := <-received:
+		// первое сообщение — от backend: команда без webhook ничего не отправила
+		if msg.Text != "@bob, please review *Add search* by Alice" {
+			t.Fatalf("unexpected message %q", msg.Text)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for chat message")
+	}
+}
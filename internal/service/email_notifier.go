@@ -0,0 +1,334 @@
+package service
+
+import (
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/repository"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates/*.tmpl
+var emailTemplatesFS embed.FS
+
+var emailFuncs = map[string]any{"join": strings.Join}
+
+var (
+	assignmentText = texttemplate.Must(texttemplate.New("assignment.txt.tmpl").Funcs(emailFuncs).
+			ParseFS(emailTemplatesFS, "templates/assignment.txt.tmpl"))
+	assignmentHTML = htmltemplate.Must(htmltemplate.New("assignment.html.tmpl").Funcs(emailFuncs).
+			ParseFS(emailTemplatesFS, "templates/assignment.html.tmpl"))
+	digestText = texttemplate.Must(texttemplate.New("digest.txt.tmpl").Funcs(emailFuncs).
+			ParseFS(emailTemplatesFS, "templates/digest.txt.tmpl"))
+	digestHTML = htmltemplate.Must(htmltemplate.New("digest.html.tmpl").Funcs(emailFuncs).
+			ParseFS(emailTemplatesFS, "templates/digest.html.tmpl"))
+)
+
+// SMTPSettings параметры подключения к SMTP-серверу.
+type SMTPSettings struct {
+	Host     string
+	Port     int
+	Username string // пустой — без AUTH
+	Password string
+	From     string
+	Timeout  time.Duration
+	// TLSConfig для STARTTLS; nil — системные корневые сертификаты и проверка имени Host
+	TLSConfig *tls.Config
+}
+
+// EmailNotifier шлёт письма о назначении ревьювером и ежедневный дайджест.
+// Реализует app.EventPublisher.
+type EmailNotifier struct {
+	users repository.UserRepository
+	prs   repository.PRRepository
+	smtp  SMTPSettings
+}
+
+// NewEmailNotifier создаёт email-нотификатор.
+func NewEmailNotifier(users repository.UserRepository, prs repository.PRRepository, settings SMTPSettings) *EmailNotifier {
+	return &EmailNotifier{
+		users: users,
+		prs:   prs,
+		smtp:  settings,
+	}
+}
+
+type assignmentEmailData struct {
+	Reviewer      domain.User
+	PR            domain.PullRequest
+	AuthorName    string
+	ReplacedName  string
+	ReviewerNames []string
+}
+
+type digestEmailData struct {
+	User domain.User
+	PRs  []domain.PullRequest
+}
+
+// Publish на reviewer.assigned отправляет письмо назначенному ревьюверу (в фоне).
+func (n *EmailNotifier) Publish(ctx context.Context, ev domain.Event) {
+	if ev.Type != domain.EventReviewerAssigned {
+		return
+	}
+
+	go func() {
+		sendCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), n.smtp.Timeout)
+		defer cancel()
+
+		if err := n.notifyAssignment(sendCtx, ev); err != nil {
+			slog.ErrorContext(sendCtx, "email: notify reviewer", "user_id", ev.ReviewerID, "pull_request_id", ev.PullRequest.ID, "error", err)
+		}
+	}()
+}
+
+func (n *EmailNotifier) notifyAssignment(ctx context.Context, ev domain.Event) error {
+	members, err := n.users.ListByTeam(ctx, ev.TeamName)
+	if err != nil {
+		return err
+	}
+	byID := make(map[string]domain.User, len(members))
+	for _, m := range members {
+		byID[m.ID] = m
+	}
+
+	reviewer, ok := byID[ev.ReviewerID]
+	if !ok {
+		// ревьювер мог оказаться из другой команды (например, выставлен руками в GitLab)
+		reviewer, err = n.users.GetByID(ctx, ev.ReviewerID)
+		if err != nil {
+			return err
+		}
+	}
+	if reviewer.Email == "" || reviewer.EmailOptOut.Assignments {
+		return nil
+	}
+
+	data := assignmentEmailData{
+		Reviewer:      reviewer,
+		PR:            ev.PullRequest,
+		AuthorName:    displayName(byID, ev.PullRequest.AuthorID),
+		ReviewerNames: make([]string, 0, len(ev.PullRequest.AssignedReviewers)),
+	}
+	if ev.ReplacedReviewerID != "" {
+		data.ReplacedName = displayName(byID, ev.ReplacedReviewerID)
+	}
+	for _, id := range ev.PullRequest.AssignedReviewers {
+		data.ReviewerNames = append(data.ReviewerNames, displayName(byID, id))
+	}
+
+	var text, html bytes.Buffer
+	if err := assignmentText.Execute(&text, data); err != nil {
+		return err
+	}
+	if err := assignmentHTML.Execute(&html, data); err != nil {
+		return err
+	}
+
+	subject := "Review requested: " + ev.PullRequest.Name
+	return n.send(ctx, reviewer.Email, subject, text.Bytes(), html.Bytes())
+}
+
+// SendDigests рассылает дайджест открытых PR на ревью всем подписанным пользователям.
+// Пользователям без ожидающих PR письмо не отправляется. Возвращает число отправленных писем.
+func (n *EmailNotifier) SendDigests(ctx context.Context) (int, error) {
+	recipients, err := n.users.ListDigestRecipients(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, u := range recipients {
+		prs, err := n.prs.ListReviewerPRs(ctx, u.ID)
+		if err != nil {
+			return sent, err
+		}
+
+		pending := make([]domain.PullRequest, 0, len(prs))
+		for _, pr := range prs {
+			if pr.Status == domain.PRStatusOpen {
+				pending = append(pending, pr)
+			}
+		}
+		if len(pending) == 0 {
+			continue
+		}
+
+		data := digestEmailData{User: u, PRs: pending}
+		var text, html bytes.Buffer
+		if err := digestText.Execute(&text, data); err != nil {
+			return sent, err
+		}
+		if err := digestHTML.Execute(&html, data); err != nil {
+			return sent, err
+		}
+
+		subject := fmt.Sprintf("%d pull request(s) waiting for your review", len(pending))
+		if err := n.send(ctx, u.Email, subject, text.Bytes(), html.Bytes()); err != nil {
+			// одному не дошло — остальным всё равно шлём
+			slog.ErrorContext(ctx, "email: send digest", "user_id", u.ID, "error", err)
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// RunDigest раз в сутки в момент at (смещение от полуночи в loc) вызывает SendDigests, пока не отменён ctx.
+func (n *EmailNotifier) RunDigest(ctx context.Context, at time.Duration, loc *time.Location) {
+	for {
+		wait := time.Until(nextDailyRun(time.Now().In(loc), at))
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		sent, err := n.SendDigests(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "email: send digests", "error", err)
+		}
+		slog.InfoContext(ctx, "email: daily digest sent", "recipients", sent)
+	}
+}
+
+// nextDailyRun ближайший момент строго после now, когда часы показывают at от начала дня.
+func nextDailyRun(now time.Time, at time.Duration) time.Time {
+	y, m, d := now.Date()
+	next := time.Date(y, m, d, 0, 0, 0, 0, now.Location()).Add(at)
+	if !next.After(now) {
+		next = time.Date(y, m, d+1, 0, 0, 0, 0, now.Location()).Add(at)
+	}
+	return next
+}
+
+// tlsConfig конфиг STARTTLS: без ServerName TLS-клиент Go отказывается проверять сертификат.
+func (n *EmailNotifier) tlsConfig() *tls.Config {
+	if n.smtp.TLSConfig == nil {
+		return &tls.Config{ServerName: n.smtp.Host}
+	}
+	cfg := n.smtp.TLSConfig.Clone()
+	if cfg.ServerName == "" {
+		cfg.ServerName = n.smtp.Host
+	}
+	return cfg
+}
+
+// send собирает multipart/alternative письмо (text + html) и отправляет его по SMTP.
+func (n *EmailNotifier) send(ctx context.Context, to, subject string, text, html []byte) error {
+	msg, err := buildMessage(n.smtp.From, to, subject, text, html)
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(n.smtp.Host, strconv.Itoa(n.smtp.Port))
+	dialer := net.Dialer{Timeout: n.smtp.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, n.smtp.Host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer func() {
+		_ = c.Close()
+	}()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(n.tlsConfig()); err != nil {
+			return err
+		}
+	}
+	if n.smtp.Username != "" {
+		auth := smtp.PlainAuth("", n.smtp.Username, n.smtp.Password, n.smtp.Host)
+		if err := c.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(n.smtp.From); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	wc, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := wc.Write(msg); err != nil {
+		_ = wc.Close()
+		return err
+	}
+	if err := wc.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func buildMessage(from, to, subject string, text, html []byte) ([]byte, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+
+	parts := []struct {
+		contentType string
+		content     []byte
+	}{
+		{"text/plain; charset=UTF-8", text},
+		{"text/html; charset=UTF-8", html},
+	}
+	for _, p := range parts {
+		pw, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {p.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qw := quotedprintable.NewWriter(pw)
+		if _, err := qw.Write(p.content); err != nil {
+			return nil, err
+		}
+		if err := qw.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", mw.Boundary())
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
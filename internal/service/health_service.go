@@ -0,0 +1,70 @@
+package service
+
+import (
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/repository"
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+type healthService struct {
+	schema          repository.SchemaRepository
+	expectedVersion int
+	draining        atomic.Bool
+}
+
+// NewHealthService создаёт сервис проверок. expectedVersion — последняя миграция,
+// с которой собран бинарь (db.LatestVersion).
+func NewHealthService(schema repository.SchemaRepository, expectedVersion int) app.HealthService {
+	return &healthService{
+		schema:          schema,
+		expectedVersion: expectedVersion,
+	}
+}
+
+// Drain помечает сервис неготовым: дальше /readyz отвечает 503, пока процесс не завершится.
+func (s *healthService) Drain() {
+	s.draining.Store(true)
+}
+
+// Ready проверяет остановку, соединение с БД и версию схемы.
+// Схема новее ожидаемой считается готовой: при раскатке миграции применяются раньше,
+// чем обновляются все реплики, и старые не должны выпадать из балансировки.
+func (s *healthService) Ready(ctx context.Context) domain.HealthReport {
+	report := domain.HealthReport{Ready: true}
+	add := func(name string, err error) {
+		check := domain.HealthCheck{Name: name, OK: err == nil}
+		if err != nil {
+			check.Error = err.Error()
+			report.Ready = false
+		}
+		report.Checks = append(report.Checks, check)
+	}
+
+	if s.draining.Load() {
+		add("shutdown", fmt.Errorf("server is shutting down"))
+	} else {
+		add("shutdown", nil)
+	}
+
+	if err := s.schema.Ping(ctx); err != nil {
+		add("database", err)
+		add("migrations", fmt.Errorf("database unavailable"))
+		return report
+	}
+	add("database", nil)
+
+	version, err := s.schema.SchemaVersion(ctx)
+	switch {
+	case err != nil:
+		add("migrations", err)
+	case version < s.expectedVersion:
+		add("migrations", fmt.Errorf("schema version %d, want %d", version, s.expectedVersion))
+	default:
+		add("migrations", nil)
+	}
+
+	return report
+}
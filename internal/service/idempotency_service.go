@@ -0,0 +1,81 @@
+package service
+
+import (
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/repository"
+	"context"
+	"log/slog"
+	"time"
+)
+
+type idempotencyService struct {
+	keys        repository.IdempotencyRepository
+	ttl         time.Duration
+	lockTimeout time.Duration
+}
+
+// NewIdempotencyService создаёт сервис ключей идемпотентности. Ответ хранится ttl;
+// незавершённый запрос держит ключ не дольше lockTimeout — потом ключ считается брошенным
+// (процесс упал посреди запроса) и может быть занят повтором.
+func NewIdempotencyService(keys repository.IdempotencyRepository, ttl, lockTimeout time.Duration) app.IdempotencyService {
+	return &idempotencyService{keys: keys, ttl: ttl, lockTimeout: lockTimeout}
+}
+
+// Begin занимает ключ или возвращает сохранённый ответ на тот же запрос.
+func (s *idempotencyService) Begin(ctx context.Context, scope, key, requestHash string) (domain.IdempotentResponse, bool, error) {
+	rec, reserved, err := s.keys.Reserve(ctx, scope, key, requestHash, s.ttl, s.lockTimeout)
+	if err != nil {
+		return domain.IdempotentResponse{}, false, err
+	}
+
+	switch {
+	case reserved:
+		return domain.IdempotentResponse{}, false, nil
+	case rec.RequestHash != requestHash:
+		return domain.IdempotentResponse{}, false, domain.ErrIdempotencyMismatch
+	case rec.Response == nil:
+		return domain.IdempotentResponse{}, false, domain.ErrIdempotencyInProgress
+	default:
+		return *rec.Response, true, nil
+	}
+}
+
+// Complete сохраняет ответ на занятый ключ.
+func (s *idempotencyService) Complete(ctx context.Context, scope, key string, resp domain.IdempotentResponse) error {
+	return s.keys.Complete(ctx, scope, key, resp)
+}
+
+// Release освобождает ключ без ответа, чтобы повтор выполнился заново.
+func (s *idempotencyService) Release(ctx context.Context, scope, key string) error {
+	return s.keys.Release(ctx, scope, key)
+}
+
+// PurgeExpired удаляет истёкшие ключи. На корректность не влияет — истёкший ключ и так
+// перезанимается, — только не даёт таблице расти.
+func (s *idempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	return s.keys.DeleteExpired(ctx)
+}
+
+// RunIdempotencyPurge раз в interval чистит истёкшие ключи, пока не отменён ctx.
+func RunIdempotencyPurge(ctx context.Context, svc app.IdempotencyService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		n, err := svc.PurgeExpired(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "idempotency: purge expired keys", "error", err)
+			continue
+		}
+		if n > 0 {
+			slog.DebugContext(ctx, "idempotency: purged expired keys", "count", n)
+		}
+	}
+}
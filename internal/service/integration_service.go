@@ -0,0 +1,197 @@
+package service
+
+import (
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/repository"
+	"context"
+	"errors"
+)
+
+type integrationService struct {
+	identities repository.IdentityRepository
+	users      repository.UserRepository
+	prs        app.PRService
+}
+
+// NewIntegrationService создаёт сервис, который переводит события Git-хостингов в вызовы PRService.
+func NewIntegrationService(
+	identities repository.IdentityRepository,
+	users repository.UserRepository,
+	prs app.PRService,
+) app.IntegrationService {
+	return &integrationService{
+		identities: identities,
+		users:      users,
+		prs:        prs,
+	}
+}
+
+// LinkIdentity привязывает внешний логин к пользователю.
+// Если пользователя нет — domain.ErrNotFound.
+func (s *integrationService) LinkIdentity(ctx context.Context, id domain.Identity) error {
+	if _, err := s.users.GetByID(ctx, id.UserID); err != nil {
+		return err
+	}
+	return s.identities.Upsert(ctx, id)
+}
+
+// HandlePREvent применяет внешнее событие к PR.
+// Хостинг повторяет доставки и шлёт события, до которых нам нет дела, поэтому
+// «нечего делать» (неизвестный автор, PR уже есть, PR не найден) — это не ошибка, а OutcomeIgnored.
+func (s *integrationService) HandlePREvent(ctx context.Context, ev domain.ExternalPREvent) (domain.IntegrationResult, error) {
+	switch ev.Action {
+	case domain.ExternalPROpened:
+		return s.handleOpened(ctx, ev)
+	case domain.ExternalPRReopened:
+		return s.handleReopened(ctx, ev)
+	case domain.ExternalPRMerged:
+		return s.handleMerged(ctx, ev)
+	case domain.ExternalPRClosed:
+		return s.handleClosed(ctx, ev)
+	case domain.ExternalPRUpdated:
+		if !ev.SyncReviewers {
+			return ignored(ev, "nothing to update"), nil
+		}
+		return s.syncReviewers(ctx, ev)
+	default:
+		return ignored(ev, "unsupported action"), nil
+	}
+}
+
+func (s *integrationService) handleOpened(ctx context.Context, ev domain.ExternalPREvent) (domain.IntegrationResult, error) {
+	authorID, err := s.resolveAuthor(ctx, ev)
+	if errors.Is(err, domain.ErrNotFound) {
+		return ignored(ev, "author login is not linked to a user"), nil
+	}
+	if err != nil {
+		return domain.IntegrationResult{}, err
+	}
+
+	_, err = s.prs.CreatePR(ctx, ev.PullRequest, ev.Title, authorID)
+	switch {
+	case errors.Is(err, domain.ErrPRExists):
+		return ignored(ev, "pull request already exists"), nil
+	case errors.Is(err, domain.ErrNotFound):
+		return ignored(ev, "author user not found"), nil
+	case err != nil:
+		return domain.IntegrationResult{}, err
+	}
+
+	// ревьюверов уже выставили руками — они важнее автоназначения
+	if ev.SyncReviewers {
+		if _, err := s.syncReviewers(ctx, ev); err != nil {
+			return domain.IntegrationResult{}, err
+		}
+	}
+
+	return domain.IntegrationResult{
+		Outcome:       domain.OutcomeCreated,
+		PullRequestID: ev.PullRequest,
+	}, nil
+}
+
+// resolveAuthor ищет автора по id на хостинге, а если id не привязан — по логину.
+func (s *integrationService) resolveAuthor(ctx context.Context, ev domain.ExternalPREvent) (string, error) {
+	if ev.AuthorExternalID != "" {
+		userID, err := s.identities.ResolveUserIDByExternalID(ctx, ev.Provider, ev.AuthorExternalID)
+		if !errors.Is(err, domain.ErrNotFound) {
+			return userID, err
+		}
+	}
+	if ev.AuthorLogin == "" {
+		return "", domain.ErrNotFound
+	}
+	return s.identities.ResolveUserID(ctx, ev.Provider, ev.AuthorLogin)
+}
+
+func (s *integrationService) handleReopened(ctx context.Context, ev domain.ExternalPREvent) (domain.IntegrationResult, error) {
+	_, err := s.prs.ReopenPR(ctx, ev.PullRequest)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		// PR открывали до подключения интеграции — заводим как новый
+		return s.handleOpened(ctx, ev)
+	case errors.Is(err, domain.ErrPRMerged):
+		return ignored(ev, "pull request is already merged"), nil
+	case err != nil:
+		return domain.IntegrationResult{}, err
+	}
+
+	return domain.IntegrationResult{
+		Outcome:       domain.OutcomeReopened,
+		PullRequestID: ev.PullRequest,
+	}, nil
+}
+
+func (s *integrationService) handleMerged(ctx context.Context, ev domain.ExternalPREvent) (domain.IntegrationResult, error) {
+	_, err := s.prs.MergePR(ctx, ev.PullRequest)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return ignored(ev, "pull request is not tracked"), nil
+	case errors.Is(err, domain.ErrPRClosed):
+		return ignored(ev, "pull request is closed"), nil
+	case err != nil:
+		return domain.IntegrationResult{}, err
+	}
+
+	return domain.IntegrationResult{
+		Outcome:       domain.OutcomeMerged,
+		PullRequestID: ev.PullRequest,
+	}, nil
+}
+
+func (s *integrationService) handleClosed(ctx context.Context, ev domain.ExternalPREvent) (domain.IntegrationResult, error) {
+	_, err := s.prs.ClosePR(ctx, ev.PullRequest)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return ignored(ev, "pull request is not tracked"), nil
+	case errors.Is(err, domain.ErrPRMerged):
+		return ignored(ev, "pull request is already merged"), nil
+	case err != nil:
+		return domain.IntegrationResult{}, err
+	}
+
+	return domain.IntegrationResult{
+		Outcome:       domain.OutcomeClosed,
+		PullRequestID: ev.PullRequest,
+	}, nil
+}
+
+// syncReviewers приводит ревьюверов PR к выставленным во внешней системе.
+// Логины без привязки к пользователю пропускаются.
+func (s *integrationService) syncReviewers(ctx context.Context, ev domain.ExternalPREvent) (domain.IntegrationResult, error) {
+	reviewerIDs := make([]string, 0, len(ev.ReviewerLogins))
+	for _, login := range ev.ReviewerLogins {
+		id, err := s.identities.ResolveUserID(ctx, ev.Provider, login)
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return domain.IntegrationResult{}, err
+		}
+		reviewerIDs = append(reviewerIDs, id)
+	}
+
+	_, err := s.prs.SetReviewers(ctx, ev.PullRequest, reviewerIDs)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return ignored(ev, "pull request is not tracked"), nil
+	case errors.Is(err, domain.ErrPRMerged), errors.Is(err, domain.ErrPRClosed):
+		return ignored(ev, "pull request is not open"), nil
+	case err != nil:
+		return domain.IntegrationResult{}, err
+	}
+
+	return domain.IntegrationResult{
+		Outcome:       domain.OutcomeReviewersSynced,
+		PullRequestID: ev.PullRequest,
+	}, nil
+}
+
+func ignored(ev domain.ExternalPREvent, reason string) domain.IntegrationResult {
+	return domain.IntegrationResult{
+		Outcome:       domain.OutcomeIgnored,
+		PullRequestID: ev.PullRequest,
+		Reason:        reason,
+	}
+}
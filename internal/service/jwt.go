@@ -0,0 +1,220 @@
+package service
+
+import (
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/domain"
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// допуск на расхождение часов с выпускающей стороной для exp/nbf/iat
+const jwtLeeway = 30 * time.Second
+
+// Роли из claim roles, которые что-то значат для сервиса.
+const (
+	jwtRoleAdmin    = "admin"
+	jwtRoleTeamLead = "team_lead"
+)
+
+// JWTSettings откуда брать ключи проверки JWT и что требовать от claims.
+type JWTSettings struct {
+	HMACSecret string // общий секрет для HS256
+	JWKSFile   string // JWKS на диске: RSA-ключи для RS256 и oct-ключи для HS256
+	Issuer     string // если задан, iss обязан совпасть
+	Audience   string // если задан, aud обязан его содержать
+}
+
+// jwtClaims claims, которые сервис понимает помимо стандартных.
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	Team  string   `json:"team"`
+	Roles []string `json:"roles"`
+}
+
+// principal: sub — user_id, роль admin даёт admin-scope, team_lead с claim team — тимлида команды.
+func (c jwtClaims) principal() (domain.Principal, error) {
+	if c.Subject == "" {
+		return domain.Principal{}, errors.New("sub claim is required")
+	}
+
+	p := domain.Principal{
+		Scope:    domain.ScopeUser,
+		UserID:   c.Subject,
+		TeamName: c.Team,
+		TeamLead: c.Team != "" && slices.Contains(c.Roles, jwtRoleTeamLead),
+	}
+	if slices.Contains(c.Roles, jwtRoleAdmin) {
+		p.Scope = domain.ScopeAdmin
+	}
+	return p, nil
+}
+
+// jwtKey ключ проверки подписи. Пустой kid подходит к токену с любым kid.
+type jwtKey struct {
+	alg string // HS256 | RS256
+	kid string
+	key any // []byte для HS256, *rsa.PublicKey для RS256
+}
+
+type jwtTokenService struct {
+	app.TokenService
+
+	keys   []jwtKey
+	parser *jwt.Parser
+}
+
+// NewJWTTokenService добавляет к TokenService приём JWT: токен из трёх сегментов через точку
+// проверяется как JWT, остальные уходят в next. Ключи читаются один раз при старте.
+func NewJWTTokenService(next app.TokenService, s JWTSettings) (app.TokenService, error) {
+	var keys []jwtKey
+	if s.HMACSecret != "" {
+		keys = append(keys, jwtKey{alg: jwt.SigningMethodHS256.Alg(), key: []byte(s.HMACSecret)})
+	}
+	if s.JWKSFile != "" {
+		fileKeys, err := loadJWKS(s.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("load JWKS %s: %w", s.JWKSFile, err)
+		}
+		keys = append(keys, fileKeys...)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no JWT verification keys configured")
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(jwtLeeway),
+	}
+	if s.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(s.Issuer))
+	}
+	if s.Audience != "" {
+		opts = append(opts, jwt.WithAudience(s.Audience))
+	}
+
+	return &jwtTokenService{
+		TokenService: next,
+		keys:         keys,
+		parser:       jwt.NewParser(opts...),
+	}, nil
+}
+
+// Authenticate проверяет JWT и переводит claims в принципала. Любая проблема с JWT —
+// domain.ErrUnauthorized, причина пишется в debug-лог, чтобы не подсказывать её клиенту.
+func (s *jwtTokenService) Authenticate(ctx context.Context, token string) (domain.Principal, error) {
+	if strings.Count(token, ".") != 2 {
+		return s.TokenService.Authenticate(ctx, token)
+	}
+
+	var claims jwtClaims
+	if _, err := s.parser.ParseWithClaims(token, &claims, s.keyFunc); err != nil {
+		slog.DebugContext(ctx, "jwt rejected", "error", err)
+		return domain.Principal{}, domain.ErrUnauthorized
+	}
+
+	p, err := claims.principal()
+	if err != nil {
+		slog.DebugContext(ctx, "jwt rejected", "error", err)
+		return domain.Principal{}, domain.ErrUnauthorized
+	}
+	return p, nil
+}
+
+// keyFunc подбирает ключи по alg и kid из заголовка. Без kid пробуются все ключи этого alg.
+func (s *jwtTokenService) keyFunc(t *jwt.Token) (any, error) {
+	alg := t.Method.Alg()
+	kid, _ := t.Header["kid"].(string)
+
+	var set jwt.VerificationKeySet
+	for _, k := range s.keys {
+		if k.alg == alg && (k.kid == "" || kid == "" || k.kid == kid) {
+			set.Keys = append(set.Keys, k.key)
+		}
+	}
+	if len(set.Keys) == 0 {
+		return nil, fmt.Errorf("no %s key for kid %q", alg, kid)
+	}
+	return set, nil
+}
+
+// jwk ключ из JWKS (RFC 7517); нужны только RSA и oct.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	K   string `json:"k"`
+}
+
+// loadJWKS читает ключи подписи из JWKS-файла. Ключи шифрования и чужих алгоритмов пропускаются.
+func loadJWKS(path string) ([]jwtKey, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(raw, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make([]jwtKey, 0, len(set.Keys))
+	for i, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		switch {
+		case k.Kty == "RSA" && (k.Alg == "" || k.Alg == jwt.SigningMethodRS256.Alg()):
+			pub, err := k.rsaPublicKey()
+			if err != nil {
+				return nil, fmt.Errorf("key %d (kid %q): %w", i, k.Kid, err)
+			}
+			keys = append(keys, jwtKey{alg: jwt.SigningMethodRS256.Alg(), kid: k.Kid, key: pub})
+		case k.Kty == "oct" && (k.Alg == "" || k.Alg == jwt.SigningMethodHS256.Alg()):
+			secret, err := base64.RawURLEncoding.DecodeString(k.K)
+			if err != nil || len(secret) == 0 {
+				return nil, fmt.Errorf("key %d (kid %q): invalid k", i, k.Kid)
+			}
+			keys = append(keys, jwtKey{alg: jwt.SigningMethodHS256.Alg(), kid: k.Kid, key: secret})
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no RS256 or HS256 signing keys")
+	}
+	return keys, nil
+}
+
+func (k jwk) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil || len(n) == 0 {
+		return nil, errors.New("invalid modulus n")
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil || len(e) == 0 || len(e) > 4 {
+		return nil, errors.New("invalid exponent e")
+	}
+
+	exp := 0
+	for _, b := range e {
+		exp = exp<<8 | int(b)
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: exp}, nil
+}
@@ -13,18 +13,22 @@ import (
 )
 
 type prService struct {
-	prs   repository.PRRepository
-	users repository.UserRepository
+	prs    repository.PRRepository
+	users  repository.UserRepository
+	events app.EventPublisher
 }
 
 // NewPRService создаёт сервис для работы с pull requestами.
+// В events уходят события о создании/мердже PR и смене ревьюверов.
 func NewPRService(
 	prs repository.PRRepository,
 	users repository.UserRepository,
+	events app.EventPublisher,
 ) app.PRService {
 	return &prService{
-		prs:   prs,
-		users: users,
+		prs:    prs,
+		users:  users,
+		events: events,
 	}
 }
 
@@ -71,16 +75,25 @@ func (s *prService) CreatePR(ctx context.Context, id, name, authorID string) (do
 		}
 	}
 
+	s.publish(ctx, domain.EventPRCreated, author.TeamName, pr, "")
+	for _, rid := range reviewerIDs {
+		s.publish(ctx, domain.EventReviewerAssigned, author.TeamName, pr, rid)
+	}
+
 	return pr, nil
 }
 
-// MergePR делает merge PR.
+// MergePR делает merge PR. Повторный merge возвращает PR как есть, для CLOSED — domain.ErrPRClosed.
 func (s *prService) MergePR(ctx context.Context, id string) (domain.PullRequest, error) {
 	pr, err := s.prs.GetForUpdate(ctx, id)
 	if err != nil {
 		// ожидается domain.ErrNotFound, который наверху превратится в 404
 		return domain.PullRequest{}, err
 	}
+	if pr.IsClosed() {
+		// закрытый PR сначала переоткрывают, иначе он стал бы MERGED с проставленным closed_at
+		return domain.PullRequest{}, domain.ErrPRClosed
+	}
 
 	if pr.IsMerged() {
 		reviewers, err := s.prs.GetReviewers(ctx, id)
@@ -106,11 +119,21 @@ func (s *prService) MergePR(ctx context.Context, id string) (domain.PullRequest,
 	}
 	pr.AssignedReviewers = reviewers
 
+	author, err := s.users.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	s.publish(ctx, domain.EventPRMerged, author.TeamName, pr, "")
+
 	return pr, nil
 }
 
 // ReassignReviewer переназначает одного ревьювера на другого из его команды.
 func (s *prService) ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (domain.PullRequest, string, error) {
+	if err := s.authorizeReviewer(ctx, oldReviewerID); err != nil {
+		return domain.PullRequest{}, "", err // domain.ErrForbidden
+	}
+
 	pr, err := s.prs.GetForUpdate(ctx, prID)
 	if err != nil {
 		return domain.PullRequest{}, "", err // может быть domain.ErrNotFound
@@ -173,9 +196,203 @@ func (s *prService) ReassignReviewer(ctx context.Context, prID, oldReviewerID st
 	}
 	pr.AssignedReviewers = reviewers
 
+	s.publish(ctx, domain.EventReviewerUnassigned, oldReviewer.TeamName, pr, oldReviewerID)
+	s.events.Publish(ctx, domain.Event{
+		Type:               domain.EventReviewerAssigned,
+		TeamName:           oldReviewer.TeamName,
+		PullRequest:        pr,
+		ReviewerID:         newReviewerID,
+		ReplacedReviewerID: oldReviewerID,
+		OccurredAt:         time.Now(),
+	})
+
 	return pr, newReviewerID, nil
 }
 
+// authorizeReviewer: снять ревьюера или поставить вердикт за него может он сам, тимлид его команды или админ.
+// Вызовы без принципала (вебхуки Git-хостингов, фоновые задачи) не ограничиваются.
+func (s *prService) authorizeReviewer(ctx context.Context, reviewerID string) error {
+	p, ok := app.PrincipalFrom(ctx)
+	if !ok || p.CanActAs(reviewerID) {
+		return nil
+	}
+	if !p.TeamLead {
+		return domain.ErrForbidden
+	}
+
+	reviewer, err := s.users.GetByID(ctx, reviewerID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrForbidden // не подсказываем, есть ли такой пользователь
+		}
+		return err
+	}
+	if !p.LeadsTeam(reviewer.TeamName) {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// ClosePR закрывает открытый PR без merge. Повторный вызов на закрытом PR ничего не меняет.
+// Для MERGED возвращает domain.ErrPRMerged.
+func (s *prService) ClosePR(ctx context.Context, id string) (domain.PullRequest, error) {
+	pr, err := s.prs.GetForUpdate(ctx, id)
+	if err != nil {
+		return domain.PullRequest{}, err // может быть domain.ErrNotFound
+	}
+	if pr.IsMerged() {
+		return domain.PullRequest{}, domain.ErrPRMerged
+	}
+	if pr.IsClosed() {
+		return s.withReviewers(ctx, pr)
+	}
+
+	if err := s.prs.UpdateStatusClosed(ctx, id); err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	return s.reloadAndPublish(ctx, id, domain.EventPRClosed)
+}
+
+// ReopenPR возвращает закрытый PR в OPEN с прежними ревьюверами.
+// Открытый PR возвращается как есть, для MERGED — domain.ErrPRMerged.
+func (s *prService) ReopenPR(ctx context.Context, id string) (domain.PullRequest, error) {
+	pr, err := s.prs.GetForUpdate(ctx, id)
+	if err != nil {
+		return domain.PullRequest{}, err // может быть domain.ErrNotFound
+	}
+	if pr.IsMerged() {
+		return domain.PullRequest{}, domain.ErrPRMerged
+	}
+	if !pr.IsClosed() {
+		return s.withReviewers(ctx, pr)
+	}
+
+	if err := s.prs.UpdateStatusReopened(ctx, id); err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	return s.reloadAndPublish(ctx, id, domain.EventPRReopened)
+}
+
+// SetReviewers приводит состав ревьюверов открытого PR к заданному списку
+// (например, когда ревьюверов выставили руками во внешней системе).
+// Автор, неизвестные и неактивные пользователи из списка отбрасываются,
+// дубликаты схлопываются.
+func (s *prService) SetReviewers(ctx context.Context, prID string, reviewerIDs []string) (domain.PullRequest, error) {
+	pr, err := s.prs.GetForUpdate(ctx, prID)
+	if err != nil {
+		return domain.PullRequest{}, err // может быть domain.ErrNotFound
+	}
+	if err := pr.CanBeReassigned(); err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	current, err := s.prs.GetReviewers(ctx, prID)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	desired := make([]string, 0, len(reviewerIDs))
+	for _, id := range reviewerIDs {
+		if id == pr.AuthorID || contains(desired, id) {
+			continue
+		}
+		// неактивного не назначаем, даже если его выставили во внешней системе
+		u, err := s.users.GetByID(ctx, id)
+		if errors.Is(err, domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return domain.PullRequest{}, err
+		}
+		if !u.IsActive {
+			continue
+		}
+		desired = append(desired, id)
+	}
+
+	var removed, added []string
+	for _, id := range current {
+		if contains(desired, id) {
+			continue
+		}
+		if err := s.prs.RemoveReviewer(ctx, prID, id); err != nil {
+			return domain.PullRequest{}, err
+		}
+		removed = append(removed, id)
+	}
+	for _, id := range desired {
+		if contains(current, id) {
+			continue
+		}
+		if err := s.prs.AddReviewer(ctx, prID, id); err != nil {
+			return domain.PullRequest{}, err
+		}
+		added = append(added, id)
+	}
+
+	pr, err = s.withReviewers(ctx, pr)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	if len(removed) == 0 && len(added) == 0 {
+		return pr, nil
+	}
+
+	author, err := s.users.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	for _, id := range removed {
+		s.publish(ctx, domain.EventReviewerUnassigned, author.TeamName, pr, id)
+	}
+	for _, id := range added {
+		s.publish(ctx, domain.EventReviewerAssigned, author.TeamName, pr, id)
+	}
+
+	return pr, nil
+}
+
+func (s *prService) withReviewers(ctx context.Context, pr domain.PullRequest) (domain.PullRequest, error) {
+	reviewers, err := s.prs.GetReviewers(ctx, pr.ID)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	pr.AssignedReviewers = reviewers
+	return pr, nil
+}
+
+// reloadAndPublish перечитывает PR после смены статуса и отдаёт событие команде автора.
+func (s *prService) reloadAndPublish(ctx context.Context, id string, t domain.EventType) (domain.PullRequest, error) {
+	pr, err := s.prs.GetForUpdate(ctx, id)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	pr, err = s.withReviewers(ctx, pr)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	author, err := s.users.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	s.publish(ctx, t, author.TeamName, pr, "")
+
+	return pr, nil
+}
+
+func (s *prService) publish(ctx context.Context, t domain.EventType, teamName string, pr domain.PullRequest, reviewerID string) {
+	s.events.Publish(ctx, domain.Event{
+		Type:        t,
+		TeamName:    teamName,
+		PullRequest: pr,
+		ReviewerID:  reviewerID,
+		OccurredAt:  time.Now(),
+	})
+}
+
 // listTeamMembers — обертка вокруг UserRepository.ListByTeam.
 // Для этого UserRepository должен реализовывать метод ListByTeam.
 func (s *prService) listTeamMembers(ctx context.Context, teamName string) ([]domain.User, error) {
@@ -207,13 +424,13 @@ func filterActiveExcept(users []domain.User, excludeID string) []domain.User {
 }
 
 // GetAssignmentStatsByReviewer возвращает статистику назначений по ревьюверам.
-func (s *prService) GetAssignmentStatsByReviewer(ctx context.Context) ([]domain.AssignmentStats, error) {
-	return s.prs.GetAssignmentStatsByReviewer(ctx)
+func (s *prService) GetAssignmentStatsByReviewer(ctx context.Context, f domain.AssignmentStatsFilter) ([]domain.AssignmentStats, error) {
+	return s.prs.GetAssignmentStatsByReviewer(ctx, f)
 }
 
 // GetAssignmentStatsByPR статистика по PR
-func (s *prService) GetAssignmentStatsByPR(ctx context.Context) ([]domain.PullRequestAssignmentStats, error) {
-	return s.prs.GetAssignmentStatsByPR(ctx)
+func (s *prService) GetAssignmentStatsByPR(ctx context.Context, f domain.AssignmentStatsFilter) ([]domain.PullRequestAssignmentStats, error) {
+	return s.prs.GetAssignmentStatsByPR(ctx, f)
 }
 
 // pickRandomUserIDs выбирает до предела случайных user.ID.
@@ -262,3 +479,73 @@ func contains(ids []string, target string) bool {
 	}
 	return false
 }
+
+// SubmitVerdict записывает вердикт ревьювера по открытому PR.
+// Ошибки: domain.ErrForbidden, domain.ErrNotFound, domain.ErrPRMerged/ErrPRClosed, domain.ErrNotAssigned.
+func (s *prService) SubmitVerdict(ctx context.Context, prID, reviewerID string, verdict domain.ReviewVerdict) (domain.PullRequest, error) {
+	if err := s.authorizeReviewer(ctx, reviewerID); err != nil {
+		return domain.PullRequest{}, err // domain.ErrForbidden
+	}
+
+	pr, err := s.prs.GetForUpdate(ctx, prID)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	if err := pr.CanBeReassigned(); err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	if err := s.prs.SetVerdict(ctx, prID, reviewerID, verdict); err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	return s.withReviewers(ctx, pr)
+}
+
+// GetLatencyStats возвращает перцентили времени до merge и до первого вердикта.
+func (s *prService) GetLatencyStats(ctx context.Context, f domain.LatencyFilter) ([]domain.LatencyStats, error) {
+	return s.prs.GetLatencyStats(ctx, f)
+}
+
+// GetFairnessReport считает равномерность нагрузки ревьюверов по командам.
+// Пустой teamName — по всем командам. Если команды нет — domain.ErrNotFound.
+func (s *prService) GetFairnessReport(ctx context.Context, teamName string) ([]domain.TeamFairness, error) {
+	loads, err := s.prs.ListReviewerLoads(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if teamName != "" && len(loads) == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	// loads отсортированы по команде — режем на подряд идущие куски
+	reports := make([]domain.TeamFairness, 0)
+	for start := 0; start < len(loads); {
+		end := start
+		for end < len(loads) && loads[end].TeamName == loads[start].TeamName {
+			end++
+		}
+		reports = append(reports, domain.NewTeamFairness(loads[start].TeamName, loads[start:end]))
+		start = end
+	}
+	return reports, nil
+}
+
+// GetReviewPairs строит матрицу «кто кого ревьюит» и topN самых частых пар.
+func (s *prService) GetReviewPairs(ctx context.Context, f domain.ReviewPairsFilter, topN int) (domain.PairMatrix, error) {
+	pairs, err := s.prs.ListReviewPairs(ctx, f)
+	if err != nil {
+		return domain.PairMatrix{}, err
+	}
+	return domain.NewPairMatrix(pairs, topN), nil
+}
+
+// ExportPullRequests построчно отдаёт PR под фильтр, не собирая их в память.
+func (s *prService) ExportPullRequests(ctx context.Context, f domain.ExportFilter, fn func(domain.PullRequest) error) error {
+	return s.prs.StreamPullRequests(ctx, f, fn)
+}
+
+// ExportAssignments построчно отдаёт назначения под фильтр.
+func (s *prService) ExportAssignments(ctx context.Context, f domain.ExportFilter, fn func(domain.Assignment) error) error {
+	return s.prs.StreamAssignments(ctx, f, fn)
+}
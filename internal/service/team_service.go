@@ -31,7 +31,7 @@ func (s *teamService) CreateTeam(ctx context.Context, team domain.Team) (domain.
 		return domain.Team{}, domain.ErrTeamExists
 	}
 
-	if err := s.teams.Create(ctx, team.Name); err != nil {
+	if err := s.teams.Create(ctx, team); err != nil {
 		return domain.Team{}, err
 	}
 
@@ -59,3 +59,9 @@ func (s *teamService) GetTeam(ctx context.Context, teamName string) (domain.Team
 	}
 	return team, nil
 }
+
+// SetChatWebhook задаёт incoming-webhook чата команды.
+// Если команды нет — domain.ErrNotFound.
+func (s *teamService) SetChatWebhook(ctx context.Context, teamName, url string) error {
+	return s.teams.SetChatWebhookURL(ctx, teamName, url)
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/repository"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+)
+
+// tokenPrefix помечает токены сервиса, чтобы их было легко найти сканерами секретов.
+const tokenPrefix = "prt_"
+
+type tokenService struct {
+	tokens repository.TokenRepository
+	users  repository.UserRepository
+	// хэш бутстрап-токена из AUTH_ADMIN_TOKEN; пусто — бутстрапа нет
+	bootstrapHash string
+}
+
+// NewTokenService создаёт сервис токенов API. bootstrapAdminToken принимается как admin-токен
+// без записи в БД — им выпускают первые токены; пустая строка его отключает.
+func NewTokenService(
+	tokens repository.TokenRepository,
+	users repository.UserRepository,
+	bootstrapAdminToken string,
+) app.TokenService {
+	s := &tokenService{tokens: tokens, users: users}
+	if bootstrapAdminToken != "" {
+		s.bootstrapHash = hashToken(bootstrapAdminToken)
+	}
+	return s
+}
+
+// hashToken SHA-256 без соли: токены случайные и длинные, перебор по словарю им не грозит,
+// а детерминированный хэш позволяет искать токен по индексу.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Issue выпускает токен. У user-токена пользователь должен существовать, иначе domain.ErrNotFound.
+func (s *tokenService) Issue(ctx context.Context, t domain.APIToken) (domain.APIToken, string, error) {
+	if t.Scope == domain.ScopeUser {
+		if _, err := s.users.GetByID(ctx, t.UserID); err != nil {
+			return domain.APIToken{}, "", err
+		}
+	} else {
+		t.UserID = ""
+	}
+
+	var b [32]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return domain.APIToken{}, "", err
+	}
+	secret := tokenPrefix + hex.EncodeToString(b[:])
+
+	created, err := s.tokens.Create(ctx, t, hashToken(secret))
+	if err != nil {
+		return domain.APIToken{}, "", err
+	}
+	return created, secret, nil
+}
+
+// List возвращает все выпущенные токены без секретов.
+func (s *tokenService) List(ctx context.Context) ([]domain.APIToken, error) {
+	return s.tokens.List(ctx)
+}
+
+// Revoke отзывает токен; следующий же запрос с ним получит 401.
+func (s *tokenService) Revoke(ctx context.Context, id int64) (domain.APIToken, error) {
+	return s.tokens.Revoke(ctx, id)
+}
+
+// Authenticate находит принципала по токену. Неизвестный или отозванный токен — domain.ErrUnauthorized.
+func (s *tokenService) Authenticate(ctx context.Context, token string) (domain.Principal, error) {
+	if token == "" {
+		return domain.Principal{}, domain.ErrUnauthorized
+	}
+
+	hash := hashToken(token)
+	if s.bootstrapHash != "" && subtle.ConstantTimeCompare([]byte(hash), []byte(s.bootstrapHash)) == 1 {
+		return domain.Principal{Scope: domain.ScopeAdmin}, nil
+	}
+
+	t, err := s.tokens.GetByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.Principal{}, domain.ErrUnauthorized
+		}
+		return domain.Principal{}, err
+	}
+	if t.RevokedAt != nil {
+		return domain.Principal{}, domain.ErrUnauthorized
+	}
+
+	return domain.Principal{TokenID: t.ID, Scope: t.Scope, UserID: t.UserID}, nil
+}
@@ -5,21 +5,27 @@ import (
 	"avi_internship_autumn/internal/domain"
 	"avi_internship_autumn/internal/repository"
 	"context"
+	"sort"
+	"time"
 )
 
 type userService struct {
-	users repository.UserRepository
-	prs   repository.PRRepository
+	users  repository.UserRepository
+	prs    repository.PRRepository
+	events app.EventPublisher
 }
 
 // NewUserService создаёт сервис для работы с пользователями и их PR.
+// В events уходят события о снятии/назначении ревьюверов при массовой деактивации.
 func NewUserService(
 	users repository.UserRepository,
 	prs repository.PRRepository,
+	events app.EventPublisher,
 ) app.UserService {
 	return &userService{
-		users: users,
-		prs:   prs,
+		users:  users,
+		prs:    prs,
+		events: events,
 	}
 }
 
@@ -33,6 +39,18 @@ func (s *userService) SetIsActive(ctx context.Context, userID string, isActive b
 	return u, nil
 }
 
+// SetChatHandle задаёт ник пользователя в чате для упоминаний.
+// Если user_id нет — возвращает domain.ErrNotFound.
+func (s *userService) SetChatHandle(ctx context.Context, userID, handle string) (domain.User, error) {
+	return s.users.UpdateChatHandle(ctx, userID, handle)
+}
+
+// SetEmailSettings задаёт адрес для уведомлений и отписки от них.
+// Пустой email выключает все письма. Если user_id нет — возвращает domain.ErrNotFound.
+func (s *userService) SetEmailSettings(ctx context.Context, userID, email string, optOut domain.EmailOptOut) (domain.User, error) {
+	return s.users.UpdateEmailSettings(ctx, userID, email, optOut)
+}
+
 // GetReviewPRs возвращает список PR, где пользователь назначен ревьювером.
 // Если юзера нет — domain.ErrNotFound.
 func (s *userService) GetReviewPRs(ctx context.Context, userID string) ([]domain.PullRequest, error) {
@@ -121,6 +139,8 @@ func (s *userService) BulkDeactivateTeam(ctx context.Context, teamName string, u
 		}
 
 		changed := false
+		var unassigned, assigned []string
+		replaced := make(map[string]string) // новый ревьювер -> кого заменил
 		reviewersSet := make(map[string]struct{}, len(current))
 		for _, id := range current {
 			reviewersSet[id] = struct{}{}
@@ -135,6 +155,7 @@ func (s *userService) BulkDeactivateTeam(ctx context.Context, teamName string, u
 				return result, err
 			}
 			delete(reviewersSet, rid)
+			unassigned = append(unassigned, rid)
 			changed = true
 
 			replacement := pickReplacementForPR(pr.AuthorID, reviewersSet, candidateIDs)
@@ -146,16 +167,56 @@ func (s *userService) BulkDeactivateTeam(ctx context.Context, teamName string, u
 				return result, err
 			}
 			reviewersSet[replacement] = struct{}{}
+			assigned = append(assigned, replacement)
+			replaced[replacement] = rid
 		}
 
 		if changed {
 			result.AffectedPRs++
+			s.publishReviewerChanges(ctx, teamName, pr, reviewersSet, unassigned, assigned, replaced)
 		}
 	}
 
 	return result, nil
 }
 
+// publishReviewerChanges отдаёт события по одному PR уже с итоговым составом ревьюверов.
+func (s *userService) publishReviewerChanges(
+	ctx context.Context,
+	teamName string,
+	pr domain.PullRequest,
+	reviewersSet map[string]struct{},
+	unassigned, assigned []string,
+	replaced map[string]string,
+) {
+	pr.AssignedReviewers = make([]string, 0, len(reviewersSet))
+	for id := range reviewersSet {
+		pr.AssignedReviewers = append(pr.AssignedReviewers, id)
+	}
+	sort.Strings(pr.AssignedReviewers)
+
+	now := time.Now()
+	for _, id := range unassigned {
+		s.events.Publish(ctx, domain.Event{
+			Type:        domain.EventReviewerUnassigned,
+			TeamName:    teamName,
+			PullRequest: pr,
+			ReviewerID:  id,
+			OccurredAt:  now,
+		})
+	}
+	for _, id := range assigned {
+		s.events.Publish(ctx, domain.Event{
+			Type:               domain.EventReviewerAssigned,
+			TeamName:           teamName,
+			PullRequest:        pr,
+			ReviewerID:         id,
+			ReplacedReviewerID: replaced[id],
+			OccurredAt:         now,
+		})
+	}
+}
+
 // pickReplacementForPR выбирает первого подходящего кандидата:
 // - не автор PR
 // - ещё не в списке ревьюверов
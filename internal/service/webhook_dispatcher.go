@@ -0,0 +1,149 @@
+package service
+
+import (
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/repository"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// SignatureHeader заголовок с HMAC-SHA256 подписью тела запроса.
+	SignatureHeader = "X-Signature"
+	// EventHeader заголовок с типом события.
+	EventHeader = "X-Webhook-Event"
+	// DeliveryHeader заголовок с id доставки (одинаковый при повторах).
+	DeliveryHeader = "X-Webhook-Delivery"
+)
+
+// RetryPolicy параметры повторных попыток доставки.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// Backoff возвращает паузу перед следующей попыткой после attempts неудачных:
+// BaseDelay * 2^(attempts-1), но не больше MaxDelay.
+func (p RetryPolicy) Backoff(attempts int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+	return d
+}
+
+// WebhookDispatcher забирает доставки из очереди и отправляет их подписчикам.
+type WebhookDispatcher struct {
+	hooks     repository.WebhookRepository
+	client    *http.Client
+	policy    RetryPolicy
+	batchSize int
+	lease     time.Duration
+	now       func() time.Time
+}
+
+// NewWebhookDispatcher создаёт диспетчер. client можно подменить в тестах (например, на httptest).
+func NewWebhookDispatcher(hooks repository.WebhookRepository, client *http.Client, policy RetryPolicy) *WebhookDispatcher {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &WebhookDispatcher{
+		hooks:     hooks,
+		client:    client,
+		policy:    policy,
+		batchSize: 50,
+		lease:     time.Minute,
+		now:       time.Now,
+	}
+}
+
+// Sign считает подпись тела в формате "sha256=<hex>".
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Run крутит цикл доставки с заданным интервалом, пока не отменён ctx.
+func (d *WebhookDispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.DeliverDue(ctx); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "webhooks: deliver due", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue отправляет одну пачку доставок, у которых подошло время.
+// Возвращает количество обработанных (успешно или нет) доставок.
+func (d *WebhookDispatcher) DeliverDue(ctx context.Context) (int, error) {
+	claimed, err := d.hooks.ClaimDueDeliveries(ctx, d.batchSize, d.lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, p := range claimed {
+		statusCode, sendErr := d.send(ctx, p)
+		if sendErr == nil {
+			if err := d.hooks.MarkDelivered(ctx, p.Delivery.ID, statusCode); err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		attempts := p.Delivery.Attempts + 1
+		dead := attempts >= d.policy.MaxAttempts
+		next := d.now().Add(d.policy.Backoff(attempts))
+		if err := d.hooks.MarkFailed(ctx, p.Delivery.ID, attempts, statusCode, sendErr.Error(), next, dead); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(claimed), nil
+}
+
+func (d *WebhookDispatcher) send(ctx context.Context, p domain.PendingDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL, bytes.NewReader(p.Delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(p.Secret, p.Delivery.Payload))
+	req.Header.Set(EventHeader, string(p.Delivery.EventType))
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(p.Delivery.ID, 10))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
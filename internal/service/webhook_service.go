@@ -0,0 +1,134 @@
+package service
+
+import (
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/repository"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+)
+
+type webhookService struct {
+	teams repository.TeamRepository
+	hooks repository.WebhookRepository
+}
+
+// NewWebhookService создаёт сервис подписок на вебхуки.
+func NewWebhookService(teams repository.TeamRepository, hooks repository.WebhookRepository) app.WebhookService {
+	return &webhookService{
+		teams: teams,
+		hooks: hooks,
+	}
+}
+
+// webhookPayload тело, которое уходит подписчику.
+type webhookPayload struct {
+	Event       domain.EventType   `json:"event"`
+	TeamName    string             `json:"team_name"`
+	OccurredAt  time.Time          `json:"occurred_at"`
+	PullRequest webhookPullRequest `json:"pull_request"`
+	ReviewerID  string             `json:"reviewer_id,omitempty"`
+	// кого заменил назначенный ревьювер (только для reviewer.assigned)
+	ReplacedReviewerID string `json:"replaced_reviewer_id,omitempty"`
+}
+
+type webhookPullRequest struct {
+	PullRequestID     string   `json:"pull_request_id"`
+	PullRequestName   string   `json:"pull_request_name"`
+	AuthorID          string   `json:"author_id"`
+	Status            string   `json:"status"`
+	AssignedReviewers []string `json:"assigned_reviewers"`
+}
+
+func buildWebhookPayload(ev domain.Event) ([]byte, error) {
+	reviewers := ev.PullRequest.AssignedReviewers
+	if reviewers == nil {
+		reviewers = []string{}
+	}
+
+	return json.Marshal(webhookPayload{
+		Event:      ev.Type,
+		TeamName:   ev.TeamName,
+		OccurredAt: ev.OccurredAt.UTC(),
+		PullRequest: webhookPullRequest{
+			PullRequestID:     ev.PullRequest.ID,
+			PullRequestName:   ev.PullRequest.Name,
+			AuthorID:          ev.PullRequest.AuthorID,
+			Status:            string(ev.PullRequest.Status),
+			AssignedReviewers: reviewers,
+		},
+		ReviewerID:         ev.ReviewerID,
+		ReplacedReviewerID: ev.ReplacedReviewerID,
+	})
+}
+
+// Publish ставит в очередь доставки события всем подходящим подпискам команды.
+// Ошибки только логируются: бизнес-операция уже выполнена и откатывать её из-за вебхука нельзя.
+func (s *webhookService) Publish(ctx context.Context, ev domain.Event) {
+	subs, err := s.hooks.ListActiveSubscriptions(ctx, ev.TeamName, ev.Type)
+	if err != nil {
+		slog.ErrorContext(ctx, "webhooks: list subscriptions", "team", ev.TeamName, "error", err)
+		return
+	}
+	if len(subs) == 0 {
+		return
+	}
+
+	payload, err := buildWebhookPayload(ev)
+	if err != nil {
+		slog.ErrorContext(ctx, "webhooks: build payload", "event", ev.Type, "error", err)
+		return
+	}
+
+	for _, sub := range subs {
+		if err := s.hooks.EnqueueDelivery(ctx, sub.ID, ev.Type, payload); err != nil {
+			slog.ErrorContext(ctx, "webhooks: enqueue delivery", "subscription_id", sub.ID, "error", err)
+		}
+	}
+}
+
+// Subscribe создаёт подписку команды. Пустой список событий означает «все события».
+// Если команды нет — domain.ErrNotFound.
+func (s *webhookService) Subscribe(ctx context.Context, sub domain.WebhookSubscription) (domain.WebhookSubscription, error) {
+	exists, err := s.teams.Exists(ctx, sub.TeamName)
+	if err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+	if !exists {
+		return domain.WebhookSubscription{}, domain.ErrNotFound
+	}
+
+	if len(sub.EventTypes) == 0 {
+		sub.EventTypes = domain.EventTypes
+	}
+
+	return s.hooks.CreateSubscription(ctx, sub)
+}
+
+// ListSubscriptions возвращает подписки команды.
+func (s *webhookService) ListSubscriptions(ctx context.Context, teamName string) ([]domain.WebhookSubscription, error) {
+	return s.hooks.ListSubscriptions(ctx, teamName)
+}
+
+// SetSubscriptionActive включает или выключает подписку; при выключении
+// её недоставленные события переходят в DEAD. Если подписки нет — domain.ErrNotFound.
+func (s *webhookService) SetSubscriptionActive(ctx context.Context, subscriptionID int64, active bool) (domain.WebhookSubscription, error) {
+	return s.hooks.SetSubscriptionActive(ctx, subscriptionID, active)
+}
+
+// RotateSecret задаёт подписке новый секрет подписи. Если подписки нет — domain.ErrNotFound.
+func (s *webhookService) RotateSecret(ctx context.Context, subscriptionID int64, secret string) (domain.WebhookSubscription, error) {
+	return s.hooks.RotateSecret(ctx, subscriptionID, secret)
+}
+
+// ListDeliveries возвращает последние доставки подписки.
+func (s *webhookService) ListDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]domain.WebhookDelivery, error) {
+	return s.hooks.ListDeliveries(ctx, subscriptionID, limit)
+}
+
+// ReplayDelivery повторно ставит доставку в очередь (в том числе из DEAD).
+func (s *webhookService) ReplayDelivery(ctx context.Context, deliveryID int64) (domain.WebhookDelivery, error) {
+	return s.hooks.ResetDelivery(ctx, deliveryID)
+}
@@ -0,0 +1,396 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/repository"
+)
+
+// InstrumentRepositories оборачивает каждый репозиторий: на каждый метод — клиентский спан pg.<Repo>.<Method>.
+func InstrumentRepositories(r *app.Repositories) *app.Repositories {
+	return &app.Repositories{
+		Teams:       &teamRepository{next: r.Teams},
+		Users:       &userRepository{next: r.Users},
+		PRs:         &prRepository{next: r.PRs},
+		Webhooks:    &webhookRepository{next: r.Webhooks},
+		Identities:  &identityRepository{next: r.Identities},
+		Schema:      &schemaRepository{next: r.Schema},
+		Tokens:      &tokenRepository{next: r.Tokens},
+		Idempotency: &idempotencyRepository{next: r.Idempotency},
+	}
+}
+
+type teamRepository struct {
+	next repository.TeamRepository
+}
+
+func (r *teamRepository) Create(ctx context.Context, team domain.Team) (err error) {
+	ctx, span := startDB(ctx, "TeamRepository.Create", teamAttr(team.Name))
+	defer func() { finish(span, err) }()
+	return r.next.Create(ctx, team)
+}
+
+func (r *teamRepository) Exists(ctx context.Context, teamName string) (_ bool, err error) {
+	ctx, span := startDB(ctx, "TeamRepository.Exists", teamAttr(teamName))
+	defer func() { finish(span, err) }()
+	return r.next.Exists(ctx, teamName)
+}
+
+func (r *teamRepository) Get(ctx context.Context, teamName string) (_ domain.Team, err error) {
+	ctx, span := startDB(ctx, "TeamRepository.Get", teamAttr(teamName))
+	defer func() { finish(span, err) }()
+	return r.next.Get(ctx, teamName)
+}
+
+func (r *teamRepository) SetChatWebhookURL(ctx context.Context, teamName string, url string) (err error) {
+	ctx, span := startDB(ctx, "TeamRepository.SetChatWebhookURL", teamAttr(teamName))
+	defer func() { finish(span, err) }()
+	return r.next.SetChatWebhookURL(ctx, teamName, url)
+}
+
+type userRepository struct {
+	next repository.UserRepository
+}
+
+func (r *userRepository) Upsert(ctx context.Context, u domain.User) (err error) {
+	ctx, span := startDB(ctx, "UserRepository.Upsert", userAttr(u.ID), teamAttr(u.TeamName))
+	defer func() { finish(span, err) }()
+	return r.next.Upsert(ctx, u)
+}
+
+func (r *userRepository) GetByID(ctx context.Context, id string) (_ domain.User, err error) {
+	ctx, span := startDB(ctx, "UserRepository.GetByID", userAttr(id))
+	defer func() { finish(span, err) }()
+	return r.next.GetByID(ctx, id)
+}
+
+func (r *userRepository) ListByTeam(ctx context.Context, teamName string) (_ []domain.User, err error) {
+	ctx, span := startDB(ctx, "UserRepository.ListByTeam", teamAttr(teamName))
+	defer func() { finish(span, err) }()
+	return r.next.ListByTeam(ctx, teamName)
+}
+
+func (r *userRepository) UpdateIsActive(ctx context.Context, id string, isActive bool) (_ domain.User, err error) {
+	ctx, span := startDB(ctx, "UserRepository.UpdateIsActive", userAttr(id))
+	defer func() { finish(span, err) }()
+	return r.next.UpdateIsActive(ctx, id, isActive)
+}
+
+func (r *userRepository) UpdateChatHandle(ctx context.Context, id string, handle string) (_ domain.User, err error) {
+	ctx, span := startDB(ctx, "UserRepository.UpdateChatHandle", userAttr(id))
+	defer func() { finish(span, err) }()
+	return r.next.UpdateChatHandle(ctx, id, handle)
+}
+
+func (r *userRepository) UpdateEmailSettings(ctx context.Context, id string, email string, optOut domain.EmailOptOut) (_ domain.User, err error) {
+	ctx, span := startDB(ctx, "UserRepository.UpdateEmailSettings", userAttr(id))
+	defer func() { finish(span, err) }()
+	return r.next.UpdateEmailSettings(ctx, id, email, optOut)
+}
+
+func (r *userRepository) ListDigestRecipients(ctx context.Context) (_ []domain.User, err error) {
+	ctx, span := startDB(ctx, "UserRepository.ListDigestRecipients")
+	defer func() { finish(span, err) }()
+	return r.next.ListDigestRecipients(ctx)
+}
+
+func (r *userRepository) BulkDeactivateInTeam(ctx context.Context, teamName string, userIDs []string) (_ int64, err error) {
+	ctx, span := startDB(ctx, "UserRepository.BulkDeactivateInTeam", teamAttr(teamName), usersAttr(userIDs))
+	defer func() { finish(span, err) }()
+	return r.next.BulkDeactivateInTeam(ctx, teamName, userIDs)
+}
+
+type prRepository struct {
+	next repository.PRRepository
+}
+
+func (r *prRepository) Exists(ctx context.Context, id string) (_ bool, err error) {
+	ctx, span := startDB(ctx, "PRRepository.Exists", prAttr(id))
+	defer func() { finish(span, err) }()
+	return r.next.Exists(ctx, id)
+}
+
+func (r *prRepository) Create(ctx context.Context, pr domain.PullRequest) (err error) {
+	ctx, span := startDB(ctx, "PRRepository.Create", prAttr(pr.ID), userAttr(pr.AuthorID))
+	defer func() { finish(span, err) }()
+	return r.next.Create(ctx, pr)
+}
+
+func (r *prRepository) GetForUpdate(ctx context.Context, id string) (_ domain.PullRequest, err error) {
+	ctx, span := startDB(ctx, "PRRepository.GetForUpdate", prAttr(id))
+	defer func() { finish(span, err) }()
+	return r.next.GetForUpdate(ctx, id)
+}
+
+func (r *prRepository) UpdateStatusMerged(ctx context.Context, id string) (err error) {
+	ctx, span := startDB(ctx, "PRRepository.UpdateStatusMerged", prAttr(id))
+	defer func() { finish(span, err) }()
+	return r.next.UpdateStatusMerged(ctx, id)
+}
+
+func (r *prRepository) UpdateStatusClosed(ctx context.Context, id string) (err error) {
+	ctx, span := startDB(ctx, "PRRepository.UpdateStatusClosed", prAttr(id))
+	defer func() { finish(span, err) }()
+	return r.next.UpdateStatusClosed(ctx, id)
+}
+
+func (r *prRepository) UpdateStatusReopened(ctx context.Context, id string) (err error) {
+	ctx, span := startDB(ctx, "PRRepository.UpdateStatusReopened", prAttr(id))
+	defer func() { finish(span, err) }()
+	return r.next.UpdateStatusReopened(ctx, id)
+}
+
+func (r *prRepository) ListReviewerPRs(ctx context.Context, reviewerID string) (_ []domain.PullRequest, err error) {
+	ctx, span := startDB(ctx, "PRRepository.ListReviewerPRs", reviewerAttr(reviewerID))
+	defer func() { finish(span, err) }()
+	return r.next.ListReviewerPRs(ctx, reviewerID)
+}
+
+func (r *prRepository) GetReviewers(ctx context.Context, prID string) (_ []string, err error) {
+	ctx, span := startDB(ctx, "PRRepository.GetReviewers", prAttr(prID))
+	defer func() { finish(span, err) }()
+	return r.next.GetReviewers(ctx, prID)
+}
+
+func (r *prRepository) AddReviewer(ctx context.Context, prID string, reviewerID string) (err error) {
+	ctx, span := startDB(ctx, "PRRepository.AddReviewer", prAttr(prID), reviewerAttr(reviewerID))
+	defer func() { finish(span, err) }()
+	return r.next.AddReviewer(ctx, prID, reviewerID)
+}
+
+func (r *prRepository) RemoveReviewer(ctx context.Context, prID string, reviewerID string) (err error) {
+	ctx, span := startDB(ctx, "PRRepository.RemoveReviewer", prAttr(prID), reviewerAttr(reviewerID))
+	defer func() { finish(span, err) }()
+	return r.next.RemoveReviewer(ctx, prID, reviewerID)
+}
+
+func (r *prRepository) SetVerdict(ctx context.Context, prID string, reviewerID string, verdict domain.ReviewVerdict) (err error) {
+	ctx, span := startDB(ctx, "PRRepository.SetVerdict", prAttr(prID), reviewerAttr(reviewerID))
+	defer func() { finish(span, err) }()
+	return r.next.SetVerdict(ctx, prID, reviewerID, verdict)
+}
+
+func (r *prRepository) GetAssignmentStatsByReviewer(ctx context.Context, f domain.AssignmentStatsFilter) (_ []domain.AssignmentStats, err error) {
+	ctx, span := startDB(ctx, "PRRepository.GetAssignmentStatsByReviewer", teamAttr(f.TeamName))
+	defer func() { finish(span, err) }()
+	return r.next.GetAssignmentStatsByReviewer(ctx, f)
+}
+
+func (r *prRepository) GetAssignmentStatsByPR(ctx context.Context, f domain.AssignmentStatsFilter) (_ []domain.PullRequestAssignmentStats, err error) {
+	ctx, span := startDB(ctx, "PRRepository.GetAssignmentStatsByPR", teamAttr(f.TeamName))
+	defer func() { finish(span, err) }()
+	return r.next.GetAssignmentStatsByPR(ctx, f)
+}
+
+func (r *prRepository) ListOpenPRsByReviewers(ctx context.Context, reviewerIDs []string) (_ []domain.PullRequest, err error) {
+	ctx, span := startDB(ctx, "PRRepository.ListOpenPRsByReviewers", reviewersAttr(reviewerIDs))
+	defer func() { finish(span, err) }()
+	return r.next.ListOpenPRsByReviewers(ctx, reviewerIDs)
+}
+
+func (r *prRepository) GetLatencyStats(ctx context.Context, f domain.LatencyFilter) (_ []domain.LatencyStats, err error) {
+	ctx, span := startDB(ctx, "PRRepository.GetLatencyStats", teamAttr(f.TeamName))
+	defer func() { finish(span, err) }()
+	return r.next.GetLatencyStats(ctx, f)
+}
+
+func (r *prRepository) ListReviewerLoads(ctx context.Context, teamName string) (_ []domain.ReviewerLoad, err error) {
+	ctx, span := startDB(ctx, "PRRepository.ListReviewerLoads", teamAttr(teamName))
+	defer func() { finish(span, err) }()
+	return r.next.ListReviewerLoads(ctx, teamName)
+}
+
+func (r *prRepository) CountOpenByTeam(ctx context.Context) (_ map[string]int64, err error) {
+	ctx, span := startDB(ctx, "PRRepository.CountOpenByTeam")
+	defer func() { finish(span, err) }()
+	return r.next.CountOpenByTeam(ctx)
+}
+
+func (r *prRepository) ListReviewPairs(ctx context.Context, f domain.ReviewPairsFilter) (_ []domain.ReviewPair, err error) {
+	ctx, span := startDB(ctx, "PRRepository.ListReviewPairs", teamAttr(f.TeamName))
+	defer func() { finish(span, err) }()
+	return r.next.ListReviewPairs(ctx, f)
+}
+
+func (r *prRepository) StreamPullRequests(ctx context.Context, f domain.ExportFilter, fn func(domain.PullRequest) error) (err error) {
+	ctx, span := startDB(ctx, "PRRepository.StreamPullRequests", teamAttr(f.TeamName))
+	defer func() { finish(span, err) }()
+	return r.next.StreamPullRequests(ctx, f, fn)
+}
+
+func (r *prRepository) StreamAssignments(ctx context.Context, f domain.ExportFilter, fn func(domain.Assignment) error) (err error) {
+	ctx, span := startDB(ctx, "PRRepository.StreamAssignments", teamAttr(f.TeamName))
+	defer func() { finish(span, err) }()
+	return r.next.StreamAssignments(ctx, f, fn)
+}
+
+type webhookRepository struct {
+	next repository.WebhookRepository
+}
+
+func (r *webhookRepository) CreateSubscription(ctx context.Context, s domain.WebhookSubscription) (_ domain.WebhookSubscription, err error) {
+	ctx, span := startDB(ctx, "WebhookRepository.CreateSubscription", teamAttr(s.TeamName))
+	defer func() { finish(span, err) }()
+	return r.next.CreateSubscription(ctx, s)
+}
+
+func (r *webhookRepository) ListSubscriptions(ctx context.Context, teamName string) (_ []domain.WebhookSubscription, err error) {
+	ctx, span := startDB(ctx, "WebhookRepository.ListSubscriptions", teamAttr(teamName))
+	defer func() { finish(span, err) }()
+	return r.next.ListSubscriptions(ctx, teamName)
+}
+
+func (r *webhookRepository) ListActiveSubscriptions(ctx context.Context, teamName string, eventType domain.EventType) (_ []domain.WebhookSubscription, err error) {
+	ctx, span := startDB(ctx, "WebhookRepository.ListActiveSubscriptions", teamAttr(teamName))
+	defer func() { finish(span, err) }()
+	return r.next.ListActiveSubscriptions(ctx, teamName, eventType)
+}
+
+func (r *webhookRepository) SetSubscriptionActive(ctx context.Context, id int64, active bool) (_ domain.WebhookSubscription, err error) {
+	ctx, span := startDB(ctx, "WebhookRepository.SetSubscriptionActive")
+	defer func() { finish(span, err) }()
+	return r.next.SetSubscriptionActive(ctx, id, active)
+}
+
+func (r *webhookRepository) RotateSecret(ctx context.Context, id int64, secret string) (_ domain.WebhookSubscription, err error) {
+	ctx, span := startDB(ctx, "WebhookRepository.RotateSecret")
+	defer func() { finish(span, err) }()
+	return r.next.RotateSecret(ctx, id, secret)
+}
+
+func (r *webhookRepository) EnqueueDelivery(ctx context.Context, subscriptionID int64, eventType domain.EventType, payload []byte) (err error) {
+	ctx, span := startDB(ctx, "WebhookRepository.EnqueueDelivery")
+	defer func() { finish(span, err) }()
+	return r.next.EnqueueDelivery(ctx, subscriptionID, eventType, payload)
+}
+
+func (r *webhookRepository) ListDeliveries(ctx context.Context, subscriptionID int64, limit int) (_ []domain.WebhookDelivery, err error) {
+	ctx, span := startDB(ctx, "WebhookRepository.ListDeliveries")
+	defer func() { finish(span, err) }()
+	return r.next.ListDeliveries(ctx, subscriptionID, limit)
+}
+
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) (_ []domain.PendingDelivery, err error) {
+	ctx, span := startDB(ctx, "WebhookRepository.ClaimDueDeliveries")
+	defer func() { finish(span, err) }()
+	return r.next.ClaimDueDeliveries(ctx, limit, lease)
+}
+
+func (r *webhookRepository) MarkDelivered(ctx context.Context, id int64, statusCode int) (err error) {
+	ctx, span := startDB(ctx, "WebhookRepository.MarkDelivered")
+	defer func() { finish(span, err) }()
+	return r.next.MarkDelivered(ctx, id, statusCode)
+}
+
+func (r *webhookRepository) MarkFailed(ctx context.Context, id int64, attempts int, statusCode int, lastErr string, nextAttemptAt time.Time, dead bool) (err error) {
+	ctx, span := startDB(ctx, "WebhookRepository.MarkFailed")
+	defer func() { finish(span, err) }()
+	return r.next.MarkFailed(ctx, id, attempts, statusCode, lastErr, nextAttemptAt, dead)
+}
+
+func (r *webhookRepository) ResetDelivery(ctx context.Context, id int64) (_ domain.WebhookDelivery, err error) {
+	ctx, span := startDB(ctx, "WebhookRepository.ResetDelivery")
+	defer func() { finish(span, err) }()
+	return r.next.ResetDelivery(ctx, id)
+}
+
+type identityRepository struct {
+	next repository.IdentityRepository
+}
+
+func (r *identityRepository) Upsert(ctx context.Context, id domain.Identity) (err error) {
+	ctx, span := startDB(ctx, "IdentityRepository.Upsert", userAttr(id.UserID))
+	defer func() { finish(span, err) }()
+	return r.next.Upsert(ctx, id)
+}
+
+func (r *identityRepository) ResolveUserID(ctx context.Context, provider domain.Provider, login string) (_ string, err error) {
+	ctx, span := startDB(ctx, "IdentityRepository.ResolveUserID")
+	defer func() { finish(span, err) }()
+	return r.next.ResolveUserID(ctx, provider, login)
+}
+
+func (r *identityRepository) ResolveUserIDByExternalID(ctx context.Context, provider domain.Provider, externalID string) (_ string, err error) {
+	ctx, span := startDB(ctx, "IdentityRepository.ResolveUserIDByExternalID")
+	defer func() { finish(span, err) }()
+	return r.next.ResolveUserIDByExternalID(ctx, provider, externalID)
+}
+
+type schemaRepository struct {
+	next repository.SchemaRepository
+}
+
+func (r *schemaRepository) Ping(ctx context.Context) (err error) {
+	ctx, span := startDB(ctx, "SchemaRepository.Ping")
+	defer func() { finish(span, err) }()
+	return r.next.Ping(ctx)
+}
+
+func (r *schemaRepository) SchemaVersion(ctx context.Context) (_ int, err error) {
+	ctx, span := startDB(ctx, "SchemaRepository.SchemaVersion")
+	defer func() { finish(span, err) }()
+	return r.next.SchemaVersion(ctx)
+}
+
+type tokenRepository struct {
+	next repository.TokenRepository
+}
+
+func (r *tokenRepository) Create(ctx context.Context, t domain.APIToken, hash string) (_ domain.APIToken, err error) {
+	ctx, span := startDB(ctx, "TokenRepository.Create", userAttr(t.UserID))
+	defer func() { finish(span, err) }()
+	return r.next.Create(ctx, t, hash)
+}
+
+func (r *tokenRepository) GetByHash(ctx context.Context, hash string) (_ domain.APIToken, err error) {
+	ctx, span := startDB(ctx, "TokenRepository.GetByHash")
+	defer func() { finish(span, err) }()
+	return r.next.GetByHash(ctx, hash)
+}
+
+func (r *tokenRepository) List(ctx context.Context) (_ []domain.APIToken, err error) {
+	ctx, span := startDB(ctx, "TokenRepository.List")
+	defer func() { finish(span, err) }()
+	return r.next.List(ctx)
+}
+
+func (r *tokenRepository) Revoke(ctx context.Context, id int64) (_ domain.APIToken, err error) {
+	ctx, span := startDB(ctx, "TokenRepository.Revoke", tokenAttr(id))
+	defer func() { finish(span, err) }()
+	return r.next.Revoke(ctx, id)
+}
+
+type idempotencyRepository struct {
+	next repository.IdempotencyRepository
+}
+
+func (r *idempotencyRepository) Reserve(
+	ctx context.Context,
+	scope, key, requestHash string,
+	ttl, lockTimeout time.Duration,
+) (_ domain.IdempotencyRecord, _ bool, err error) {
+	ctx, span := startDB(ctx, "IdempotencyRepository.Reserve", idempotencyScopeAttr(scope))
+	defer func() { finish(span, err) }()
+	return r.next.Reserve(ctx, scope, key, requestHash, ttl, lockTimeout)
+}
+
+func (r *idempotencyRepository) Complete(ctx context.Context, scope, key string, resp domain.IdempotentResponse) (err error) {
+	ctx, span := startDB(ctx, "IdempotencyRepository.Complete", idempotencyScopeAttr(scope))
+	defer func() { finish(span, err) }()
+	return r.next.Complete(ctx, scope, key, resp)
+}
+
+func (r *idempotencyRepository) Release(ctx context.Context, scope, key string) (err error) {
+	ctx, span := startDB(ctx, "IdempotencyRepository.Release", idempotencyScopeAttr(scope))
+	defer func() { finish(span, err) }()
+	return r.next.Release(ctx, scope, key)
+}
+
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context) (_ int64, err error) {
+	ctx, span := startDB(ctx, "IdempotencyRepository.DeleteExpired")
+	defer func() { finish(span, err) }()
+	return r.next.DeleteExpired(ctx)
+}
@@ -0,0 +1,304 @@
+package tracing
+
+import (
+	"context"
+
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/domain"
+)
+
+// InstrumentTeamService оборачивает TeamService спанами.
+func InstrumentTeamService(svc app.TeamService) app.TeamService {
+	return &teamService{next: svc}
+}
+
+// InstrumentUserService оборачивает UserService спанами.
+func InstrumentUserService(svc app.UserService) app.UserService {
+	return &userService{next: svc}
+}
+
+// InstrumentPRService оборачивает PRService спанами.
+func InstrumentPRService(svc app.PRService) app.PRService {
+	return &prService{next: svc}
+}
+
+// InstrumentWebhookService оборачивает WebhookService спанами.
+// Publish не оборачивается: это постановка в очередь внутри уже открытого спана сервиса.
+func InstrumentWebhookService(svc app.WebhookService) app.WebhookService {
+	return &webhookService{next: svc}
+}
+
+// InstrumentIntegrationService оборачивает IntegrationService спанами.
+func InstrumentIntegrationService(svc app.IntegrationService) app.IntegrationService {
+	return &integrationService{next: svc}
+}
+
+// InstrumentTokenService оборачивает TokenService спанами.
+func InstrumentTokenService(svc app.TokenService) app.TokenService {
+	return &tokenService{next: svc}
+}
+
+// InstrumentIdempotencyService оборачивает IdempotencyService спанами.
+func InstrumentIdempotencyService(svc app.IdempotencyService) app.IdempotencyService {
+	return &idempotencyService{next: svc}
+}
+
+type teamService struct {
+	next app.TeamService
+}
+
+func (s *teamService) CreateTeam(ctx context.Context, team domain.Team) (_ domain.Team, err error) {
+	ctx, span := startService(ctx, "TeamService.CreateTeam", teamAttr(team.Name))
+	defer func() { finish(span, err) }()
+	return s.next.CreateTeam(ctx, team)
+}
+
+func (s *teamService) GetTeam(ctx context.Context, teamName string) (_ domain.Team, err error) {
+	ctx, span := startService(ctx, "TeamService.GetTeam", teamAttr(teamName))
+	defer func() { finish(span, err) }()
+	return s.next.GetTeam(ctx, teamName)
+}
+
+func (s *teamService) SetChatWebhook(ctx context.Context, teamName string, url string) (err error) {
+	ctx, span := startService(ctx, "TeamService.SetChatWebhook", teamAttr(teamName))
+	defer func() { finish(span, err) }()
+	return s.next.SetChatWebhook(ctx, teamName, url)
+}
+
+type userService struct {
+	next app.UserService
+}
+
+func (s *userService) SetIsActive(ctx context.Context, userID string, isActive bool) (_ domain.User, err error) {
+	ctx, span := startService(ctx, "UserService.SetIsActive", userAttr(userID))
+	defer func() { finish(span, err) }()
+	return s.next.SetIsActive(ctx, userID, isActive)
+}
+
+func (s *userService) SetChatHandle(ctx context.Context, userID string, handle string) (_ domain.User, err error) {
+	ctx, span := startService(ctx, "UserService.SetChatHandle", userAttr(userID))
+	defer func() { finish(span, err) }()
+	return s.next.SetChatHandle(ctx, userID, handle)
+}
+
+func (s *userService) SetEmailSettings(ctx context.Context, userID string, email string, optOut domain.EmailOptOut) (_ domain.User, err error) {
+	ctx, span := startService(ctx, "UserService.SetEmailSettings", userAttr(userID))
+	defer func() { finish(span, err) }()
+	return s.next.SetEmailSettings(ctx, userID, email, optOut)
+}
+
+func (s *userService) GetReviewPRs(ctx context.Context, userID string) (_ []domain.PullRequest, err error) {
+	ctx, span := startService(ctx, "UserService.GetReviewPRs", userAttr(userID))
+	defer func() { finish(span, err) }()
+	return s.next.GetReviewPRs(ctx, userID)
+}
+
+func (s *userService) BulkDeactivateTeam(ctx context.Context, teamName string, userIDs []string) (_ domain.BulkDeactivateResult, err error) {
+	ctx, span := startService(ctx, "UserService.BulkDeactivateTeam", teamAttr(teamName), usersAttr(userIDs))
+	defer func() { finish(span, err) }()
+	return s.next.BulkDeactivateTeam(ctx, teamName, userIDs)
+}
+
+type prService struct {
+	next app.PRService
+}
+
+func (s *prService) CreatePR(ctx context.Context, id string, name string, authorID string) (_ domain.PullRequest, err error) {
+	ctx, span := startService(ctx, "PRService.CreatePR", prAttr(id), userAttr(authorID))
+	defer func() { finish(span, err) }()
+	return s.next.CreatePR(ctx, id, name, authorID)
+}
+
+func (s *prService) MergePR(ctx context.Context, id string) (_ domain.PullRequest, err error) {
+	ctx, span := startService(ctx, "PRService.MergePR", prAttr(id))
+	defer func() { finish(span, err) }()
+	return s.next.MergePR(ctx, id)
+}
+
+func (s *prService) ReassignReviewer(ctx context.Context, prID string, oldReviewerID string) (_ domain.PullRequest, _ string, err error) {
+	ctx, span := startService(ctx, "PRService.ReassignReviewer", prAttr(prID), reviewerAttr(oldReviewerID))
+	defer func() { finish(span, err) }()
+	return s.next.ReassignReviewer(ctx, prID, oldReviewerID)
+}
+
+func (s *prService) ClosePR(ctx context.Context, id string) (_ domain.PullRequest, err error) {
+	ctx, span := startService(ctx, "PRService.ClosePR", prAttr(id))
+	defer func() { finish(span, err) }()
+	return s.next.ClosePR(ctx, id)
+}
+
+func (s *prService) ReopenPR(ctx context.Context, id string) (_ domain.PullRequest, err error) {
+	ctx, span := startService(ctx, "PRService.ReopenPR", prAttr(id))
+	defer func() { finish(span, err) }()
+	return s.next.ReopenPR(ctx, id)
+}
+
+func (s *prService) SetReviewers(ctx context.Context, prID string, reviewerIDs []string) (_ domain.PullRequest, err error) {
+	ctx, span := startService(ctx, "PRService.SetReviewers", prAttr(prID), reviewersAttr(reviewerIDs))
+	defer func() { finish(span, err) }()
+	return s.next.SetReviewers(ctx, prID, reviewerIDs)
+}
+
+func (s *prService) SubmitVerdict(ctx context.Context, prID string, reviewerID string, verdict domain.ReviewVerdict) (_ domain.PullRequest, err error) {
+	ctx, span := startService(ctx, "PRService.SubmitVerdict", prAttr(prID), reviewerAttr(reviewerID))
+	defer func() { finish(span, err) }()
+	return s.next.SubmitVerdict(ctx, prID, reviewerID, verdict)
+}
+
+func (s *prService) GetAssignmentStatsByReviewer(ctx context.Context, f domain.AssignmentStatsFilter) (_ []domain.AssignmentStats, err error) {
+	ctx, span := startService(ctx, "PRService.GetAssignmentStatsByReviewer", teamAttr(f.TeamName))
+	defer func() { finish(span, err) }()
+	return s.next.GetAssignmentStatsByReviewer(ctx, f)
+}
+
+func (s *prService) GetAssignmentStatsByPR(ctx context.Context, f domain.AssignmentStatsFilter) (_ []domain.PullRequestAssignmentStats, err error) {
+	ctx, span := startService(ctx, "PRService.GetAssignmentStatsByPR", teamAttr(f.TeamName))
+	defer func() { finish(span, err) }()
+	return s.next.GetAssignmentStatsByPR(ctx, f)
+}
+
+func (s *prService) GetLatencyStats(ctx context.Context, f domain.LatencyFilter) (_ []domain.LatencyStats, err error) {
+	ctx, span := startService(ctx, "PRService.GetLatencyStats", teamAttr(f.TeamName))
+	defer func() { finish(span, err) }()
+	return s.next.GetLatencyStats(ctx, f)
+}
+
+func (s *prService) GetFairnessReport(ctx context.Context, teamName string) (_ []domain.TeamFairness, err error) {
+	ctx, span := startService(ctx, "PRService.GetFairnessReport", teamAttr(teamName))
+	defer func() { finish(span, err) }()
+	return s.next.GetFairnessReport(ctx, teamName)
+}
+
+func (s *prService) GetReviewPairs(ctx context.Context, f domain.ReviewPairsFilter, topN int) (_ domain.PairMatrix, err error) {
+	ctx, span := startService(ctx, "PRService.GetReviewPairs", teamAttr(f.TeamName))
+	defer func() { finish(span, err) }()
+	return s.next.GetReviewPairs(ctx, f, topN)
+}
+
+func (s *prService) ExportPullRequests(ctx context.Context, f domain.ExportFilter, fn func(domain.PullRequest) error) (err error) {
+	ctx, span := startService(ctx, "PRService.ExportPullRequests", teamAttr(f.TeamName))
+	defer func() { finish(span, err) }()
+	return s.next.ExportPullRequests(ctx, f, fn)
+}
+
+func (s *prService) ExportAssignments(ctx context.Context, f domain.ExportFilter, fn func(domain.Assignment) error) (err error) {
+	ctx, span := startService(ctx, "PRService.ExportAssignments", teamAttr(f.TeamName))
+	defer func() { finish(span, err) }()
+	return s.next.ExportAssignments(ctx, f, fn)
+}
+
+type webhookService struct {
+	next app.WebhookService
+}
+
+func (s *webhookService) Publish(ctx context.Context, ev domain.Event) {
+	s.next.Publish(ctx, ev)
+}
+
+func (s *webhookService) Subscribe(ctx context.Context, sub domain.WebhookSubscription) (_ domain.WebhookSubscription, err error) {
+	ctx, span := startService(ctx, "WebhookService.Subscribe", teamAttr(sub.TeamName))
+	defer func() { finish(span, err) }()
+	return s.next.Subscribe(ctx, sub)
+}
+
+func (s *webhookService) ListSubscriptions(ctx context.Context, teamName string) (_ []domain.WebhookSubscription, err error) {
+	ctx, span := startService(ctx, "WebhookService.ListSubscriptions", teamAttr(teamName))
+	defer func() { finish(span, err) }()
+	return s.next.ListSubscriptions(ctx, teamName)
+}
+
+func (s *webhookService) SetSubscriptionActive(ctx context.Context, subscriptionID int64, active bool) (_ domain.WebhookSubscription, err error) {
+	ctx, span := startService(ctx, "WebhookService.SetSubscriptionActive")
+	defer func() { finish(span, err) }()
+	return s.next.SetSubscriptionActive(ctx, subscriptionID, active)
+}
+
+func (s *webhookService) RotateSecret(ctx context.Context, subscriptionID int64, secret string) (_ domain.WebhookSubscription, err error) {
+	ctx, span := startService(ctx, "WebhookService.RotateSecret")
+	defer func() { finish(span, err) }()
+	return s.next.RotateSecret(ctx, subscriptionID, secret)
+}
+
+func (s *webhookService) ListDeliveries(ctx context.Context, subscriptionID int64, limit int) (_ []domain.WebhookDelivery, err error) {
+	ctx, span := startService(ctx, "WebhookService.ListDeliveries")
+	defer func() { finish(span, err) }()
+	return s.next.ListDeliveries(ctx, subscriptionID, limit)
+}
+
+func (s *webhookService) ReplayDelivery(ctx context.Context, deliveryID int64) (_ domain.WebhookDelivery, err error) {
+	ctx, span := startService(ctx, "WebhookService.ReplayDelivery")
+	defer func() { finish(span, err) }()
+	return s.next.ReplayDelivery(ctx, deliveryID)
+}
+
+type integrationService struct {
+	next app.IntegrationService
+}
+
+func (s *integrationService) LinkIdentity(ctx context.Context, id domain.Identity) (err error) {
+	ctx, span := startService(ctx, "IntegrationService.LinkIdentity", userAttr(id.UserID))
+	defer func() { finish(span, err) }()
+	return s.next.LinkIdentity(ctx, id)
+}
+
+func (s *integrationService) HandlePREvent(ctx context.Context, ev domain.ExternalPREvent) (_ domain.IntegrationResult, err error) {
+	ctx, span := startService(ctx, "IntegrationService.HandlePREvent", prAttr(ev.PullRequest))
+	defer func() { finish(span, err) }()
+	return s.next.HandlePREvent(ctx, ev)
+}
+
+type tokenService struct {
+	next app.TokenService
+}
+
+func (s *tokenService) Issue(ctx context.Context, t domain.APIToken) (_ domain.APIToken, _ string, err error) {
+	ctx, span := startService(ctx, "TokenService.Issue", userAttr(t.UserID))
+	defer func() { finish(span, err) }()
+	return s.next.Issue(ctx, t)
+}
+
+func (s *tokenService) List(ctx context.Context) (_ []domain.APIToken, err error) {
+	ctx, span := startService(ctx, "TokenService.List")
+	defer func() { finish(span, err) }()
+	return s.next.List(ctx)
+}
+
+func (s *tokenService) Revoke(ctx context.Context, id int64) (_ domain.APIToken, err error) {
+	ctx, span := startService(ctx, "TokenService.Revoke", tokenAttr(id))
+	defer func() { finish(span, err) }()
+	return s.next.Revoke(ctx, id)
+}
+
+func (s *tokenService) Authenticate(ctx context.Context, token string) (_ domain.Principal, err error) {
+	ctx, span := startService(ctx, "TokenService.Authenticate")
+	defer func() { finish(span, err) }()
+	return s.next.Authenticate(ctx, token)
+}
+
+type idempotencyService struct {
+	next app.IdempotencyService
+}
+
+func (s *idempotencyService) Begin(ctx context.Context, scope, key, requestHash string) (_ domain.IdempotentResponse, _ bool, err error) {
+	ctx, span := startService(ctx, "IdempotencyService.Begin", idempotencyScopeAttr(scope))
+	defer func() { finish(span, err) }()
+	return s.next.Begin(ctx, scope, key, requestHash)
+}
+
+func (s *idempotencyService) Complete(ctx context.Context, scope, key string, resp domain.IdempotentResponse) (err error) {
+	ctx, span := startService(ctx, "IdempotencyService.Complete", idempotencyScopeAttr(scope))
+	defer func() { finish(span, err) }()
+	return s.next.Complete(ctx, scope, key, resp)
+}
+
+func (s *idempotencyService) Release(ctx context.Context, scope, key string) (err error) {
+	ctx, span := startService(ctx, "IdempotencyService.Release", idempotencyScopeAttr(scope))
+	defer func() { finish(span, err) }()
+	return s.next.Release(ctx, scope, key)
+}
+
+func (s *idempotencyService) PurgeExpired(ctx context.Context) (_ int64, err error) {
+	ctx, span := startService(ctx, "IdempotencyService.PurgeExpired")
+	defer func() { finish(span, err) }()
+	return s.next.PurgeExpired(ctx)
+}
@@ -0,0 +1,166 @@
+// Package tracing настраивает OpenTelemetry и оборачивает HTTP, gRPC, сервисы и репозитории спанами.
+// Пока провайдер не настроен, глобальный otel отдаёт no-op трейсер, и обёртки почти ничего не стоят.
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"avi_internship_autumn/internal/domain"
+	"avi_internship_autumn/internal/logging"
+)
+
+const instrumentationName = "avi_internship_autumn/internal/tracing"
+
+// tracer берётся из глобального провайдера при каждом вызове,
+// поэтому обёртки можно создавать до Setup.
+var tracer = otel.Tracer(instrumentationName)
+
+// Атрибуты предметной области, которые вешаются на спаны всех слоёв.
+const (
+	PRIDKey             = attribute.Key("pr.id")
+	UserIDKey           = attribute.Key("user.id")
+	ReviewerIDKey       = attribute.Key("reviewer.id")
+	TeamNameKey         = attribute.Key("team.name")
+	RequestIDKey        = attribute.Key("request.id")
+	TokenIDKey          = attribute.Key("token.id")
+	IdempotencyScopeKey = attribute.Key("idempotency.scope")
+)
+
+// Settings параметры экспорта трейсов.
+type Settings struct {
+	ServiceName string
+	SampleRatio float64
+}
+
+// InitPropagation включает W3C trace-context и baggage. Нужна и без экспорта:
+// входящий traceparent тогда всё равно доходит до исходящих вызовов и логов.
+func InitPropagation() {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+}
+
+// Setup поднимает OTLP/HTTP экспортёр и делает провайдер глобальным.
+// Эндпоинт, заголовки и TLS экспортёр берёт из стандартных OTEL_EXPORTER_OTLP_*.
+// Возвращает shutdown, который досылает буфер спанов.
+func Setup(ctx context.Context, s Settings) (func(context.Context) error, error) {
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(s.ServiceName),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(s.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	InitPropagation()
+
+	return provider.Shutdown, nil
+}
+
+func start(ctx context.Context, name string, kind trace.SpanKind, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if id := logging.RequestID(ctx); id != "" {
+		attrs = append(attrs, RequestIDKey.String(id))
+	}
+	return tracer.Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+}
+
+// startService спан метода app.*Service.
+func startService(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return start(ctx, name, trace.SpanKindInternal, attrs...)
+}
+
+// startDB спан метода pg-репозитория; имя метода идёт в db.operation.name.
+func startDB(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, semconv.DBSystemNamePostgreSQL, semconv.DBOperationName(name))
+	return start(ctx, "pg."+name, trace.SpanKindClient, attrs...)
+}
+
+// finish закрывает спан. Доменные ошибки (нет PR, нет кандидата...) — штатный ответ API,
+// их пишем событием, но спан ошибочным не помечаем.
+func finish(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		if !isDomainError(err) {
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+	span.End()
+}
+
+func isDomainError(err error) bool {
+	for _, target := range []error{
+		domain.ErrTeamExists,
+		domain.ErrPRExists,
+		domain.ErrPRMerged,
+		domain.ErrPRClosed,
+		domain.ErrNotAssigned,
+		domain.ErrNoCandidate,
+		domain.ErrNotFound,
+		domain.ErrInvalidSignature,
+		domain.ErrValidation,
+		domain.ErrUnauthorized,
+		domain.ErrForbidden,
+		domain.ErrIdempotencyMismatch,
+		domain.ErrIdempotencyInProgress,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func prAttr(id string) attribute.KeyValue {
+	return PRIDKey.String(id)
+}
+
+func userAttr(id string) attribute.KeyValue {
+	return UserIDKey.String(id)
+}
+
+func reviewerAttr(id string) attribute.KeyValue {
+	return ReviewerIDKey.String(id)
+}
+
+func teamAttr(name string) attribute.KeyValue {
+	return TeamNameKey.String(name)
+}
+
+func tokenAttr(id int64) attribute.KeyValue {
+	return TokenIDKey.Int64(id)
+}
+
+func idempotencyScopeAttr(scope string) attribute.KeyValue {
+	return IdempotencyScopeKey.String(scope)
+}
+
+func usersAttr(ids []string) attribute.KeyValue {
+	return attribute.StringSlice("user.ids", ids)
+}
+
+func reviewersAttr(ids []string) attribute.KeyValue {
+	return attribute.StringSlice("reviewer.ids", ids)
+}
@@ -0,0 +1,218 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"avi_internship_autumn/internal/app"
+	"avi_internship_autumn/internal/domain"
+	apihttp "avi_internship_autumn/internal/http"
+	"avi_internship_autumn/internal/logging"
+	"avi_internship_autumn/internal/repository"
+	"avi_internship_autumn/internal/service"
+)
+
+// фейковые репозитории: ровно те методы, что нужны GetTeam и MergePR
+
+type fakeTeams struct {
+	repository.TeamRepository
+}
+
+func (fakeTeams) Get(_ context.Context, teamName string) (domain.Team, error) {
+	if teamName != "backend" {
+		return domain.Team{}, domain.ErrNotFound
+	}
+	return domain.Team{Name: teamName}, nil
+}
+
+type fakeUsers struct {
+	repository.UserRepository
+}
+
+func (fakeUsers) GetByID(_ context.Context, id string) (domain.User, error) {
+	return domain.User{ID: id, TeamName: "backend", IsActive: true}, nil
+}
+
+type fakePRs struct {
+	repository.PRRepository
+	merged bool
+}
+
+func (f *fakePRs) GetForUpdate(_ context.Context, id string) (domain.PullRequest, error) {
+	pr := domain.PullRequest{ID: id, Name: "Traced", AuthorID: "u1", Status: domain.PRStatusOpen}
+	if f.merged {
+		pr.Status = domain.PRStatusMerged
+	}
+	return pr, nil
+}
+
+func (f *fakePRs) UpdateStatusMerged(context.Context, string) error {
+	f.merged = true
+	return nil
+}
+
+func (f *fakePRs) GetReviewers(context.Context, string) ([]string, error) {
+	return []string{"u2"}, nil
+}
+
+// adminTokens пускает любой токен с правами admin; без обёртки, чтобы не плодить спанов.
+type adminTokens struct {
+	app.TokenService
+}
+
+func (adminTokens) Authenticate(context.Context, string) (domain.Principal, error) {
+	return domain.Principal{Scope: domain.ScopeAdmin}, nil
+}
+
+func TestMiddlewareSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	// глобальный провайдер otel подменяется только один раз за процесс, поэтому берём трейсер напрямую
+	prev := tracer
+	tracer = provider.Tracer(instrumentationName)
+	InitPropagation()
+	t.Cleanup(func() {
+		tracer = prev
+		_ = provider.Shutdown(context.Background())
+	})
+
+	repos := InstrumentRepositories(&app.Repositories{Teams: fakeTeams{}, Users: fakeUsers{}, PRs: &fakePRs{}})
+	teamSvc := InstrumentTeamService(service.NewTeamService(repos.Teams, repos.Users))
+	prSvc := InstrumentPRService(service.NewPRService(repos.PRs, repos.Users, app.MultiPublisher{}))
+	router := apihttp.NewRouter(teamSvc, nil, prSvc, nil, nil, adminTokens{}, nil,
+		apihttp.IntegrationSecrets{}, apihttp.Limits{}, app.NewEventBus(1), time.Second)
+	// как в main.go: request ID снаружи трейсинга
+	h := logging.Middleware(Middleware(router))
+
+	// serve выполняет запрос и возвращает его спаны по имени
+	serve := func(t *testing.T, method, target string, header http.Header) (int, map[string]sdktrace.ReadOnlySpan) {
+		t.Helper()
+		recorder.Reset()
+
+		req := httptest.NewRequest(method, target, nil)
+		req.Header.Set("Authorization", "Bearer token")
+		for k, v := range header {
+			req.Header.Set(k, v[0])
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		spans := make(map[string]sdktrace.ReadOnlySpan)
+		for _, s := range recorder.Ended() {
+			spans[s.Name()] = s
+		}
+		return rec.Code, spans
+	}
+	// span достаёт спан по имени; если его нет — падает с перечнем записанных
+	span := func(t *testing.T, spans map[string]sdktrace.ReadOnlySpan, name string) sdktrace.ReadOnlySpan {
+		t.Helper()
+		s, ok := spans[name]
+		if !ok {
+			names := make([]string, 0, len(spans))
+			for n := range spans {
+				names = append(names, n)
+			}
+			t.Fatalf("no span %q, recorded %v", name, names)
+		}
+		return s
+	}
+	attr := func(s sdktrace.ReadOnlySpan, key attribute.Key) string {
+		for _, kv := range s.Attributes() {
+			if kv.Key == key {
+				return kv.Value.Emit()
+			}
+		}
+		return ""
+	}
+	childOf := func(t *testing.T, child, parent sdktrace.ReadOnlySpan) {
+		t.Helper()
+		if child.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Fatalf("%s is not a child of %s", child.Name(), parent.Name())
+		}
+	}
+
+	t.Run("team request", func(t *testing.T) {
+		const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		code, spans := serve(t, http.MethodGet, "/api/v1/teams/backend", http.Header{
+			"Traceparent":           {"00-" + traceID + "-00f067aa0ba902b7-01"},
+			logging.RequestIDHeader: {"req-team-1"},
+		})
+		if code != http.StatusOK || len(spans) != 3 {
+			t.Fatalf("status %d, %d spans: %v", code, len(spans), spans)
+		}
+
+		server := span(t, spans, "GET /api/v1/teams/{team_name}")
+		svc := span(t, spans, "TeamService.GetTeam")
+		db := span(t, spans, "pg.TeamRepository.Get")
+
+		// входящий traceparent продолжается, а не начинается новый трейс
+		if server.SpanKind() != trace.SpanKindServer || server.SpanContext().TraceID().String() != traceID ||
+			!server.Parent().IsRemote() {
+			t.Fatalf("server span does not continue the incoming trace: %+v", server.SpanContext())
+		}
+		if got := attr(server, semconv.HTTPRouteKey); got != "/api/v1/teams/{team_name}" {
+			t.Fatalf("http.route = %q", got)
+		}
+		if got := attr(server, semconv.HTTPResponseStatusCodeKey); got != "200" {
+			t.Fatalf("http.response.status_code = %q", got)
+		}
+		childOf(t, svc, server)
+		childOf(t, db, svc)
+		if db.SpanKind() != trace.SpanKindClient || attr(db, semconv.DBOperationNameKey) != "TeamRepository.Get" {
+			t.Fatalf("unexpected db span: kind %v, attributes %v", db.SpanKind(), db.Attributes())
+		}
+		for _, s := range []sdktrace.ReadOnlySpan{svc, db} {
+			if attr(s, TeamNameKey) != "backend" || attr(s, RequestIDKey) != "req-team-1" {
+				t.Fatalf("%s attributes: %v", s.Name(), s.Attributes())
+			}
+		}
+	})
+
+	t.Run("merge request", func(t *testing.T) {
+		code, spans := serve(t, http.MethodPost, "/api/v1/pull-requests/pr-1/merge", nil)
+		if code != http.StatusOK {
+			t.Fatalf("status %d", code)
+		}
+
+		server := span(t, spans, "POST /api/v1/pull-requests/{pull_request_id}/merge")
+		svc := span(t, spans, "PRService.MergePR")
+		childOf(t, svc, server)
+		if attr(svc, PRIDKey) != "pr-1" {
+			t.Fatalf("%s attributes: %v", svc.Name(), svc.Attributes())
+		}
+		for _, name := range []string{"pg.PRRepository.GetForUpdate", "pg.PRRepository.UpdateStatusMerged", "pg.PRRepository.GetReviewers"} {
+			db := span(t, spans, name)
+			childOf(t, db, svc)
+			if attr(db, PRIDKey) != "pr-1" {
+				t.Fatalf("%s attributes: %v", name, db.Attributes())
+			}
+		}
+		childOf(t, span(t, spans, "pg.UserRepository.GetByID"), svc)
+	})
+
+	t.Run("domain error", func(t *testing.T) {
+		code, spans := serve(t, http.MethodGet, "/api/v1/teams/missing", nil)
+		if code != http.StatusNotFound {
+			t.Fatalf("status %d", code)
+		}
+		// 404 — штатный ответ: ошибка пишется событием, но спаны не ошибочные
+		for name, s := range spans {
+			if s.Status().Code == codes.Error {
+				t.Fatalf("%s marked as error: %+v", name, s.Status())
+			}
+		}
+		if events := span(t, spans, "pg.TeamRepository.Get").Events(); len(events) != 1 || events[0].Name != "exception" {
+			t.Fatalf("expected an exception event, got %+v", events)
+		}
+	})
+}
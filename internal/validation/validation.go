@@ -0,0 +1,123 @@
+// Package validation проверки полей запросов, общие для HTTP и gRPC API:
+// оба транспорта отдают клиенту одни и те же ограничения и тексты ошибок.
+package validation
+
+import (
+	"avi_internship_autumn/internal/domain"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Ограничения на поля запросов. Длины — в символах, не в байтах.
+const (
+	MaxIDLen     = 128  // user_id, team_name, pull_request_id
+	MaxNameLen   = 256  // username, pull_request_name, chat_handle, секреты
+	MaxEmailLen  = 254  // RFC 5321
+	MaxURLLen    = 2048 // chat_webhook_url, url подписки
+	MaxListItems = 1000 // members, user_ids, event_types
+)
+
+// Validator копит ошибки полей, чтобы вернуть их клиенту одним ответом.
+// Имена полей задаёт транспорт: JSON-ключи в HTTP, имена из proto в gRPC.
+type Validator struct {
+	fields []domain.FieldError
+}
+
+// Add добавляет ошибку поля.
+func (v *Validator) Add(field, message string) {
+	v.fields = append(v.fields, domain.FieldError{Field: field, Message: message})
+}
+
+// Check добавляет ошибку, если условие не выполнено.
+func (v *Validator) Check(ok bool, field, message string) {
+	if !ok {
+		v.Add(field, message)
+	}
+}
+
+// Required проверяет, что строка непустая; возвращает false, если поля нет.
+func (v *Validator) Required(field, value string) bool {
+	if value == "" {
+		v.Add(field, "is required")
+		return false
+	}
+	return true
+}
+
+// MaxLen ограничивает длину строки.
+func (v *Validator) MaxLen(field, value string, n int) {
+	if utf8.RuneCountInString(value) > n {
+		v.Add(field, "must be at most "+strconv.Itoa(n)+" characters")
+	}
+}
+
+// ID обязательный идентификатор: не длиннее MaxIDLen, без пробелов и управляющих символов —
+// такие значения попадают в пути /api/v1, метки метрик и заголовки.
+func (v *Validator) ID(field, value string) {
+	if !v.Required(field, value) {
+		return
+	}
+	v.OptionalID(field, value)
+}
+
+// OptionalID идентификатор-фильтр: пустой допустим.
+func (v *Validator) OptionalID(field, value string) {
+	if utf8.RuneCountInString(value) > MaxIDLen {
+		v.Add(field, "must be at most "+strconv.Itoa(MaxIDLen)+" characters")
+		return
+	}
+	for _, c := range value {
+		if unicode.IsSpace(c) || !unicode.IsPrint(c) {
+			v.Add(field, "must not contain whitespace or control characters")
+			return
+		}
+	}
+}
+
+// Email необязательный голый адрес, без отображаемого имени: пустая строка снимает адрес.
+func (v *Validator) Email(field, value string) {
+	if value == "" {
+		return
+	}
+	v.MaxLen(field, value, MaxEmailLen)
+	addr, err := mail.ParseAddress(value)
+	v.Check(err == nil && addr.Address == value, field, "must be a bare email address")
+}
+
+// HTTPURL необязательный http(s)-адрес.
+func (v *Validator) HTTPURL(field, value string) {
+	if value == "" {
+		return
+	}
+	v.MaxLen(field, value, MaxURLLen)
+	u, err := url.Parse(value)
+	v.Check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "", field, "must be an http(s) URL")
+}
+
+// MaxItems ограничивает длину списка.
+func (v *Validator) MaxItems(field string, n, limit int) {
+	if n > limit {
+		v.Add(field, "must contain at most "+strconv.Itoa(limit)+" items")
+	}
+}
+
+// Err nil, если ошибок нет, иначе *domain.ValidationError.
+func (v *Validator) Err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &domain.ValidationError{Fields: v.fields}
+}
+
+// Invalid ошибка валидации одного поля.
+func Invalid(field, message string) error {
+	return &domain.ValidationError{Fields: []domain.FieldError{{Field: field, Message: message}}}
+}
+
+// Index путь к элементу списка: members[2].user_id.
+func Index(field string, i int) string {
+	return field + "[" + strconv.Itoa(i) + "]"
+}
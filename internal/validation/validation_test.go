@@ -0,0 +1,71 @@
+package validation
+
+import (
+	"avi_internship_autumn/internal/domain"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidator(t *testing.T) {
+	tests := []struct {
+		name  string
+		check func(v *Validator)
+		want  []domain.FieldError
+	}{
+		{name: "valid id", check: func(v *Validator) { v.ID("user_id", "u1") }},
+		{name: "missing id", check: func(v *Validator) { v.ID("user_id", "") },
+			want: []domain.FieldError{{Field: "user_id", Message: "is required"}}},
+		{name: "id with space", check: func(v *Validator) { v.ID("user_id", "u 1") },
+			want: []domain.FieldError{{Field: "user_id", Message: "must not contain whitespace or control characters"}}},
+		{name: "long id", check: func(v *Validator) { v.OptionalID("team_name", strings.Repeat("я", MaxIDLen+1)) },
+			want: []domain.FieldError{{Field: "team_name", Message: "must be at most 128 characters"}}},
+		// длина в символах: 128 кириллических букв — это 256 байт, но проходят
+		{name: "id length in runes", check: func(v *Validator) { v.OptionalID("team_name", strings.Repeat("я", MaxIDLen)) }},
+		{name: "empty optional id", check: func(v *Validator) { v.OptionalID("team_name", "") }},
+		{name: "empty email", check: func(v *Validator) { v.Email("email", "") }},
+		{name: "bare email", check: func(v *Validator) { v.Email("email", "a@example.com") }},
+		{name: "email with display name", check: func(v *Validator) { v.Email("email", "A <a@example.com>") },
+			want: []domain.FieldError{{Field: "email", Message: "must be a bare email address"}}},
+		{name: "https url", check: func(v *Validator) { v.HTTPURL("url", "https://chat.example.com/hook") }},
+		{name: "url without host", check: func(v *Validator) { v.HTTPURL("url", "https://") },
+			want: []domain.FieldError{{Field: "url", Message: "must be an http(s) URL"}}},
+		{name: "non-http url", check: func(v *Validator) { v.HTTPURL("url", "ftp://example.com") },
+			want: []domain.FieldError{{Field: "url", Message: "must be an http(s) URL"}}},
+		{name: "too many items", check: func(v *Validator) { v.MaxItems("members", 3, 2) },
+			want: []domain.FieldError{{Field: "members", Message: "must contain at most 2 items"}}},
+		{
+			name: "errors are collected",
+			check: func(v *Validator) {
+				v.ID(Index("members", 1)+".user_id", "")
+				v.MaxLen("username", "abc", 2)
+			},
+			want: []domain.FieldError{
+				{Field: "members[1].user_id", Message: "is required"},
+				{Field: "username", Message: "must be at most 2 characters"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v Validator
+			tt.check(&v)
+			err := v.Err()
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var verr *domain.ValidationError
+			if !errors.As(err, &verr) || !errors.Is(err, domain.ErrValidation) {
+				t.Fatalf("Err() = %v, want *domain.ValidationError", err)
+			}
+			if !reflect.DeepEqual(verr.Fields, tt.want) {
+				t.Fatalf("fields = %+v, want %+v", verr.Fields, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,355 @@
+// Package client типизированный Go-клиент HTTP API сервиса назначения ревьюверов (/api/v1).
+//
+// Клиент сам подставляет bearer-токен, повторяет запросы, которые сервер не выполнил
+// (429, 503) или которые безопасно повторить (GET/PUT/DELETE и POST с Idempotency-Key),
+// и превращает ErrorResponse в *APIError, сравнимый через errors.Is с ErrNotFound, ErrPRExists и т. д.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	mathrand "math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	requestIDHeader      = "X-Request-ID"
+	idempotencyKeyHeader = "Idempotency-Key"
+)
+
+// DefaultRetryPolicy три попытки с паузой 200 мс, 400 мс, но не дольше Retry-After от сервера.
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 3, BaseDelay: 200 * time.Millisecond, MaxDelay: 5 * time.Second}
+
+// DefaultTimeout сколько по умолчанию ждём ответа на обычный (не потоковый) вызов, с учётом повторов.
+const DefaultTimeout = 30 * time.Second
+
+// RetryPolicy параметры повторных попыток. MaxAttempts <= 1 выключает повторы.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// Backoff возвращает паузу перед следующей попыткой после attempts неудачных:
+// случайная в [d/2, d], где d = BaseDelay * 2^(attempts-1), но не больше MaxDelay.
+func (p RetryPolicy) Backoff(attempts int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < attempts && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	// джиттер, чтобы клиенты, упёршиеся в один лимит, не возвращались разом
+	return d/2 + mathrand.N(d/2+1)
+}
+
+// Client клиент API. Безопасен для одновременного использования из нескольких горутин.
+type Client struct {
+	baseURL *url.URL
+	http    *http.Client
+	token   string
+	timeout time.Duration
+	retry   RetryPolicy
+}
+
+// Option настройка клиента для New и Clone.
+type Option func(*Client)
+
+// WithToken задаёт bearer-токен: admin- или user-токен из /api/v1/tokens либо JWT.
+func WithToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// WithHTTPClient задаёт свой http.Client, например с mTLS или трейсингом в транспорте.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.http = hc }
+}
+
+// WithTimeout ограничивает обычный вызов вместе со всеми повторами; 0 — только дедлайн из context.
+// На потоковые выгрузки и ReviewStream не действует, их время жизни задаёт context.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) { c.timeout = d }
+}
+
+// WithRetry задаёт политику повторов.
+func WithRetry(p RetryPolicy) Option {
+	return func(c *Client) { c.retry = p }
+}
+
+// New создаёт клиент для сервиса по адресу baseURL, например http://localhost:8080.
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("client: invalid base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("client: base URL must be an absolute http(s) URL, got %q", baseURL)
+	}
+
+	c := &Client{
+		baseURL: u,
+		http:    http.DefaultClient,
+		timeout: DefaultTimeout,
+		retry:   DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// Clone копия клиента с дополнительными настройками, например с другим токеном.
+func (c *Client) Clone(opts ...Option) *Client {
+	cp := *c
+	for _, opt := range opts {
+		opt(&cp)
+	}
+	return &cp
+}
+
+type ctxKey int
+
+const (
+	requestIDKey ctxKey = iota
+	idempotencyKeyKey
+)
+
+// WithRequestID передаёт X-Request-ID со всеми запросами, сделанными с этим context:
+// так запрос клиента находится в логах и трейсах сервиса.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// WithIdempotencyKey задаёт Idempotency-Key для POST-запросов с этим context. Без него клиент
+// генерирует случайный ключ на каждый вызов, чтобы повтор после обрыва не создал дубль.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyKey, key)
+}
+
+// request описание одного вызова API.
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	// body кодируется в JSON; []byte уходит как есть
+	body   any
+	header http.Header
+	// public — ручка без bearer-токена (вебхуки хостингов), Idempotency-Key ей не нужен
+	public bool
+}
+
+// call выполняет запрос и декодирует JSON-ответ в out (если out != nil).
+func (c *Client) call(ctx context.Context, req request, out any) error {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("client: %s %s: decode response: %w", req.method, req.path, err)
+	}
+	return nil
+}
+
+// do отправляет запрос с повторами и возвращает успешный (2xx) ответ, тело закрывает вызывающий.
+func (c *Client) do(ctx context.Context, req request) (*http.Response, error) {
+	var body []byte
+	switch b := req.body.(type) {
+	case nil:
+	case []byte:
+		// уже готовый JSON, который нельзя переформатировать: по нему считана подпись вебхука
+		body = b
+	default:
+		var err error
+		if body, err = json.Marshal(b); err != nil {
+			return nil, fmt.Errorf("client: %s %s: encode request: %w", req.method, req.path, err)
+		}
+	}
+
+	// path уже экранирован: сегменты из ID собирает pathf
+	target := c.baseURL.String() + req.path
+	if len(req.query) > 0 {
+		target += "?" + req.query.Encode()
+	}
+
+	header := http.Header{}
+	for k, v := range req.header {
+		header[k] = v
+	}
+	if header.Get("Accept") == "" {
+		header.Set("Accept", "application/json")
+	}
+	if body != nil {
+		header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" && !req.public {
+		header.Set("Authorization", "Bearer "+c.token)
+	}
+	if id, ok := ctx.Value(requestIDKey).(string); ok && id != "" {
+		header.Set(requestIDHeader, id)
+	}
+
+	// POST повторяем только под Idempotency-Key: сервер отдаст сохранённый ответ, а не создаст дубль
+	idempotent := req.method != http.MethodPost
+	if req.method == http.MethodPost && !req.public {
+		key, _ := ctx.Value(idempotencyKeyKey).(string)
+		if key == "" && c.retry.MaxAttempts > 1 {
+			key = newIdempotencyKey()
+		}
+		if key != "" {
+			header.Set(idempotencyKeyHeader, key)
+			idempotent = true
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		httpReq, err := http.NewRequestWithContext(ctx, req.method, target, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("client: %s %s: %w", req.method, req.path, err)
+		}
+		if body == nil {
+			httpReq.Body = http.NoBody
+		}
+		httpReq.Header = header.Clone()
+
+		resp, err := c.http.Do(httpReq)
+		var retryAfter time.Duration
+		switch {
+		case err != nil:
+			err = fmt.Errorf("client: %s %s: %w", req.method, req.path, err)
+			// без Idempotency-Key неизвестно, успел ли сервер выполнить POST
+			if ctx.Err() != nil || !idempotent {
+				return nil, err
+			}
+		case resp.StatusCode < 300:
+			return resp, nil
+		default:
+			apiErr := readAPIError(resp)
+			if !retryable(apiErr, idempotent) {
+				return nil, apiErr
+			}
+			err, retryAfter = apiErr, apiErr.RetryAfter
+		}
+
+		if attempt >= c.retry.MaxAttempts {
+			return nil, err
+		}
+		delay := max(c.retry.Backoff(attempt), retryAfter)
+		// не ждём паузу, которую дедлайн всё равно не переживёт
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return nil, err
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+// retryable можно ли повторить запрос, получивший ошибку.
+func retryable(err *APIError, idempotent bool) bool {
+	switch {
+	case errors.Is(err, ErrRateLimited), errors.Is(err, ErrOverloaded):
+		// запрос до хендлера не дошёл — повтор безопасен для любого метода
+		return true
+	case errors.Is(err, ErrIdempotencyKeyInUse):
+		// первый запрос с тем же ключом ещё выполняется; после него придёт сохранённый ответ
+		return true
+	case err.StatusCode == http.StatusBadGateway, err.StatusCode == http.StatusGatewayTimeout,
+		err.StatusCode == http.StatusServiceUnavailable, errors.Is(err, ErrInternal):
+		return idempotent
+	default:
+		return false
+	}
+}
+
+// readAPIError читает тело ошибки и закрывает его.
+func readAPIError(resp *http.Response) *APIError {
+	defer func() { _ = resp.Body.Close() }()
+
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get(requestIDHeader),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+
+	var body struct {
+		Error struct {
+			Code      ErrorCode    `json:"code"`
+			Message   string       `json:"message"`
+			Details   []FieldError `json:"details"`
+			RequestID string       `json:"request_id"`
+		} `json:"error"`
+	}
+	// ошибку прокси или 405 от роутера не в формате ErrorResponse оставляем с одним статусом
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if json.Unmarshal(raw, &body) == nil && body.Error.Code != "" {
+		apiErr.Code = body.Error.Code
+		apiErr.Message = body.Error.Message
+		apiErr.Details = body.Error.Details
+		if body.Error.RequestID != "" {
+			apiErr.RequestID = body.Error.RequestID
+		}
+	} else {
+		apiErr.Message = strings.TrimSpace(string(raw))
+	}
+	return apiErr
+}
+
+// parseRetryAfter Retry-After в секундах или HTTP-датой.
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(min(secs, math.MaxInt32)) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0)
+	}
+	return 0
+}
+
+func newIdempotencyKey() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// pathf подставляет в шаблон пути экранированные сегменты: ID приходят от пользователя.
+func pathf(format string, segments ...any) string {
+	escaped := make([]any, 0, len(segments))
+	for _, s := range segments {
+		escaped = append(escaped, url.PathEscape(fmt.Sprint(s)))
+	}
+	return fmt.Sprintf(format, escaped...)
+}
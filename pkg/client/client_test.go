@@ -0,0 +1,329 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fastRetry повторы без заметных пауз, чтобы тесты не спали.
+var fastRetry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        *APIError
+		idempotent bool
+		want       bool
+	}{
+		{name: "rate limited POST", err: &APIError{StatusCode: 429, Code: CodeRateLimited}, want: true},
+		{name: "rate limited by proxy", err: &APIError{StatusCode: 429}, want: true},
+		{name: "overloaded POST", err: &APIError{StatusCode: 503, Code: CodeOverloaded}, want: true},
+		{name: "idempotency key in use", err: &APIError{StatusCode: 409, Code: CodeIdempotencyKeyInUse}, want: true},
+		{name: "internal idempotent", err: &APIError{StatusCode: 500, Code: CodeInternal}, idempotent: true, want: true},
+		{name: "internal POST", err: &APIError{StatusCode: 500, Code: CodeInternal}},
+		{name: "bad gateway idempotent", err: &APIError{StatusCode: 502}, idempotent: true, want: true},
+		{name: "bad gateway POST", err: &APIError{StatusCode: 502}},
+		{name: "gateway timeout idempotent", err: &APIError{StatusCode: 504}, idempotent: true, want: true},
+		{name: "unavailable without code idempotent", err: &APIError{StatusCode: 503}, idempotent: true, want: true},
+		{name: "not found", err: &APIError{StatusCode: 404, Code: CodeNotFound}, idempotent: true},
+		{name: "conflict", err: &APIError{StatusCode: 409, Code: CodePRExists}, idempotent: true},
+		{name: "key reused", err: &APIError{StatusCode: 422, Code: CodeIdempotencyKeyReused}, idempotent: true},
+		{name: "validation", err: &APIError{StatusCode: 400, Code: CodeValidation}, idempotent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryable(tt.err, tt.idempotent); got != tt.want {
+				t.Fatalf("retryable(%v, %v) = %v, want %v", tt.err, tt.idempotent, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 10, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		attempts int
+		min, max time.Duration
+	}{
+		{attempts: 1, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{attempts: 2, min: 100 * time.Millisecond, max: 200 * time.Millisecond},
+		{attempts: 3, min: 200 * time.Millisecond, max: 400 * time.Millisecond},
+		// 800 мс → 1.6 с упирается в MaxDelay
+		{attempts: 5, min: 500 * time.Millisecond, max: time.Second},
+		// удвоение останавливается на MaxDelay и не переполняется
+		{attempts: 100, min: 500 * time.Millisecond, max: time.Second},
+	}
+	for _, tt := range tests {
+		// джиттер случайный — проверяем границы на многих выборках
+		for i := 0; i < 200; i++ {
+			if d := p.Backoff(tt.attempts); d < tt.min || d > tt.max {
+				t.Fatalf("Backoff(%d) = %v, want within [%v, %v]", tt.attempts, d, tt.min, tt.max)
+			}
+		}
+	}
+
+	if d := (RetryPolicy{}).Backoff(3); d != 0 {
+		t.Fatalf("zero policy: Backoff = %v, want 0", d)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		min, max time.Duration
+	}{
+		{name: "empty", value: ""},
+		{name: "seconds", value: "3", min: 3 * time.Second, max: 3 * time.Second},
+		{name: "zero seconds", value: "0"},
+		{name: "negative seconds", value: "-5"},
+		{name: "garbage", value: "soon"},
+		// у HTTP-даты точность в секунду: часть секунды могла уже пройти
+		{name: "http date", value: time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat), min: 8 * time.Second, max: 10 * time.Second},
+		{name: "http date in the past", value: time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRetryAfter(tt.value); got < tt.min || got > tt.max {
+				t.Fatalf("parseRetryAfter(%q) = %v, want within [%v, %v]", tt.value, got, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+// writeAPIError отвечает в формате ErrorResponse.
+func writeAPIError(w http.ResponseWriter, status int, code ErrorCode) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, `{"error":{"code":"`+string(code)+`","message":"try again"}}`)
+}
+
+func TestClientRetriesPOSTWithSameIdempotencyKey(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		keys []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get(idempotencyKeyHeader))
+		attempt := len(keys)
+		mu.Unlock()
+
+		// первые две попытки сервер падает, третья проходит
+		if attempt < 3 {
+			writeAPIError(w, http.StatusInternalServerError, CodeInternal)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"pr":{"pull_request_id":"pr-1"}}`)
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL, WithRetry(fastRetry))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	// sent отдаёт ключи, пришедшие серверу, и начинает запись заново
+	sent := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		got := keys
+		keys = nil
+		return got
+	}
+
+	t.Run("generated key", func(t *testing.T) {
+		pr, err := c.CreatePullRequest(context.Background(), CreatePullRequestRequest{PullRequestID: "pr-1"})
+		if err != nil || pr.PullRequestID != "pr-1" {
+			t.Fatalf("CreatePullRequest = %+v, %v", pr, err)
+		}
+		if keys := sent(); len(keys) != 3 || keys[0] == "" || keys[1] != keys[0] || keys[2] != keys[0] {
+			t.Fatalf("every attempt must carry the same generated key, got %q", keys)
+		}
+	})
+
+	t.Run("key from context", func(t *testing.T) {
+		ctx := WithIdempotencyKey(context.Background(), "my-key")
+		if _, err := c.CreatePullRequest(ctx, CreatePullRequestRequest{PullRequestID: "pr-1"}); err != nil {
+			t.Fatalf("CreatePullRequest: %v", err)
+		}
+		if keys := sent(); len(keys) != 3 || keys[0] != "my-key" || keys[1] != "my-key" || keys[2] != "my-key" {
+			t.Fatalf("every attempt must carry the caller's key, got %q", keys)
+		}
+	})
+}
+
+// failingTransport обрывает каждый запрос, не дойдя до сервера, и запоминает, что пытались отправить.
+type failingTransport struct {
+	mu       sync.Mutex
+	requests []*http.Request
+}
+
+func (f *failingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r)
+	return nil, errors.New("connection reset by peer")
+}
+
+func TestClientTransportErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		call     func(*Client) error
+		attempts int
+	}{
+		{
+			// у вебхука хостинга нет Idempotency-Key: неизвестно, выполнил ли его сервер
+			name: "POST without key",
+			call: func(c *Client) error {
+				_, err := c.GitHubWebhook(context.Background(), "pull_request", "sha256=00", []byte(`{}`))
+				return err
+			},
+			attempts: 1,
+		},
+		{
+			name: "POST with generated key",
+			call: func(c *Client) error {
+				_, err := c.CreatePullRequest(context.Background(), CreatePullRequestRequest{PullRequestID: "pr-1"})
+				return err
+			},
+			attempts: 3,
+		},
+		{
+			name: "PUT",
+			call: func(c *Client) error {
+				_, err := c.SetIsActive(context.Background(), "u1", false)
+				return err
+			},
+			attempts: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &failingTransport{}
+			c, err := New("http://reviewer.test", WithRetry(fastRetry), WithHTTPClient(&http.Client{Transport: transport}))
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+
+			err = tt.call(c)
+			if err == nil || !strings.Contains(err.Error(), "connection reset by peer") {
+				t.Fatalf("expected the transport error, got %v", err)
+			}
+			if len(transport.requests) != tt.attempts {
+				t.Fatalf("%d attempts, want %d", len(transport.requests), tt.attempts)
+			}
+		})
+	}
+}
+
+func TestReadAPIError(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		header   http.Header
+		body     string
+		want     APIError
+		sentinel error
+	}{
+		{
+			name:   "envelope",
+			status: http.StatusBadRequest,
+			header: http.Header{requestIDHeader: {"from-header"}},
+			body: `{"error":{"code":"VALIDATION_ERROR","message":"invalid request",` +
+				`"details":[{"field":"user_id","message":"is required"}],"request_id":"from-body"}}`,
+			want: APIError{
+				StatusCode: http.StatusBadRequest,
+				Code:       CodeValidation,
+				Message:    "invalid request",
+				Details:    []FieldError{{Field: "user_id", Message: "is required"}},
+				RequestID:  "from-body",
+			},
+			sentinel: ErrValidation,
+		},
+		{
+			name:     "rate limited with Retry-After",
+			status:   http.StatusTooManyRequests,
+			header:   http.Header{"Retry-After": {"2"}},
+			body:     `{"error":{"code":"RATE_LIMITED","message":"slow down"}}`,
+			want:     APIError{StatusCode: http.StatusTooManyRequests, Code: CodeRateLimited, Message: "slow down", RetryAfter: 2 * time.Second},
+			sentinel: ErrRateLimited,
+		},
+		{
+			// не ErrorResponse: остаются статус и текст, сравнение — по статусу
+			name:     "proxy text",
+			status:   http.StatusNotFound,
+			header:   http.Header{requestIDHeader: {"req-1"}},
+			body:     "404 page not found\n",
+			want:     APIError{StatusCode: http.StatusNotFound, Message: "404 page not found", RequestID: "req-1"},
+			sentinel: ErrNotFound,
+		},
+		{
+			name:   "json without code",
+			status: http.StatusBadGateway,
+			body:   `{"message":"upstream is down"}`,
+			want:   APIError{StatusCode: http.StatusBadGateway, Message: `{"message":"upstream is down"}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			for k, v := range tt.header {
+				rec.Header().Set(k, v[0])
+			}
+			rec.WriteHeader(tt.status)
+			_, _ = io.WriteString(rec, tt.body)
+
+			got := readAPIError(rec.Result())
+			if got.StatusCode != tt.want.StatusCode || got.Code != tt.want.Code || got.Message != tt.want.Message ||
+				got.RequestID != tt.want.RequestID || got.RetryAfter != tt.want.RetryAfter ||
+				len(got.Details) != len(tt.want.Details) || len(got.Details) > 0 && got.Details[0] != tt.want.Details[0] {
+				t.Fatalf("readAPIError() = %+v, want %+v", *got, tt.want)
+			}
+			if tt.sentinel != nil && !errors.Is(got, tt.sentinel) {
+				t.Fatalf("errors.Is(%v, %v) = false", got, tt.sentinel)
+			}
+			if tt.sentinel == nil && errors.Unwrap(got) != nil {
+				t.Fatalf("%v must not match any sentinel, unwraps to %v", got, errors.Unwrap(got))
+			}
+		})
+	}
+}
+
+func TestAPIErrorIs(t *testing.T) {
+	// каждому коду — ровно своя ошибка-значение
+	for code, sentinel := range codeErrors {
+		err := error(&APIError{StatusCode: http.StatusTeapot, Code: code})
+		for _, other := range codeErrors {
+			if got := errors.Is(err, other); got != (other == sentinel) {
+				t.Fatalf("errors.Is(%s, %v) = %v", code, other, got)
+			}
+		}
+	}
+
+	// код из тела важнее статуса: 404 с PR_EXISTS — это не ErrNotFound
+	err := &APIError{StatusCode: http.StatusNotFound, Code: CodePRExists}
+	if !errors.Is(err, ErrPRExists) || errors.Is(err, ErrNotFound) {
+		t.Fatalf("code must win over status: %v", err)
+	}
+
+	// errors.Is видит APIError и сквозь обёртку вызывающего
+	wrapped := errors.Join(errors.New("create PR"), &APIError{StatusCode: http.StatusConflict, Code: CodePRExists})
+	var apiErr *APIError
+	if !errors.Is(wrapped, ErrPRExists) || !errors.As(wrapped, &apiErr) || apiErr.StatusCode != http.StatusConflict {
+		t.Fatalf("wrapped error: %v", wrapped)
+	}
+}
@@ -0,0 +1,142 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrorCode код ошибки из ErrorResponse.error.code.
+type ErrorCode string
+
+// Коды ошибок API, как в openapi.yml.
+const (
+	CodeTeamExists           ErrorCode = "TEAM_EXISTS"
+	CodePRExists             ErrorCode = "PR_EXISTS"
+	CodePRMerged             ErrorCode = "PR_MERGED"
+	CodePRClosed             ErrorCode = "PR_CLOSED"
+	CodeNotAssigned          ErrorCode = "NOT_ASSIGNED"
+	CodeNoCandidate          ErrorCode = "NO_CANDIDATE"
+	CodeNotFound             ErrorCode = "NOT_FOUND"
+	CodeInvalidSignature     ErrorCode = "INVALID_SIGNATURE"
+	CodeValidation           ErrorCode = "VALIDATION_ERROR"
+	CodeUnauthorized         ErrorCode = "UNAUTHORIZED"
+	CodeForbidden            ErrorCode = "FORBIDDEN"
+	CodeIdempotencyKeyReused ErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	CodeIdempotencyKeyInUse  ErrorCode = "IDEMPOTENCY_KEY_IN_USE"
+	CodeRateLimited          ErrorCode = "RATE_LIMITED"
+	CodeOverloaded           ErrorCode = "OVERLOADED"
+	CodeInternal             ErrorCode = "INTERNAL_ERROR"
+)
+
+// Ошибки для errors.Is: любой *APIError с соответствующим кодом сравнивается с ними как равный.
+var (
+	// ErrTeamExists команда уже существует
+	ErrTeamExists = errors.New("team exists")
+	// ErrPRExists pull request уже существует
+	ErrPRExists = errors.New("pr exists")
+	// ErrPRMerged pull request уже смержен
+	ErrPRMerged = errors.New("pr is merged")
+	// ErrPRClosed pull request закрыт без merge
+	ErrPRClosed = errors.New("pr is closed")
+	// ErrNotAssigned ревьюер не назначен на pull request
+	ErrNotAssigned = errors.New("reviewer not assigned")
+	// ErrNoCandidate нет активного кандидата на замену
+	ErrNoCandidate = errors.New("no candidate")
+	// ErrNotFound ресурс не найден
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidSignature подпись вебхука не прошла проверку
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrValidation запрос не прошёл проверку полей, подробности в APIError.Details
+	ErrValidation = errors.New("validation failed")
+	// ErrUnauthorized нет токена, либо он неизвестен или отозван
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden токен не даёт права на операцию
+	ErrForbidden = errors.New("forbidden")
+	// ErrIdempotencyKeyReused Idempotency-Key уже использован с другим запросом
+	ErrIdempotencyKeyReused = errors.New("idempotency key reused with different request")
+	// ErrIdempotencyKeyInUse запрос с тем же Idempotency-Key ещё выполняется
+	ErrIdempotencyKeyInUse = errors.New("idempotency key in progress")
+	// ErrRateLimited превышен лимит запросов клиента
+	ErrRateLimited = errors.New("rate limited")
+	// ErrOverloaded сервер перегружен
+	ErrOverloaded = errors.New("overloaded")
+	// ErrInternal непредвиденная ошибка сервера, причина в его логах по RequestID
+	ErrInternal = errors.New("internal error")
+)
+
+var codeErrors = map[ErrorCode]error{
+	CodeTeamExists:           ErrTeamExists,
+	CodePRExists:             ErrPRExists,
+	CodePRMerged:             ErrPRMerged,
+	CodePRClosed:             ErrPRClosed,
+	CodeNotAssigned:          ErrNotAssigned,
+	CodeNoCandidate:          ErrNoCandidate,
+	CodeNotFound:             ErrNotFound,
+	CodeInvalidSignature:     ErrInvalidSignature,
+	CodeValidation:           ErrValidation,
+	CodeUnauthorized:         ErrUnauthorized,
+	CodeForbidden:            ErrForbidden,
+	CodeIdempotencyKeyReused: ErrIdempotencyKeyReused,
+	CodeIdempotencyKeyInUse:  ErrIdempotencyKeyInUse,
+	CodeRateLimited:          ErrRateLimited,
+	CodeOverloaded:           ErrOverloaded,
+	CodeInternal:             ErrInternal,
+}
+
+// statusErrors ответы без ErrorResponse (прокси, балансировщик) сопоставляем по статусу.
+var statusErrors = map[int]error{
+	http.StatusNotFound:            ErrNotFound,
+	http.StatusUnauthorized:        ErrUnauthorized,
+	http.StatusForbidden:           ErrForbidden,
+	http.StatusTooManyRequests:     ErrRateLimited,
+	http.StatusInternalServerError: ErrInternal,
+}
+
+// FieldError ошибка одного поля из details для VALIDATION_ERROR.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// APIError ошибка, которую вернул сервер. Сравнивается через errors.Is с ErrNotFound и т. п.;
+// подробности достаются через errors.As.
+type APIError struct {
+	StatusCode int
+	// Code пуст, если тело ответа не в формате ErrorResponse
+	Code    ErrorCode
+	Message string
+	Details []FieldError
+	// RequestID по нему запрос ищется в логах сервиса
+	RequestID string
+	// RetryAfter пауза из заголовка Retry-After у 429 и 503
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "api error %d", e.StatusCode)
+	if e.Code != "" {
+		fmt.Fprintf(&b, " %s", e.Code)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	for _, d := range e.Details {
+		fmt.Fprintf(&b, "; %s: %s", d.Field, d.Message)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request_id %s)", e.RequestID)
+	}
+	return b.String()
+}
+
+// Unwrap возвращает ошибку-значение для кода ответа, чтобы работал errors.Is.
+func (e *APIError) Unwrap() error {
+	if e.Code != "" {
+		return codeErrors[e.Code]
+	}
+	return statusErrors[e.StatusCode]
+}
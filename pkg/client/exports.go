@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ExportFilter фильтр выгрузок pull requests и назначений.
+type ExportFilter struct {
+	TimeWindow
+	Team   string
+	Status PRStatus
+}
+
+func (f ExportFilter) query() url.Values {
+	q := url.Values{}
+	f.encode(q)
+	setIfNotEmpty(q, "team", f.Team)
+	setIfNotEmpty(q, "status", string(f.Status))
+	return q
+}
+
+// AssignmentExport строка выгрузки назначений: одно назначение ревьювера на PR.
+type AssignmentExport struct {
+	PullRequestID   string        `json:"pull_request_id"`
+	PullRequestName string        `json:"pull_request_name"`
+	AuthorID        string        `json:"author_id"`
+	Status          PRStatus      `json:"status"`
+	ReviewerID      string        `json:"reviewer_id"`
+	ReviewerTeam    string        `json:"reviewer_team"`
+	AssignedAt      time.Time     `json:"assignedAt"`
+	Verdict         ReviewVerdict `json:"verdict,omitempty"`
+	VerdictAt       *time.Time    `json:"verdictAt,omitempty"`
+}
+
+// ExportPullRequests GET /api/v1/exports/pull-requests в NDJSON: fn вызывается на каждый PR
+// по мере чтения ответа. Ошибка fn прерывает выгрузку и возвращается как есть.
+func (c *Client) ExportPullRequests(ctx context.Context, f ExportFilter, fn func(PullRequest) error) error {
+	return exportNDJSON(ctx, c, "/api/v1/exports/pull-requests", f.query(), fn)
+}
+
+// ExportAssignments GET /api/v1/exports/assignments в NDJSON.
+func (c *Client) ExportAssignments(ctx context.Context, f ExportFilter, fn func(AssignmentExport) error) error {
+	return exportNDJSON(ctx, c, "/api/v1/exports/assignments", f.query(), fn)
+}
+
+// ExportStats GET /api/v1/exports/stats в NDJSON — статистика назначений по ревьюверам.
+func (c *Client) ExportStats(ctx context.Context, f AssignmentStatsFilter, fn func(ReviewerAssignments) error) error {
+	return exportNDJSON(ctx, c, "/api/v1/exports/stats", f.query(), fn)
+}
+
+// ExportPullRequestsCSV GET /api/v1/exports/pull-requests в CSV, пишется в w.
+func (c *Client) ExportPullRequestsCSV(ctx context.Context, f ExportFilter, w io.Writer) error {
+	return c.exportCSV(ctx, "/api/v1/exports/pull-requests", f.query(), w)
+}
+
+// ExportAssignmentsCSV GET /api/v1/exports/assignments в CSV, пишется в w.
+func (c *Client) ExportAssignmentsCSV(ctx context.Context, f ExportFilter, w io.Writer) error {
+	return c.exportCSV(ctx, "/api/v1/exports/assignments", f.query(), w)
+}
+
+// ExportStatsCSV GET /api/v1/exports/stats в CSV, пишется в w.
+func (c *Client) ExportStatsCSV(ctx context.Context, f AssignmentStatsFilter, w io.Writer) error {
+	return c.exportCSV(ctx, "/api/v1/exports/stats", f.query(), w)
+}
+
+func (c *Client) exportCSV(ctx context.Context, path string, q url.Values, w io.Writer) error {
+	q.Set("format", "csv")
+	return c.download(ctx, request{method: http.MethodGet, path: path, query: q}, w)
+}
+
+// exportNDJSON читает выгрузку построчно. Сервер рвёт соединение, если выгрузка упала на середине,
+// поэтому обрезанный ответ всегда даёт ошибку, а не молча укороченный список.
+func exportNDJSON[T any](ctx context.Context, c *Client, path string, q url.Values, fn func(T) error) error {
+	q.Set("format", "ndjson")
+	resp, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   path,
+		query:  q,
+		header: http.Header{"Accept": {"application/x-ndjson"}},
+	})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var row T
+		if err := dec.Decode(&row); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("client: GET %s: read export: %w", path, err)
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+}
+
+// download копирует тело успешного ответа в w. Таймаут клиента не действует: выгрузка
+// может идти дольше, её ограничивает context.
+func (c *Client) download(ctx context.Context, req request, w io.Writer) error {
+	if req.header == nil {
+		req.header = http.Header{"Accept": {"text/csv"}}
+	}
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("client: %s %s: read body: %w", req.method, req.path, err)
+	}
+	return nil
+}
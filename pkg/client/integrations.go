@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Provider Git-хостинг.
+type Provider string
+
+// Поддерживаемые хостинги.
+const (
+	ProviderGitHub Provider = "github"
+	ProviderGitLab Provider = "gitlab"
+)
+
+// Identity привязка логина на хостинге к пользователю сервиса.
+type Identity struct {
+	Provider Provider `json:"provider"`
+	Login    string   `json:"login"`
+	// ExternalID числовой id пользователя на хостинге. Для GitLab нужен, чтобы узнать автора MR:
+	// в Merge Request Hook автор приходит только как author_id.
+	ExternalID string `json:"external_id,omitempty"`
+	UserID     string `json:"user_id"`
+}
+
+// IntegrationResult что сервис сделал с событием хостинга. Outcome: created, merged, closed,
+// reopened, reviewers_synced или ignored (тогда причина в Reason).
+type IntegrationResult struct {
+	Outcome       string `json:"outcome"`
+	PullRequestID string `json:"pull_request_id,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+// LinkIdentity POST /api/v1/integrations/identities.
+func (c *Client) LinkIdentity(ctx context.Context, identity Identity) (Identity, error) {
+	var resp struct {
+		Identity Identity `json:"identity"`
+	}
+	err := c.call(ctx, request{method: http.MethodPost, path: "/api/v1/integrations/identities", body: identity}, &resp)
+	return resp.Identity, err
+}
+
+// GitHubWebhook POST /api/v1/integrations/github/webhook — пересылает событие GitHub как есть.
+// event — заголовок X-GitHub-Event, signature — X-Hub-Signature-256 ("sha256=<hex>") от payload.
+// Ручка публичная, bearer-токен не отправляется.
+func (c *Client) GitHubWebhook(ctx context.Context, event, signature string, payload []byte) (IntegrationResult, error) {
+	var resp IntegrationResult
+	err := c.call(ctx, request{
+		method: http.MethodPost,
+		path:   "/api/v1/integrations/github/webhook",
+		body:   payload,
+		header: http.Header{"X-Github-Event": {event}, "X-Hub-Signature-256": {signature}},
+		public: true,
+	}, &resp)
+	return resp, err
+}
+
+// GitLabWebhook POST /api/v1/integrations/gitlab/webhook — пересылает Merge Request Hook как есть.
+// token — заголовок X-Gitlab-Token.
+func (c *Client) GitLabWebhook(ctx context.Context, token string, payload []byte) (IntegrationResult, error) {
+	var resp IntegrationResult
+	err := c.call(ctx, request{
+		method: http.MethodPost,
+		path:   "/api/v1/integrations/gitlab/webhook",
+		body:   payload,
+		header: http.Header{"X-Gitlab-Token": {token}},
+		public: true,
+	}, &resp)
+	return resp, err
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// PRStatus статус pull request.
+type PRStatus string
+
+// Статусы pull request.
+const (
+	PRStatusOpen   PRStatus = "OPEN"
+	PRStatusMerged PRStatus = "MERGED"
+	PRStatusClosed PRStatus = "CLOSED"
+)
+
+// ReviewVerdict вердикт ревьювера.
+type ReviewVerdict string
+
+// Вердикты ревьювера.
+const (
+	VerdictApproved         ReviewVerdict = "APPROVED"
+	VerdictChangesRequested ReviewVerdict = "CHANGES_REQUESTED"
+)
+
+// PullRequest pull request с назначенными ревьюверами.
+type PullRequest struct {
+	PullRequestID     string     `json:"pull_request_id"`
+	PullRequestName   string     `json:"pull_request_name"`
+	AuthorID          string     `json:"author_id"`
+	Status            PRStatus   `json:"status"`
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	CreatedAt         *time.Time `json:"createdAt,omitempty"`
+	MergedAt          *time.Time `json:"mergedAt,omitempty"`
+	ClosedAt          *time.Time `json:"closedAt,omitempty"`
+}
+
+// PullRequestShort pull request в очереди ревьювера.
+type PullRequestShort struct {
+	PullRequestID   string   `json:"pull_request_id"`
+	PullRequestName string   `json:"pull_request_name"`
+	AuthorID        string   `json:"author_id"`
+	Status          PRStatus `json:"status"`
+}
+
+// CreatePullRequestRequest тело POST /api/v1/pull-requests.
+type CreatePullRequestRequest struct {
+	PullRequestID   string `json:"pull_request_id"`
+	PullRequestName string `json:"pull_request_name"`
+	AuthorID        string `json:"author_id"`
+}
+
+// ReassignResult ответ POST /api/v1/pull-requests/{pull_request_id}/reassign.
+type ReassignResult struct {
+	PR         PullRequest `json:"pr"`
+	ReplacedBy string      `json:"replaced_by"`
+}
+
+// ReviewResult ответ POST /api/v1/pull-requests/{pull_request_id}/reviews.
+type ReviewResult struct {
+	PR         PullRequest   `json:"pr"`
+	ReviewerID string        `json:"reviewer_id"`
+	Verdict    ReviewVerdict `json:"verdict"`
+}
+
+// CreatePullRequest POST /api/v1/pull-requests: создаёт PR и назначает до двух ревьюверов
+// из команды автора. Если PR уже есть — ErrPRExists.
+func (c *Client) CreatePullRequest(ctx context.Context, req CreatePullRequestRequest) (PullRequest, error) {
+	var resp struct {
+		PR PullRequest `json:"pr"`
+	}
+	err := c.call(ctx, request{method: http.MethodPost, path: "/api/v1/pull-requests", body: req}, &resp)
+	return resp.PR, err
+}
+
+// MergePullRequest POST /api/v1/pull-requests/{pull_request_id}/merge. Повторный merge не ошибка.
+func (c *Client) MergePullRequest(ctx context.Context, pullRequestID string) (PullRequest, error) {
+	var resp struct {
+		PR PullRequest `json:"pr"`
+	}
+	err := c.call(ctx, request{method: http.MethodPost, path: pathf("/api/v1/pull-requests/%s/merge", pullRequestID)}, &resp)
+	return resp.PR, err
+}
+
+// ReassignReviewer POST /api/v1/pull-requests/{pull_request_id}/reassign: заменяет oldUserID
+// другим активным участником его команды. Ошибки: ErrPRMerged, ErrNotAssigned, ErrNoCandidate.
+func (c *Client) ReassignReviewer(ctx context.Context, pullRequestID, oldUserID string) (ReassignResult, error) {
+	body := struct {
+		OldUserID string `json:"old_user_id"`
+	}{OldUserID: oldUserID}
+
+	var resp ReassignResult
+	err := c.call(ctx, request{method: http.MethodPost, path: pathf("/api/v1/pull-requests/%s/reassign", pullRequestID), body: body}, &resp)
+	return resp, err
+}
+
+// SubmitReview POST /api/v1/pull-requests/{pull_request_id}/reviews — вердикт назначенного ревьювера.
+func (c *Client) SubmitReview(ctx context.Context, pullRequestID, reviewerID string, verdict ReviewVerdict) (ReviewResult, error) {
+	body := struct {
+		ReviewerID string        `json:"reviewer_id"`
+		Verdict    ReviewVerdict `json:"verdict"`
+	}{ReviewerID: reviewerID, Verdict: verdict}
+
+	var resp ReviewResult
+	err := c.call(ctx, request{method: http.MethodPost, path: pathf("/api/v1/pull-requests/%s/reviews", pullRequestID), body: body}, &resp)
+	return resp, err
+}
@@ -0,0 +1,216 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// StatsGrouping разбивка статистики назначений по времени.
+type StatsGrouping string
+
+// Варианты разбивки; пустая — одна цифра за весь период.
+const (
+	GroupByNone StatsGrouping = ""
+	GroupByDay  StatsGrouping = "day"
+	GroupByWeek StatsGrouping = "week"
+)
+
+// TimeWindow окно [From, To); nil-граница не ограничивает.
+type TimeWindow struct {
+	From *time.Time
+	To   *time.Time
+}
+
+func (w TimeWindow) encode(q url.Values) {
+	if w.From != nil {
+		q.Set("from", w.From.UTC().Format(time.RFC3339))
+	}
+	if w.To != nil {
+		q.Set("to", w.To.UTC().Format(time.RFC3339))
+	}
+}
+
+// setIfNotEmpty добавляет необязательный фильтр.
+func setIfNotEmpty(q url.Values, key, value string) {
+	if value != "" {
+		q.Set(key, value)
+	}
+}
+
+// AssignmentStatsFilter фильтр /stats/assignments и /exports/stats.
+type AssignmentStatsFilter struct {
+	TimeWindow
+	Team    string
+	Status  PRStatus
+	GroupBy StatsGrouping
+}
+
+func (f AssignmentStatsFilter) query() url.Values {
+	q := url.Values{}
+	f.encode(q)
+	setIfNotEmpty(q, "team", f.Team)
+	setIfNotEmpty(q, "status", string(f.Status))
+	setIfNotEmpty(q, "group_by", string(f.GroupBy))
+	return q
+}
+
+// ReviewerAssignments число назначений ревьювера (за период, если задана разбивка).
+type ReviewerAssignments struct {
+	UserID      string     `json:"user_id"`
+	Period      *time.Time `json:"period,omitempty"`
+	Assignments int64      `json:"assignments"`
+}
+
+// PullRequestAssignments число назначений на pull request.
+type PullRequestAssignments struct {
+	PullRequestID string     `json:"pull_request_id"`
+	Period        *time.Time `json:"period,omitempty"`
+	Assignments   int64      `json:"assignments"`
+}
+
+// AssignmentStats ответ GET /api/v1/stats/assignments.
+type AssignmentStats struct {
+	ByReviewer []ReviewerAssignments    `json:"by_reviewer"`
+	ByPR       []PullRequestAssignments `json:"by_pr"`
+}
+
+// LatencyFilter фильтр /stats/latency.
+type LatencyFilter struct {
+	TimeWindow
+	Team string
+}
+
+// Percentiles перцентили длительности в секундах.
+type Percentiles struct {
+	Count      int64   `json:"count"`
+	P50Seconds float64 `json:"p50_seconds"`
+	P90Seconds float64 `json:"p90_seconds"`
+	P99Seconds float64 `json:"p99_seconds"`
+}
+
+// LatencyStats время до merge и до первого вердикта для одного ключа (команды, автора, ревьювера).
+type LatencyStats struct {
+	Key                string      `json:"key,omitempty"`
+	TimeToMerge        Percentiles `json:"time_to_merge"`
+	TimeToFirstVerdict Percentiles `json:"time_to_first_verdict"`
+}
+
+// LatencyReport ответ GET /api/v1/stats/latency.
+type LatencyReport struct {
+	Overall    LatencyStats   `json:"overall"`
+	ByTeam     []LatencyStats `json:"by_team"`
+	ByAuthor   []LatencyStats `json:"by_author"`
+	ByReviewer []LatencyStats `json:"by_reviewer"`
+}
+
+// FairnessIndex показатели равномерности нагрузки.
+type FairnessIndex struct {
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stddev"`
+	Gini   float64 `json:"gini"`
+	// MaxMinRatio nil, если у кого-то из активных ноль назначений, а у других нет
+	MaxMinRatio *float64 `json:"max_min_ratio"`
+}
+
+// LoadDistribution распределение нагрузки и выбивающиеся из него ревьюверы.
+type LoadDistribution struct {
+	Fairness   FairnessIndex `json:"fairness"`
+	Overloaded []string      `json:"overloaded"`
+	Underused  []string      `json:"underused"`
+}
+
+// ReviewerLoad нагрузка одного участника команды.
+type ReviewerLoad struct {
+	UserID           string `json:"user_id"`
+	IsActive         bool   `json:"is_active"`
+	OpenAssignments  int64  `json:"open_assignments"`
+	TotalAssignments int64  `json:"total_assignments"`
+}
+
+// TeamFairness отчёт о равномерности по команде.
+type TeamFairness struct {
+	TeamName   string           `json:"team_name"`
+	Members    []ReviewerLoad   `json:"members"`
+	Open       LoadDistribution `json:"open"`
+	Historical LoadDistribution `json:"historical"`
+}
+
+// PairsFilter фильтр /stats/pairs. Top — сколько самых частых пар вернуть (0..100), nil — по умолчанию 10.
+type PairsFilter struct {
+	TimeWindow
+	Team string
+	Top  *int
+}
+
+func (f PairsFilter) query() url.Values {
+	q := url.Values{}
+	f.encode(q)
+	setIfNotEmpty(q, "team", f.Team)
+	if f.Top != nil {
+		q.Set("top", strconv.Itoa(*f.Top))
+	}
+	return q
+}
+
+// ReviewPair сколько раз ревьювер получал PR автора.
+type ReviewPair struct {
+	AuthorID    string `json:"author_id"`
+	ReviewerID  string `json:"reviewer_id"`
+	Assignments int64  `json:"assignments"`
+}
+
+// ReviewPairs ответ GET /api/v1/stats/pairs: Matrix[i][j] — назначения Reviewers[j] на PR Authors[i].
+type ReviewPairs struct {
+	Authors   []string     `json:"authors"`
+	Reviewers []string     `json:"reviewers"`
+	Matrix    [][]int64    `json:"matrix"`
+	TopPairs  []ReviewPair `json:"top_pairs"`
+}
+
+// AssignmentStats GET /api/v1/stats/assignments.
+func (c *Client) AssignmentStats(ctx context.Context, f AssignmentStatsFilter) (AssignmentStats, error) {
+	var resp AssignmentStats
+	err := c.call(ctx, request{method: http.MethodGet, path: "/api/v1/stats/assignments", query: f.query()}, &resp)
+	return resp, err
+}
+
+// LatencyStats GET /api/v1/stats/latency.
+func (c *Client) LatencyStats(ctx context.Context, f LatencyFilter) (LatencyReport, error) {
+	q := url.Values{}
+	f.encode(q)
+	setIfNotEmpty(q, "team", f.Team)
+
+	var resp LatencyReport
+	err := c.call(ctx, request{method: http.MethodGet, path: "/api/v1/stats/latency", query: q}, &resp)
+	return resp, err
+}
+
+// FairnessReport GET /api/v1/stats/fairness; пустой teamName — по всем командам.
+func (c *Client) FairnessReport(ctx context.Context, teamName string) ([]TeamFairness, error) {
+	q := url.Values{}
+	setIfNotEmpty(q, "team", teamName)
+
+	var resp struct {
+		Teams []TeamFairness `json:"teams"`
+	}
+	err := c.call(ctx, request{method: http.MethodGet, path: "/api/v1/stats/fairness", query: q}, &resp)
+	return resp.Teams, err
+}
+
+// ReviewPairs GET /api/v1/stats/pairs.
+func (c *Client) ReviewPairs(ctx context.Context, f PairsFilter) (ReviewPairs, error) {
+	var resp ReviewPairs
+	err := c.call(ctx, request{method: http.MethodGet, path: "/api/v1/stats/pairs", query: f.query()}, &resp)
+	return resp, err
+}
+
+// ReviewPairsCSV GET /api/v1/stats/pairs?format=csv — матрица таблицей, пишется в w.
+func (c *Client) ReviewPairsCSV(ctx context.Context, f PairsFilter, w io.Writer) error {
+	q := f.query()
+	q.Set("format", "csv")
+	return c.download(ctx, request{method: http.MethodGet, path: "/api/v1/stats/pairs", query: q}, w)
+}
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EventSnapshot первое событие стрима: текущая очередь, как в GetReview.
+const EventSnapshot = "snapshot"
+
+// ReviewEvent событие из /users/{user_id}/review-stream.
+type ReviewEvent struct {
+	// ID позиция в шине событий; у снапшота 0
+	ID uint64
+	// Event EventSnapshot, reviewer.assigned, reviewer.unassigned или pull_request.merged
+	Event string
+	// Snapshot заполнен только у EventSnapshot
+	Snapshot           *UserReviews
+	ReviewerID         string
+	ReplacedReviewerID string
+	PullRequest        PullRequest
+	OccurredAt         time.Time
+}
+
+// ReviewStream открытый SSE-стрим очереди ревьювера. Не безопасен для использования
+// из нескольких горутин.
+type ReviewStream struct {
+	body   io.ReadCloser
+	r      *bufio.Reader
+	lastID uint64
+}
+
+// ReviewStream GET /api/v1/users/{user_id}/review-stream. lastEventID 0 — новый стрим со снапшотом;
+// после обрыва передают stream.LastEventID(), и сервер дошлёт пропущенное (или снапшот, если уже не может).
+// Стрим живёт, пока не отменён ctx, не вызван Close или сервер не закрыл соединение.
+func (c *Client) ReviewStream(ctx context.Context, userID string, lastEventID uint64) (*ReviewStream, error) {
+	header := http.Header{"Accept": {"text/event-stream"}}
+	if lastEventID > 0 {
+		header.Set("Last-Event-ID", strconv.FormatUint(lastEventID, 10))
+	}
+
+	resp, err := c.do(ctx, request{
+		method: http.MethodGet,
+		path:   pathf("/api/v1/users/%s/review-stream", userID),
+		header: header,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ReviewStream{body: resp.Body, r: bufio.NewReader(resp.Body), lastID: lastEventID}, nil
+}
+
+// LastEventID id последнего прочитанного блока, включая heartbeat; с ним переподключаются.
+func (s *ReviewStream) LastEventID() uint64 {
+	return s.lastID
+}
+
+// Close закрывает соединение.
+func (s *ReviewStream) Close() error {
+	return s.body.Close()
+}
+
+// Next ждёт следующее событие. io.EOF — сервер закрыл стрим, можно переподключиться
+// с LastEventID. Heartbeat-блоки событий не порождают, только сдвигают LastEventID.
+func (s *ReviewStream) Next() (ReviewEvent, error) {
+	var (
+		id, event string
+		data      strings.Builder
+	)
+	for {
+		line, err := s.r.ReadString('\n')
+		if err != nil {
+			if err == io.EOF && line == "" {
+				return ReviewEvent{}, io.EOF
+			}
+			return ReviewEvent{}, fmt.Errorf("client: read review stream: %w", err)
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if line != "" {
+			field, value, _ := strings.Cut(line, ":")
+			value = strings.TrimPrefix(value, " ")
+			switch field {
+			case "id":
+				id = value
+			case "event":
+				event = value
+			case "data":
+				if data.Len() > 0 {
+					data.WriteByte('\n')
+				}
+				data.WriteString(value)
+			}
+			// комментарии (": heartbeat") и retry пропускаем
+			continue
+		}
+
+		// пустая строка завершает блок
+		if id != "" {
+			if n, err := strconv.ParseUint(id, 10, 64); err == nil {
+				s.lastID = n
+			}
+		}
+		if data.Len() > 0 {
+			return decodeReviewEvent(id, event, data.String())
+		}
+		id, event = "", ""
+	}
+}
+
+func decodeReviewEvent(id, event, data string) (ReviewEvent, error) {
+	ev := ReviewEvent{Event: event}
+	ev.ID, _ = strconv.ParseUint(id, 10, 64)
+
+	if event == EventSnapshot {
+		var snapshot UserReviews
+		if err := json.Unmarshal([]byte(data), &snapshot); err != nil {
+			return ReviewEvent{}, fmt.Errorf("client: decode %s event: %w", event, err)
+		}
+		ev.Snapshot = &snapshot
+		return ev, nil
+	}
+
+	var payload struct {
+		ReviewerID         string      `json:"reviewer_id"`
+		ReplacedReviewerID string      `json:"replaced_reviewer_id"`
+		PullRequest        PullRequest `json:"pull_request"`
+		OccurredAt         time.Time   `json:"occurredAt"`
+	}
+	if err := json.Unmarshal([]byte(data), &payload); err != nil {
+		return ReviewEvent{}, fmt.Errorf("client: decode %s event: %w", event, err)
+	}
+	ev.ReviewerID = payload.ReviewerID
+	ev.ReplacedReviewerID = payload.ReplacedReviewerID
+	ev.PullRequest = payload.PullRequest
+	ev.OccurredAt = payload.OccurredAt
+	return ev, nil
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// TeamMember участник команды.
+type TeamMember struct {
+	UserID     string `json:"user_id"`
+	Username   string `json:"username"`
+	IsActive   bool   `json:"is_active"`
+	ChatHandle string `json:"chat_handle,omitempty"`
+	Email      string `json:"email,omitempty"`
+}
+
+// Team команда с участниками.
+type Team struct {
+	TeamName string       `json:"team_name"`
+	Members  []TeamMember `json:"members"`
+}
+
+// CreateTeamRequest тело POST /api/v1/teams.
+type CreateTeamRequest struct {
+	TeamName string       `json:"team_name"`
+	Members  []TeamMember `json:"members"`
+	// ChatWebhookURL необязательный адрес входящего вебхука чата команды
+	ChatWebhookURL string `json:"chat_webhook_url,omitempty"`
+}
+
+// TeamUser участник в плоском списке /teams/{team_name}/users.
+type TeamUser struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+	IsActive bool   `json:"is_active"`
+}
+
+// TeamUsers ответ GET /api/v1/teams/{team_name}/users.
+type TeamUsers struct {
+	TeamName string     `json:"team_name"`
+	Users    []TeamUser `json:"users"`
+}
+
+// ChatWebhookStatus ответ PUT /api/v1/teams/{team_name}/chat-webhook.
+type ChatWebhookStatus struct {
+	TeamName          string `json:"team_name"`
+	ChatNotifyEnabled bool   `json:"chat_notify_enabled"`
+}
+
+// BulkDeactivateResult ответ POST /api/v1/teams/{team_name}/users/deactivate.
+type BulkDeactivateResult struct {
+	TeamName         string `json:"team_name"`
+	DeactivatedUsers int64  `json:"deactivated_users"`
+	AffectedPRs      int    `json:"affected_prs"`
+}
+
+// CreateTeam POST /api/v1/teams. Если команда уже есть — ErrTeamExists.
+func (c *Client) CreateTeam(ctx context.Context, req CreateTeamRequest) (Team, error) {
+	var resp struct {
+		Team Team `json:"team"`
+	}
+	err := c.call(ctx, request{method: http.MethodPost, path: "/api/v1/teams", body: req}, &resp)
+	return resp.Team, err
+}
+
+// GetTeam GET /api/v1/teams/{team_name}.
+func (c *Client) GetTeam(ctx context.Context, teamName string) (Team, error) {
+	var resp Team
+	err := c.call(ctx, request{method: http.MethodGet, path: pathf("/api/v1/teams/%s", teamName)}, &resp)
+	return resp, err
+}
+
+// TeamUsers GET /api/v1/teams/{team_name}/users. isActive != nil оставляет только активных или неактивных.
+func (c *Client) TeamUsers(ctx context.Context, teamName string, isActive *bool) (TeamUsers, error) {
+	q := url.Values{}
+	if isActive != nil {
+		q.Set("is_active", strconv.FormatBool(*isActive))
+	}
+
+	var resp TeamUsers
+	err := c.call(ctx, request{method: http.MethodGet, path: pathf("/api/v1/teams/%s/users", teamName), query: q}, &resp)
+	return resp, err
+}
+
+// SetChatWebhook PUT /api/v1/teams/{team_name}/chat-webhook. Пустой url выключает уведомления в чат.
+func (c *Client) SetChatWebhook(ctx context.Context, teamName, chatWebhookURL string) (ChatWebhookStatus, error) {
+	body := struct {
+		ChatWebhookURL string `json:"chat_webhook_url"`
+	}{ChatWebhookURL: chatWebhookURL}
+
+	var resp ChatWebhookStatus
+	err := c.call(ctx, request{method: http.MethodPut, path: pathf("/api/v1/teams/%s/chat-webhook", teamName), body: body}, &resp)
+	return resp, err
+}
+
+// BulkDeactivate POST /api/v1/teams/{team_name}/users/deactivate: выключает userIDs команды
+// и переназначает их открытые ревью. Пустой список ничего не меняет.
+func (c *Client) BulkDeactivate(ctx context.Context, teamName string, userIDs []string) (BulkDeactivateResult, error) {
+	body := struct {
+		UserIDs []string `json:"user_ids"`
+	}{UserIDs: userIDs}
+
+	var resp BulkDeactivateResult
+	err := c.call(ctx, request{method: http.MethodPost, path: pathf("/api/v1/teams/%s/users/deactivate", teamName), body: body}, &resp)
+	return resp, err
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Scope права токена.
+type Scope string
+
+// Scope токенов.
+const (
+	ScopeAdmin Scope = "admin"
+	ScopeUser  Scope = "user"
+)
+
+// IssueTokenRequest тело POST /api/v1/tokens. UserID обязателен для ScopeUser и пуст для ScopeAdmin.
+type IssueTokenRequest struct {
+	Scope  Scope  `json:"scope"`
+	UserID string `json:"user_id,omitempty"`
+	Name   string `json:"name,omitempty"`
+}
+
+// APIToken токен без секрета.
+type APIToken struct {
+	TokenID   int64      `json:"token_id"`
+	Scope     Scope      `json:"scope"`
+	UserID    string     `json:"user_id,omitempty"`
+	Name      string     `json:"name"`
+	CreatedAt time.Time  `json:"createdAt"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+}
+
+// IssuedToken выпущенный токен; Secret показывается один раз, его передают в WithToken.
+type IssuedToken struct {
+	Token  APIToken `json:"token"`
+	Secret string   `json:"secret"`
+}
+
+// IssueToken POST /api/v1/tokens.
+func (c *Client) IssueToken(ctx context.Context, req IssueTokenRequest) (IssuedToken, error) {
+	var resp IssuedToken
+	err := c.call(ctx, request{method: http.MethodPost, path: "/api/v1/tokens", body: req}, &resp)
+	return resp, err
+}
+
+// ListTokens GET /api/v1/tokens, включая отозванные.
+func (c *Client) ListTokens(ctx context.Context) ([]APIToken, error) {
+	var resp struct {
+		Tokens []APIToken `json:"tokens"`
+	}
+	err := c.call(ctx, request{method: http.MethodGet, path: "/api/v1/tokens"}, &resp)
+	return resp.Tokens, err
+}
+
+// RevokeToken DELETE /api/v1/tokens/{token_id}.
+func (c *Client) RevokeToken(ctx context.Context, tokenID int64) (APIToken, error) {
+	var resp struct {
+		Token APIToken `json:"token"`
+	}
+	err := c.call(ctx, request{method: http.MethodDelete, path: pathf("/api/v1/tokens/%d", tokenID)}, &resp)
+	return resp.Token, err
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// User пользователь.
+type User struct {
+	UserID     string `json:"user_id"`
+	Username   string `json:"username"`
+	TeamName   string `json:"team_name"`
+	IsActive   bool   `json:"is_active"`
+	ChatHandle string `json:"chat_handle,omitempty"`
+	Email      string `json:"email,omitempty"`
+}
+
+// EmailSettings email пользователя и отписки от писем.
+type EmailSettings struct {
+	UserID            string `json:"user_id"`
+	Email             string `json:"email"`
+	OptOutAssignments bool   `json:"opt_out_assignments"`
+	OptOutDailyDigest bool   `json:"opt_out_daily_digest"`
+}
+
+// UserReviews очередь ревью пользователя.
+type UserReviews struct {
+	UserID       string             `json:"user_id"`
+	PullRequests []PullRequestShort `json:"pull_requests"`
+}
+
+// SetIsActive PUT /api/v1/users/{user_id}/active.
+func (c *Client) SetIsActive(ctx context.Context, userID string, isActive bool) (User, error) {
+	body := struct {
+		IsActive bool `json:"is_active"`
+	}{IsActive: isActive}
+
+	var resp struct {
+		User User `json:"user"`
+	}
+	err := c.call(ctx, request{method: http.MethodPut, path: pathf("/api/v1/users/%s/active", userID), body: body}, &resp)
+	return resp.User, err
+}
+
+// SetChatHandle PUT /api/v1/users/{user_id}/chat-handle. Пустой handle убирает упоминание в чате.
+func (c *Client) SetChatHandle(ctx context.Context, userID, chatHandle string) (User, error) {
+	body := struct {
+		ChatHandle string `json:"chat_handle"`
+	}{ChatHandle: chatHandle}
+
+	var resp struct {
+		User User `json:"user"`
+	}
+	err := c.call(ctx, request{method: http.MethodPut, path: pathf("/api/v1/users/%s/chat-handle", userID), body: body}, &resp)
+	return resp.User, err
+}
+
+// SetEmailSettings PUT /api/v1/users/{user_id}/email-settings. UserID берётся из settings.
+func (c *Client) SetEmailSettings(ctx context.Context, settings EmailSettings) (EmailSettings, error) {
+	var resp struct {
+		Settings EmailSettings `json:"email_settings"`
+	}
+	err := c.call(ctx, request{
+		method: http.MethodPut,
+		path:   pathf("/api/v1/users/%s/email-settings", settings.UserID),
+		body:   settings,
+	}, &resp)
+	return resp.Settings, err
+}
+
+// GetReview GET /api/v1/users/{user_id}/reviews — PR, где пользователь назначен ревьювером.
+// С user-токеном доступна только своя очередь, иначе ErrForbidden.
+func (c *Client) GetReview(ctx context.Context, userID string) (UserReviews, error) {
+	var resp UserReviews
+	err := c.call(ctx, request{method: http.MethodGet, path: pathf("/api/v1/users/%s/reviews", userID)}, &resp)
+	return resp, err
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// EventType тип события, на которое подписывается вебхук.
+type EventType string
+
+// Типы событий.
+const (
+	EventPRCreated          EventType = "pull_request.created"
+	EventPRMerged           EventType = "pull_request.merged"
+	EventPRClosed           EventType = "pull_request.closed"
+	EventPRReopened         EventType = "pull_request.reopened"
+	EventReviewerAssigned   EventType = "reviewer.assigned"
+	EventReviewerUnassigned EventType = "reviewer.unassigned"
+)
+
+// SubscribeRequest тело POST /api/v1/teams/{team_name}/webhooks. Пустой EventTypes — все события.
+type SubscribeRequest struct {
+	URL        string      `json:"url"`
+	Secret     string      `json:"secret"`
+	EventTypes []EventType `json:"event_types"`
+}
+
+// WebhookSubscription подписка команды; секрет сервер не возвращает.
+type WebhookSubscription struct {
+	SubscriptionID int64       `json:"subscription_id"`
+	TeamName       string      `json:"team_name"`
+	URL            string      `json:"url"`
+	EventTypes     []EventType `json:"event_types"`
+	IsActive       bool        `json:"is_active"`
+	CreatedAt      time.Time   `json:"createdAt"`
+}
+
+// WebhookDelivery доставка события подписчику. Status: PENDING, DELIVERED или DEAD.
+type WebhookDelivery struct {
+	DeliveryID     int64           `json:"delivery_id"`
+	SubscriptionID int64           `json:"subscription_id"`
+	EventType      EventType       `json:"event_type"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"nextAttemptAt"`
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	Payload        json.RawMessage `json:"payload"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	DeliveredAt    *time.Time      `json:"deliveredAt,omitempty"`
+}
+
+// Subscribe POST /api/v1/teams/{team_name}/webhooks.
+func (c *Client) Subscribe(ctx context.Context, teamName string, req SubscribeRequest) (WebhookSubscription, error) {
+	var resp struct {
+		Subscription WebhookSubscription `json:"subscription"`
+	}
+	err := c.call(ctx, request{method: http.MethodPost, path: pathf("/api/v1/teams/%s/webhooks", teamName), body: req}, &resp)
+	return resp.Subscription, err
+}
+
+// ListWebhooks GET /api/v1/teams/{team_name}/webhooks.
+func (c *Client) ListWebhooks(ctx context.Context, teamName string) ([]WebhookSubscription, error) {
+	var resp struct {
+		Subscriptions []WebhookSubscription `json:"subscriptions"`
+	}
+	err := c.call(ctx, request{method: http.MethodGet, path: pathf("/api/v1/teams/%s/webhooks", teamName)}, &resp)
+	return resp.Subscriptions, err
+}
+
+// SetWebhookActive PUT /api/v1/webhooks/{subscription_id}/active. Выключение переводит
+// недоставленные события подписки в DEAD.
+func (c *Client) SetWebhookActive(ctx context.Context, subscriptionID int64, isActive bool) (WebhookSubscription, error) {
+	body := struct {
+		IsActive bool `json:"is_active"`
+	}{IsActive: isActive}
+
+	var resp struct {
+		Subscription WebhookSubscription `json:"subscription"`
+	}
+	err := c.call(ctx, request{method: http.MethodPut, path: pathf("/api/v1/webhooks/%d/active", subscriptionID), body: body}, &resp)
+	return resp.Subscription, err
+}
+
+// RotateWebhookSecret PUT /api/v1/webhooks/{subscription_id}/secret — новый секрет подписи.
+func (c *Client) RotateWebhookSecret(ctx context.Context, subscriptionID int64, secret string) (WebhookSubscription, error) {
+	body := struct {
+		Secret string `json:"secret"`
+	}{Secret: secret}
+
+	var resp struct {
+		Subscription WebhookSubscription `json:"subscription"`
+	}
+	err := c.call(ctx, request{method: http.MethodPut, path: pathf("/api/v1/webhooks/%d/secret", subscriptionID), body: body}, &resp)
+	return resp.Subscription, err
+}
+
+// Deliveries GET /api/v1/webhooks/{subscription_id}/deliveries, новые первыми; limit 0 — по умолчанию 50.
+func (c *Client) Deliveries(ctx context.Context, subscriptionID int64, limit int) ([]WebhookDelivery, error) {
+	q := url.Values{}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+
+	var resp struct {
+		Deliveries []WebhookDelivery `json:"deliveries"`
+	}
+	err := c.call(ctx, request{method: http.MethodGet, path: pathf("/api/v1/webhooks/%d/deliveries", subscriptionID), query: q}, &resp)
+	return resp.Deliveries, err
+}
+
+// ReplayDelivery POST /api/v1/webhook-deliveries/{delivery_id}/replay — ставит доставку в очередь заново.
+func (c *Client) ReplayDelivery(ctx context.Context, deliveryID int64) (WebhookDelivery, error) {
+	var resp struct {
+		Delivery WebhookDelivery `json:"delivery"`
+	}
+	err := c.call(ctx, request{method: http.MethodPost, path: pathf("/api/v1/webhook-deliveries/%d/replay", deliveryID)}, &resp)
+	return resp.Delivery, err
+}
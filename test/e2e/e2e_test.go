@@ -3,26 +3,55 @@
 package e2e
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"database/sql"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"io"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/textproto"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	_ "github.com/lib/pq"
 	tc "github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
 
 	"avi_internship_autumn/internal/app"
+	migrations "avi_internship_autumn/internal/db"
+	"avi_internship_autumn/internal/domain"
+	apigrpc "avi_internship_autumn/internal/grpc"
 	apihttp "avi_internship_autumn/internal/http"
 	"avi_internship_autumn/internal/service"
+	reviewerv1 "avi_internship_autumn/pkg/api/reviewer/v1"
+	apiclient "avi_internship_autumn/pkg/client"
 )
 
 func startPostgres(t *testing.T, ctx context.Context) (*sql.DB, func()) {
@@ -117,92 +146,169 @@ func applyMigrations(t *testing.T, db *sql.DB) {
 	}
 }
 
-func TestE2E_FullFlow(t *testing.T) {
-	ctx := context.Background()
+// newTestDB отдаёт базу из E2E_DSN или из свежего контейнера с применёнными миграциями.
+func newTestDB(t *testing.T, ctx context.Context) *sql.DB {
+	t.Helper()
 
 	db, teardown := startPostgres(t, ctx)
-	defer teardown()
+	t.Cleanup(teardown)
 
 	if os.Getenv("E2E_DSN") == "" {
 		applyMigrations(t, db)
 	}
+	return db
+}
 
-	repos := app.NewRepositories(db)
+// testAPIOptions чем стенд отличается от обычного.
+type testAPIOptions struct {
+	// Secrets секреты приёма вебхуков GitHub/GitLab.
+	Secrets apihttp.IntegrationSecrets
+	// JWTSecret включает JWT HS256 поверх токенов сервиса.
+	JWTSecret string
+	// Bus шина SSE и gRPC-стримов; задана — получает события. nil — своя, без событий.
+	Bus *app.EventBus
+	// Publishers получают события вместе с вебхуками.
+	Publishers []app.EventPublisher
+}
 
-	teamSvc := service.NewTeamService(repos.Teams, repos.Users)
-	userSvc := service.NewUserService(repos.Users, repos.PRs)
-	prSvc := service.NewPRService(repos.PRs, repos.Users)
+// testAPI сервисы поверх тестовой базы, HTTP API на них и клиент с admin-токеном.
+type testAPI struct {
+	Repos    *app.Repositories
+	Webhooks app.WebhookService
+	Teams    app.TeamService
+	Users    app.UserService
+	PRs      app.PRService
+	Tokens   app.TokenService
 
-	handler := apihttp.NewRouter(teamSvc, userSvc, prSvc)
-	server := httptest.NewServer(handler)
-	defer server.Close()
+	Server *httptest.Server
+	Client *apiclient.Client
+}
 
-	client := server.Client()
+// newTestAPI собирает сервисы как main.go, но без трейсинга и метрик; сервер закрывается в t.Cleanup.
+func newTestAPI(t *testing.T, db *sql.DB, opts testAPIOptions) *testAPI {
+	t.Helper()
 
-	createTeamReq := `{
-	  "team_name": "payments_e2e",
-	  "members": [
-	    { "user_id": "u1", "username": "Alice", "is_active": true },
-	    { "user_id": "u2", "username": "Bob",   "is_active": true },
-	    { "user_id": "u3", "username": "Charlie", "is_active": true }
-	  ]
-	}`
+	repos := app.NewRepositories(db)
+	webhookSvc := service.NewWebhookService(repos.Teams, repos.Webhooks)
 
-	resp, err := client.Post(server.URL+"/team/add", "application/json", strings.NewReader(createTeamReq))
-	if err != nil {
-		t.Fatalf("team/add request failed: %v", err)
+	bus := opts.Bus
+	events := append(app.MultiPublisher{webhookSvc}, opts.Publishers...)
+	if bus != nil {
+		events = append(events, bus)
+	} else {
+		bus = app.NewEventBus(16)
 	}
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
 
+	teamSvc := service.NewTeamService(repos.Teams, repos.Users)
+	userSvc := service.NewUserService(repos.Users, repos.PRs, events)
+	prSvc := service.NewPRService(repos.PRs, repos.Users, events)
+	integrationSvc := service.NewIntegrationService(repos.Identities, repos.Users, prSvc)
+	idempotencySvc := service.NewIdempotencyService(repos.Idempotency, time.Hour, time.Minute)
+
+	tokenSvc := service.NewTokenService(repos.Tokens, repos.Users, e2eAdminToken)
+	if opts.JWTSecret != "" {
+		var err error
+		tokenSvc, err = service.NewJWTTokenService(tokenSvc, service.JWTSettings{HMACSecret: opts.JWTSecret})
+		if err != nil {
+			t.Fatalf("failed to set up JWT: %v", err)
 		}
-	}(resp.Body)
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusBadRequest {
-		body, _ := io.ReadAll(resp.Body)
-		t.Fatalf("unexpected status %d, body: %s", resp.StatusCode, string(body))
 	}
 
-	createPRReq := `{
-	  "pull_request_id": "pr-e2e-1",
-	  "pull_request_name": "Add payments endpoint",
-	  "author_id": "u1"
-	}`
+	server := httptest.NewServer(apihttp.NewRouter(teamSvc, userSvc, prSvc, webhookSvc, integrationSvc, tokenSvc, idempotencySvc,
+		opts.Secrets, apihttp.Limits{}, bus, time.Second))
+	t.Cleanup(server.Close)
 
-	resp, err = client.Post(server.URL+"/pullRequest/create", "application/json", strings.NewReader(createPRReq))
+	client, err := apiclient.New(server.URL, apiclient.WithToken(e2eAdminToken))
 	if err != nil {
-		t.Fatalf("pullRequest/create request failed: %v", err)
+		t.Fatalf("failed to create API client: %v", err)
 	}
-	bodyBytes, _ := io.ReadAll(resp.Body)
-	err = resp.Body.Close()
-	if err != nil {
-		return
+
+	return &testAPI{
+		Repos:    repos,
+		Webhooks: webhookSvc,
+		Teams:    teamSvc,
+		Users:    userSvc,
+		PRs:      prSvc,
+		Tokens:   tokenSvc,
+		Server:   server,
+		Client:   client,
 	}
+}
+
+// e2eAdminToken бутстрап-токен (AUTH_ADMIN_TOKEN), с которым тесты ходят в API.
+const e2eAdminToken = "e2e-admin-token"
+
+// e2eJWTSecret секрет HS256 (AUTH_JWT_HS256_SECRET) для JWT в тестах.
+const e2eJWTSecret = "e2e-jwt-secret"
 
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusConflict {
-		t.Fatalf("unexpected status %d for create PR, body: %s", resp.StatusCode, string(bodyBytes))
+// signJWT подписывает claims секретом e2eJWTSecret; exp — через минуту.
+func signJWT(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	claims["exp"] = time.Now().Add(time.Minute).Unix()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(e2eJWTSecret))
+	if err != nil {
+		t.Fatalf("failed to sign JWT: %v", err)
 	}
+	return token
+}
+
+// bearerTransport добавляет Authorization: Bearer к каждому запросу.
+type bearerTransport struct {
+	token string
+	next  http.RoundTripper
+}
+
+func (t bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.next.RoundTrip(req)
+}
+
+// bearerClient клиент тестового сервера, подписывающий запросы токеном.
+func bearerClient(server *httptest.Server, token string) *http.Client {
+	return &http.Client{Transport: bearerTransport{token: token, next: server.Client().Transport}}
+}
+
+func TestE2E_FullFlow(t *testing.T) {
+	ctx := context.Background()
 
-	type prResponse struct {
-		PR struct {
-			ID                string   `json:"pull_request_id"`
-			AssignedReviewers []string `json:"assigned_reviewers"`
-		} `json:"pr"`
+	db := newTestDB(t, ctx)
+	env := newTestAPI(t, db, testAPIOptions{JWTSecret: e2eJWTSecret})
+	server, api, prSvc := env.Server, env.Client, env.PRs
+	client := bearerClient(server, e2eAdminToken)
+
+	// база из E2E_DSN могла остаться от прошлого прогона — команда и PR тогда уже есть
+	_, err := api.CreateTeam(ctx, apiclient.CreateTeamRequest{
+		TeamName: "payments_e2e",
+		Members: []apiclient.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u3", Username: "Charlie", IsActive: true},
+		},
+	})
+	if err != nil && !errors.Is(err, apiclient.ErrTeamExists) {
+		t.Fatalf("create team: %v", err)
 	}
 
-	var prResp prResponse
-	_ = json.Unmarshal(bodyBytes, &prResp)
+	_, err = api.CreatePullRequest(ctx, apiclient.CreatePullRequestRequest{
+		PullRequestID:   "pr-e2e-1",
+		PullRequestName: "Add payments endpoint",
+		AuthorID:        "u1",
+	})
+	if err != nil && !errors.Is(err, apiclient.ErrPRExists) {
+		t.Fatalf("create PR: %v", err)
+	}
 
 	// 3) bulkDeactivate u2, u3
 	bulkReq := `{
 	  "team_name": "payments_e2e",
 	  "user_ids": ["u2", "u3"]
 	}`
-	resp, err = client.Post(server.URL+"/users/bulkDeactivate", "application/json", strings.NewReader(bulkReq))
+	resp, err := client.Post(server.URL+"/users/bulkDeactivate", "application/json", strings.NewReader(bulkReq))
 	if err != nil {
 		t.Fatalf("bulkDeactivate request failed: %v", err)
 	}
-	bodyBytes, _ = io.ReadAll(resp.Body)
+	bodyBytes, _ := io.ReadAll(resp.Body)
 	err = resp.Body.Close()
 	if err != nil {
 		return
@@ -223,4 +329,1764 @@ func TestE2E_FullFlow(t *testing.T) {
 	if resp.StatusCode != http.StatusOK {
 		t.Fatalf("unexpected status %d for stats, body: %s", resp.StatusCode, string(bodyBytes))
 	}
+	if resp.Header.Get("Deprecation") == "" || resp.Header.Get("Sunset") == "" {
+		t.Fatalf("legacy route must be marked deprecated, headers: %v", resp.Header)
+	}
+
+	// после bulkDeactivate активным в команде остался только автор
+	onlyActive := true
+	users, err := api.TeamUsers(ctx, "payments_e2e", &onlyActive)
+	if err != nil || len(users.Users) != 1 || users.Users[0].UserID != "u1" {
+		t.Fatalf("unexpected team users: %+v, err %v", users, err)
+	}
+
+	resp, err = client.Get(server.URL + "/pullRequest/merge")
+	if err != nil {
+		t.Fatalf("GET pullRequest/merge request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed || resp.Header.Get("Allow") != http.MethodPost {
+		t.Fatalf("expected 405 with Allow: POST, got %d %q", resp.StatusCode, resp.Header.Get("Allow"))
+	}
+
+	_, err = api.CreateTeam(ctx, apiclient.CreateTeamRequest{
+		Members: []apiclient.TeamMember{
+			{UserID: "u9", Username: "Dave"},
+			{UserID: "u9", Username: "Eve"},
+		},
+	})
+	var apiErr *apiclient.APIError
+	if !errors.Is(err, apiclient.ErrValidation) || !errors.As(err, &apiErr) ||
+		apiErr.StatusCode != http.StatusBadRequest || len(apiErr.Details) != 2 ||
+		apiErr.Details[0].Field != "team_name" ||
+		apiErr.Details[1].Field != "members[1].user_id" {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/api/v1/teams/missing_e2e", nil)
+	req.Header.Set("Accept", "application/problem+json")
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("problem+json request failed: %v", err)
+	}
+	var problem struct {
+		Type      string `json:"type"`
+		Status    int    `json:"status"`
+		Instance  string `json:"instance"`
+		RequestID string `json:"request_id"`
+		Code      string `json:"code"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&problem)
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound || resp.Header.Get("Content-Type") != "application/problem+json" ||
+		problem.Status != http.StatusNotFound || problem.Code != "NOT_FOUND" || problem.Type == "" ||
+		problem.Instance != "/api/v1/teams/missing_e2e" || problem.RequestID == "" {
+		t.Fatalf("unexpected problem response: %d %+v", resp.StatusCode, problem)
+	}
+
+	// повтор с тем же Idempotency-Key отдаёт сохранённый ответ, а не PR_EXISTS
+	idemPR := `{"pull_request_id":"pr-e2e-idem","pull_request_name":"Retry me","author_id":"u1"}`
+	for i, want := range []struct {
+		body     string
+		status   int
+		replayed string
+	}{
+		{body: idemPR, status: http.StatusCreated, replayed: ""},
+		{body: idemPR, status: http.StatusCreated, replayed: "true"},
+		{body: strings.Replace(idemPR, "Retry me", "Other", 1), status: http.StatusUnprocessableEntity, replayed: ""},
+	} {
+		req, _ = http.NewRequest(http.MethodPost, server.URL+"/api/v1/pull-requests", strings.NewReader(want.body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "e2e-create-pr")
+		resp, err = client.Do(req)
+		if err != nil {
+			t.Fatalf("idempotent request %d failed: %v", i, err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != want.status || resp.Header.Get("Idempotent-Replayed") != want.replayed {
+			t.Fatalf("idempotent request %d: expected %d replayed=%q, got %d replayed=%q",
+				i, want.status, want.replayed, resp.StatusCode, resp.Header.Get("Idempotent-Replayed"))
+		}
+	}
+
+	// закрытый без merge PR смержить нельзя, пока его не переоткрыли
+	_, err = api.CreatePullRequest(ctx, apiclient.CreatePullRequestRequest{
+		PullRequestID:   "pr-e2e-closed",
+		PullRequestName: "Abandoned",
+		AuthorID:        "u1",
+	})
+	if err != nil && !errors.Is(err, apiclient.ErrPRExists) {
+		t.Fatalf("create PR to close: %v", err)
+	}
+	if _, err := prSvc.ClosePR(ctx, "pr-e2e-closed"); err != nil {
+		t.Fatalf("close PR: %v", err)
+	}
+	_, err = api.MergePullRequest(ctx, "pr-e2e-closed")
+	if !errors.Is(err, apiclient.ErrPRClosed) || !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict {
+		t.Fatalf("merge closed PR: expected 409 %v, got %v", apiclient.ErrPRClosed, err)
+	}
+	if _, err := prSvc.ClosePR(ctx, "pr-e2e-closed"); err != nil {
+		t.Fatalf("PR must stay closed after refused merge: %v", err)
+	}
+
+	resp, err = server.Client().Get(server.URL + "/api/v1/users/u2/reviews")
+	if err != nil {
+		t.Fatalf("anonymous request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized || resp.Header.Get("WWW-Authenticate") == "" {
+		t.Fatalf("expected 401 with WWW-Authenticate, got %d", resp.StatusCode)
+	}
+
+	issued, err := api.IssueToken(ctx, apiclient.IssueTokenRequest{Scope: apiclient.ScopeUser, UserID: "u2", Name: "e2e"})
+	if err != nil || issued.Secret == "" {
+		t.Fatalf("unexpected issue token response: %+v, err %v", issued, err)
+	}
+
+	userAPI := api.Clone(apiclient.WithToken(issued.Secret))
+	for name, tt := range map[string]struct {
+		call func() error
+		want error
+	}{
+		"own reviews": {call: func() error { _, err := userAPI.GetReview(ctx, "u2"); return err }},
+		"other reviews": {
+			call: func() error { _, err := userAPI.GetReview(ctx, "u1"); return err },
+			want: apiclient.ErrForbidden,
+		},
+		"stats": {
+			call: func() error { _, err := userAPI.AssignmentStats(ctx, apiclient.AssignmentStatsFilter{}); return err },
+			want: apiclient.ErrForbidden,
+		},
+	} {
+		if err := tt.call(); !errors.Is(err, tt.want) {
+			t.Fatalf("%s with user token: expected %v, got %v", name, tt.want, err)
+		}
+	}
+
+	if _, err := api.RevokeToken(ctx, issued.Token.TokenID); err != nil {
+		t.Fatalf("revoke token: %v", err)
+	}
+	if _, err := userAPI.GetReview(ctx, "u2"); !errors.Is(err, apiclient.ErrUnauthorized) {
+		t.Fatalf("revoked token: expected %v, got %v", apiclient.ErrUnauthorized, err)
+	}
+
+	// reassign и вердикт решает сервис: за чужого ревьюера действует только тимлид его команды.
+	// Своя команда на прогон, у каждого случая свой PR с одним известным ревьювером.
+	suffix := time.Now().Format("150405.000000")
+	reassignTeam := "reassign_" + suffix
+	lead, r1, r2 := "rl_"+suffix, "r1_"+suffix, "r2_"+suffix
+	_, err = api.CreateTeam(ctx, apiclient.CreateTeamRequest{
+		TeamName: reassignTeam,
+		Members: []apiclient.TeamMember{
+			{UserID: lead, Username: "Lead", IsActive: true},
+			{UserID: r1, Username: "R1", IsActive: true},
+			{UserID: r2, Username: "R2", IsActive: true},
+			{UserID: "r3_" + suffix, Username: "R3", IsActive: true},
+			{UserID: "r4_" + suffix, Username: "R4", IsActive: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create reassign team: %v", err)
+	}
+
+	reassignCases := []struct {
+		name     string
+		token    string
+		reviewer string
+		allowed  bool
+	}{
+		{name: "peer", token: signJWT(t, jwt.MapClaims{"sub": r2, "team": reassignTeam}), reviewer: r1},
+		{name: "other lead", token: signJWT(t, jwt.MapClaims{"sub": r2, "team": "other_e2e", "roles": []string{"team_lead"}}), reviewer: r1},
+		{name: "team lead", token: signJWT(t, jwt.MapClaims{"sub": lead, "team": reassignTeam, "roles": []string{"team_lead"}}), reviewer: r1, allowed: true},
+		{name: "self", token: signJWT(t, jwt.MapClaims{"sub": r2}), reviewer: r2, allowed: true},
+	}
+	for i, tt := range reassignCases {
+		prID := "pr-reassign-" + strconv.Itoa(i) + "-" + suffix
+		if _, err := api.CreatePullRequest(ctx, apiclient.CreatePullRequestRequest{
+			PullRequestID: prID, PullRequestName: "Reassign " + tt.name, AuthorID: lead,
+		}); err != nil {
+			t.Fatalf("%s: create PR: %v", tt.name, err)
+		}
+		if _, err := prSvc.SetReviewers(ctx, prID, []string{tt.reviewer}); err != nil {
+			t.Fatalf("%s: set reviewers: %v", tt.name, err)
+		}
+
+		caller := api.Clone(apiclient.WithToken(tt.token))
+		_, verdictErr := caller.SubmitReview(ctx, prID, tt.reviewer, apiclient.VerdictApproved)
+		res, err := caller.ReassignReviewer(ctx, prID, tt.reviewer)
+		if !tt.allowed {
+			if !errors.Is(verdictErr, apiclient.ErrForbidden) {
+				t.Fatalf("%s verdict: expected %v, got %v", tt.name, apiclient.ErrForbidden, verdictErr)
+			}
+			if !errors.Is(err, apiclient.ErrForbidden) {
+				t.Fatalf("%s reassign: expected %v, got %v", tt.name, apiclient.ErrForbidden, err)
+			}
+			continue
+		}
+		if verdictErr != nil {
+			t.Fatalf("%s verdict: %v", tt.name, verdictErr)
+		}
+		if err != nil {
+			t.Fatalf("%s reassign: %v", tt.name, err)
+		}
+		if res.ReplacedBy == "" || res.ReplacedBy == tt.reviewer || res.ReplacedBy == lead ||
+			!reflect.DeepEqual(res.PR.AssignedReviewers, []string{res.ReplacedBy}) {
+			t.Fatalf("%s reassign: %s must be replaced by another member, got %+v", tt.name, tt.reviewer, res)
+		}
+	}
+
+	forged := signJWT(t, jwt.MapClaims{"sub": "u1", "roles": []string{"admin"}})
+	_, err = api.Clone(apiclient.WithToken(forged[:len(forged)-2]+"xx")).AssignmentStats(ctx, apiclient.AssignmentStatsFilter{})
+	if !errors.Is(err, apiclient.ErrUnauthorized) {
+		t.Fatalf("forged JWT: expected %v, got %v", apiclient.ErrUnauthorized, err)
+	}
+}
+
+func TestE2E_Webhooks(t *testing.T) {
+	ctx := context.Background()
+
+	db := newTestDB(t, ctx)
+	env := newTestAPI(t, db, testAPIOptions{})
+	api := env.Client
+
+	// Получатель: первый запрос отвечает 500, дальше 200 — проверяем и подпись, и ретрай.
+	const secret = "e2e-secret"
+	type received struct {
+		event     string
+		signature string
+		body      []byte
+	}
+	var got []received
+	failFirst := true
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = append(got, received{
+			event:     r.Header.Get(service.EventHeader),
+			signature: r.Header.Get(service.SignatureHeader),
+			body:      body,
+		})
+		if failFirst {
+			failFirst = false
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	suffix := time.Now().Format("150405.000000")
+	teamName := "hooks_" + suffix
+
+	_, err := api.CreateTeam(ctx, apiclient.CreateTeamRequest{
+		TeamName: teamName,
+		Members: []apiclient.TeamMember{
+			{UserID: "wh1_" + suffix, Username: "A", IsActive: true},
+			{UserID: "wh2_" + suffix, Username: "B", IsActive: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+
+	sub, err := api.Subscribe(ctx, teamName, apiclient.SubscribeRequest{
+		URL:        receiver.URL,
+		Secret:     secret,
+		EventTypes: []apiclient.EventType{apiclient.EventPRCreated},
+	})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	_, err = api.CreatePullRequest(ctx, apiclient.CreatePullRequestRequest{
+		PullRequestID:   "pr-wh-" + suffix,
+		PullRequestName: "hooks",
+		AuthorID:        "wh1_" + suffix,
+	})
+	if err != nil {
+		t.Fatalf("create PR: %v", err)
+	}
+
+	dispatcher := service.NewWebhookDispatcher(env.Repos.Webhooks, receiver.Client(), service.RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    time.Millisecond,
+	})
+
+	// Первая попытка падает, вторая (после backoff) должна пройти.
+	for i := 0; i < 2; i++ {
+		time.Sleep(10 * time.Millisecond)
+		if _, err := dispatcher.DeliverDue(ctx); err != nil {
+			t.Fatalf("deliver due: %v", err)
+		}
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 delivery attempts, got %d", len(got))
+	}
+	for _, g := range got {
+		if g.event != "pull_request.created" {
+			t.Fatalf("unexpected event %q", g.event)
+		}
+		if g.signature != service.Sign(secret, g.body) {
+			t.Fatalf("bad signature %q", g.signature)
+		}
+	}
+
+	deliveries, err := api.Deliveries(ctx, sub.SubscriptionID, 0)
+	if err != nil {
+		t.Fatalf("list deliveries: %v", err)
+	}
+	if len(deliveries) != 1 || deliveries[0].Status != "DELIVERED" || deliveries[0].Attempts != 2 {
+		t.Fatalf("unexpected deliveries: %+v", deliveries)
+	}
+
+	if _, err := api.ReplayDelivery(ctx, deliveries[0].DeliveryID); err != nil {
+		t.Fatalf("replay delivery: %v", err)
+	}
+	if _, err := dispatcher.DeliverDue(ctx); err != nil {
+		t.Fatalf("deliver due after replay: %v", err)
+	}
+	if len(got) != 3 || string(got[2].body) != string(got[0].body) {
+		t.Fatalf("replay should resend the same payload, got %d attempts", len(got))
+	}
+
+	// Повторная доставка снова падает; следующий replay не тащит за собой её ошибку.
+	failFirst = true
+	if _, err := api.ReplayDelivery(ctx, deliveries[0].DeliveryID); err != nil {
+		t.Fatalf("replay delivery: %v", err)
+	}
+	if _, err := dispatcher.DeliverDue(ctx); err != nil {
+		t.Fatalf("deliver due after replay: %v", err)
+	}
+	deliveries, err = api.Deliveries(ctx, sub.SubscriptionID, 0)
+	if err != nil {
+		t.Fatalf("list deliveries: %v", err)
+	}
+	if len(deliveries) != 1 || deliveries[0].Status != "PENDING" || deliveries[0].LastError == "" {
+		t.Fatalf("failed replay should keep the error until the next attempt: %+v", deliveries)
+	}
+	replayed, err := api.ReplayDelivery(ctx, deliveries[0].DeliveryID)
+	if err != nil {
+		t.Fatalf("replay delivery: %v", err)
+	}
+	if replayed.Status != "PENDING" || replayed.Attempts != 0 || replayed.LastError != "" {
+		t.Fatalf("replay should reset attempts and last error: %+v", replayed)
+	}
+
+	// Новый секрет действует и для доставки, поставленной в очередь до ротации.
+	const rotated = "e2e-secret-2"
+	if _, err := api.RotateWebhookSecret(ctx, sub.SubscriptionID, rotated); err != nil {
+		t.Fatalf("rotate secret: %v", err)
+	}
+	if _, err := dispatcher.DeliverDue(ctx); err != nil {
+		t.Fatalf("deliver due after rotation: %v", err)
+	}
+	if len(got) != 5 || got[4].signature != service.Sign(rotated, got[4].body) {
+		t.Fatalf("delivery after rotation should be signed with the new secret, got %d attempts", len(got))
+	}
+
+	// Выключение подписки хоронит её недоставленные события, диспетчер их больше не берёт.
+	if _, err := api.ReplayDelivery(ctx, deliveries[0].DeliveryID); err != nil {
+		t.Fatalf("replay delivery: %v", err)
+	}
+	off, err := api.SetWebhookActive(ctx, sub.SubscriptionID, false)
+	if err != nil {
+		t.Fatalf("deactivate subscription: %v", err)
+	}
+	if off.IsActive {
+		t.Fatalf("subscription should be inactive: %+v", off)
+	}
+	if _, err := dispatcher.DeliverDue(ctx); err != nil {
+		t.Fatalf("deliver due after deactivation: %v", err)
+	}
+	deliveries, err = api.Deliveries(ctx, sub.SubscriptionID, 0)
+	if err != nil {
+		t.Fatalf("list deliveries: %v", err)
+	}
+	if len(got) != 5 || len(deliveries) != 1 || deliveries[0].Status != "DEAD" || deliveries[0].LastError != "subscription deactivated" {
+		t.Fatalf("deactivation should kill pending deliveries: %d attempts, %+v", len(got), deliveries)
+	}
+
+	if _, err := api.SetWebhookActive(ctx, 1<<40, false); !errors.Is(err, apiclient.ErrNotFound) {
+		t.Fatalf("deactivating a missing subscription: want ErrNotFound, got %v", err)
+	}
+}
+
+// smtpMessage письмо, принятое фейковым SMTP-сервером.
+type smtpMessage struct {
+	to   string
+	data string
+	// tls письмо пришло после STARTTLS
+	tls bool
+}
+
+// selfSignedCert сертификат на 127.0.0.1 и пул корней, которому он доверен.
+func selfSignedCert(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "fake smtp"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(leaf)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}, roots
+}
+
+// startFakeSMTP поднимает минимальный SMTP-сервер без AUTH, который предлагает STARTTLS
+// и складывает письма в канал. roots — корни, которым доверять его сертификат.
+func startFakeSMTP(t *testing.T) (host string, port int, roots *x509.CertPool, messages <-chan smtpMessage) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen fake smtp: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	cert, roots := selfSignedCert(t)
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}}
+
+	out := make(chan smtpMessage, 16)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveFakeSMTP(conn, tlsConfig, out)
+		}
+	}()
+
+	addr := ln.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port, roots, out
+}
+
+func serveFakeSMTP(conn net.Conn, tlsConfig *tls.Config, out chan<- smtpMessage) {
+	defer func() { _ = conn.Close() }()
+
+	tp := textproto.NewConn(conn)
+	_ = tp.PrintfLine("220 fake ESMTP")
+
+	var (
+		msg   smtpMessage
+		tlsOn bool
+	)
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			if tlsOn {
+				_ = tp.PrintfLine("250 fake")
+			} else {
+				_ = tp.PrintfLine("250-fake")
+				_ = tp.PrintfLine("250 STARTTLS")
+			}
+		case cmd == "STARTTLS" && !tlsOn:
+			_ = tp.PrintfLine("220 ready to start TLS")
+			tlsConn := tls.Server(conn, tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn, tp, tlsOn = tlsConn, textproto.NewConn(tlsConn), true
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			msg = smtpMessage{tls: tlsOn}
+			_ = tp.PrintfLine("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			msg.to = strings.Trim(line[len("RCPT TO:"):], "<> ")
+			_ = tp.PrintfLine("250 OK")
+		case cmd == "DATA":
+			_ = tp.PrintfLine("354 go ahead")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			// склеиваем мягкие переносы quoted-printable, чтобы искать по тексту
+			msg.data = strings.ReplaceAll(string(data), "=\n", "")
+			out <- msg
+			_ = tp.PrintfLine("250 OK")
+		case cmd == "QUIT":
+			_ = tp.PrintfLine("221 bye")
+			return
+		default:
+			_ = tp.PrintfLine("250 OK")
+		}
+	}
+}
+
+// githubHook собирает событие pull_request: login — автор PR.
+func githubHook(t *testing.T, repo string, number int, action, login string, draft, merged bool) []byte {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]any{
+		"action": action,
+		"number": number,
+		"pull_request": map[string]any{
+			"title": "GitHub PR", "draft": draft, "merged": merged,
+			"user": map[string]any{"login": login},
+		},
+		"repository": map[string]any{"full_name": repo},
+	})
+	if err != nil {
+		t.Fatalf("marshal GitHub payload: %v", err)
+	}
+	return body
+}
+
+func TestE2E_GitHub(t *testing.T) {
+	ctx := context.Background()
+
+	db := newTestDB(t, ctx)
+	const githubSecret = "e2e-github-secret"
+	api := newTestAPI(t, db, testAPIOptions{Secrets: apihttp.IntegrationSecrets{GitHubWebhookSecret: githubSecret}}).Client
+
+	suffix := time.Now().Format("150405.000000")
+	author, bob, carol := "gha_"+suffix, "ghb_"+suffix, "ghc_"+suffix
+	_, err := api.CreateTeam(ctx, apiclient.CreateTeamRequest{
+		TeamName: "github_" + suffix,
+		Members: []apiclient.TeamMember{
+			{UserID: author, Username: "Alice", IsActive: true},
+			{UserID: bob, Username: "Bob", IsActive: true},
+			{UserID: carol, Username: "Carol", IsActive: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+	_, err = api.LinkIdentity(ctx, apiclient.Identity{Provider: apiclient.ProviderGitHub, Login: "alice_" + suffix, UserID: author})
+	if err != nil {
+		t.Fatalf("link identity: %v", err)
+	}
+
+	repo := "org/e2e-" + suffix
+	login := "alice_" + suffix
+	opened := githubHook(t, repo, 1, "opened", login, false, false)
+
+	for _, bad := range []struct {
+		name      string
+		signature string
+		payload   []byte
+	}{
+		{name: "missing signature", signature: "", payload: opened},
+		{name: "signature without prefix", signature: strings.TrimPrefix(service.Sign(githubSecret, opened), "sha256="), payload: opened},
+		{name: "signature is not hex", signature: "sha256=zz", payload: opened},
+		{name: "other secret", signature: service.Sign("other-secret", opened), payload: opened},
+		{name: "body changed after signing", signature: service.Sign(githubSecret, opened), payload: githubHook(t, repo, 2, "opened", login, false, false)},
+	} {
+		_, err := api.GitHubWebhook(ctx, "pull_request", bad.signature, bad.payload)
+		if !errors.Is(err, apiclient.ErrInvalidSignature) {
+			t.Fatalf("%s: expected %v, got %v", bad.name, apiclient.ErrInvalidSignature, err)
+		}
+	}
+
+	// неверная подпись ничего не создаёт
+	if got := reviewStatus(t, ctx, api, bob, "github:"+repo+"#1"); got != "" {
+		t.Fatalf("request with invalid signature created a PR: status %q", got)
+	}
+
+	ping := []byte(`{"zen":"Keep it logically awesome."}`)
+	result, err := api.GitHubWebhook(ctx, "ping", service.Sign(githubSecret, ping), ping)
+	if err != nil || result.Outcome != "ignored" || result.Reason != "unsupported event" {
+		t.Fatalf("ping: expected ignored, got %+v, %v", result, err)
+	}
+
+	steps := []struct {
+		name      string
+		payload   []byte
+		outcome   string
+		reason    string
+		reviewers map[string]apiclient.PRStatus
+	}{
+		{
+			name:    "opened by unknown login",
+			payload: githubHook(t, repo, 2, "opened", "stranger_"+suffix, false, false),
+			outcome: "ignored",
+			reason:  "author login is not linked to a user",
+		},
+		{
+			name:      "opened",
+			payload:   opened,
+			outcome:   "created",
+			reviewers: map[string]apiclient.PRStatus{bob: apiclient.PRStatusOpen, carol: apiclient.PRStatusOpen, author: ""},
+		},
+		{
+			name:    "opened again",
+			payload: opened,
+			outcome: "ignored",
+			reason:  "pull request already exists",
+		},
+		{
+			name:      "closed without merge",
+			payload:   githubHook(t, repo, 1, "closed", login, false, false),
+			outcome:   "closed",
+			reviewers: map[string]apiclient.PRStatus{bob: apiclient.PRStatusClosed, carol: apiclient.PRStatusClosed},
+		},
+		{
+			name:      "reopened",
+			payload:   githubHook(t, repo, 1, "reopened", login, false, false),
+			outcome:   "reopened",
+			reviewers: map[string]apiclient.PRStatus{bob: apiclient.PRStatusOpen, carol: apiclient.PRStatusOpen},
+		},
+		{
+			name:      "merged",
+			payload:   githubHook(t, repo, 1, "closed", login, false, true),
+			outcome:   "merged",
+			reviewers: map[string]apiclient.PRStatus{bob: apiclient.PRStatusMerged, carol: apiclient.PRStatusMerged},
+		},
+		{
+			name:    "draft opened",
+			payload: githubHook(t, repo, 3, "opened", login, true, false),
+			outcome: "ignored",
+			reason:  "unsupported action",
+		},
+		{
+			name:      "draft ready for review",
+			payload:   githubHook(t, repo, 3, "ready_for_review", login, false, false),
+			outcome:   "created",
+			reviewers: map[string]apiclient.PRStatus{bob: apiclient.PRStatusOpen, carol: apiclient.PRStatusOpen},
+		},
+	}
+	for _, step := range steps {
+		result, err := api.GitHubWebhook(ctx, "pull_request", service.Sign(githubSecret, step.payload), step.payload)
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if result.Outcome != step.outcome || result.Reason != step.reason {
+			t.Fatalf("%s: expected %s %q, got %+v", step.name, step.outcome, step.reason, result)
+		}
+		for userID, want := range step.reviewers {
+			if got := reviewStatus(t, ctx, api, userID, result.PullRequestID); got != want {
+				t.Fatalf("%s: PR %s in %s's queue with status %q, want %q", step.name, result.PullRequestID, userID, got, want)
+			}
+		}
+	}
+}
+
+// gitlabHook собирает Merge Request Hook: actor — тот, кто совершил действие, author — автор MR.
+func gitlabHook(t *testing.T, project string, iid int, action string, actorID int, actorLogin string, authorID int, reviewers []string, reviewersChanged bool) []byte {
+	t.Helper()
+
+	type user struct {
+		ID       int    `json:"id"`
+		Username string `json:"username"`
+	}
+	payload := map[string]any{
+		"object_kind": "merge_request",
+		"user":        user{ID: actorID, Username: actorLogin},
+		"project":     map[string]any{"path_with_namespace": project},
+		"object_attributes": map[string]any{
+			"iid": iid, "author_id": authorID, "title": "GitLab MR", "action": action, "draft": false,
+		},
+	}
+	var users []user
+	for i, login := range reviewers {
+		users = append(users, user{ID: 1000 + i, Username: login})
+	}
+	payload["reviewers"] = users
+	if reviewersChanged {
+		payload["changes"] = map[string]any{"reviewers": map[string]any{"previous": []user{}, "current": users}}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal GitLab payload: %v", err)
+	}
+	return body
+}
+
+// reviewStatus статус PR в очереди ревьювера; "" — PR ему не назначен.
+func reviewStatus(t *testing.T, ctx context.Context, api *apiclient.Client, userID, prID string) apiclient.PRStatus {
+	t.Helper()
+
+	reviews, err := api.GetReview(ctx, userID)
+	if err != nil {
+		t.Fatalf("get reviews of %s: %v", userID, err)
+	}
+	for _, pr := range reviews.PullRequests {
+		if pr.PullRequestID == prID {
+			return pr.Status
+		}
+	}
+	return ""
+}
+
+func TestE2E_GitLab(t *testing.T) {
+	ctx := context.Background()
+
+	db := newTestDB(t, ctx)
+	const gitlabToken = "e2e-gitlab-token"
+	api := newTestAPI(t, db, testAPIOptions{Secrets: apihttp.IntegrationSecrets{GitLabWebhookToken: gitlabToken}}).Client
+
+	suffix := time.Now().Format("150405.000000")
+	author, bob, carol, dave, erin := "gla_"+suffix, "glb_"+suffix, "glc_"+suffix, "gld_"+suffix, "gle_"+suffix
+	_, err := api.CreateTeam(ctx, apiclient.CreateTeamRequest{
+		TeamName: "gitlab_" + suffix,
+		Members: []apiclient.TeamMember{
+			{UserID: author, Username: "Alice", IsActive: true},
+			{UserID: bob, Username: "Bob", IsActive: true},
+			{UserID: carol, Username: "Carol", IsActive: true},
+			{UserID: dave, Username: "Dave", IsActive: true},
+			{UserID: erin, Username: "Erin", IsActive: false},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+
+	// автор привязан по id на GitLab, ревьюверы — только по логину
+	const authorGitLabID, botGitLabID, daveGitLabID, unknownGitLabID = 9101, 9102, 9103, 9199
+	for _, identity := range []apiclient.Identity{
+		{Provider: apiclient.ProviderGitLab, Login: "alice_" + suffix, ExternalID: "9101", UserID: author},
+		{Provider: apiclient.ProviderGitLab, Login: "bob_" + suffix, UserID: bob},
+		{Provider: apiclient.ProviderGitLab, Login: "carol_" + suffix, UserID: carol},
+		{Provider: apiclient.ProviderGitLab, Login: "dave_" + suffix, UserID: dave},
+		{Provider: apiclient.ProviderGitLab, Login: "erin_" + suffix, UserID: erin},
+	} {
+		if _, err := api.LinkIdentity(ctx, identity); err != nil {
+			t.Fatalf("link identity %s: %v", identity.Login, err)
+		}
+	}
+
+	project := "group/e2e-" + suffix
+	hook := func(action string, actorID int, authorID int, reviewers []string, reviewersChanged bool) []byte {
+		return gitlabHook(t, project, 1, action, actorID, "bot_"+suffix, authorID, reviewers, reviewersChanged)
+	}
+
+	_, err = api.GitLabWebhook(ctx, "wrong-token", hook("open", botGitLabID, authorGitLabID, nil, false))
+	if !errors.Is(err, apiclient.ErrInvalidSignature) {
+		t.Fatalf("wrong X-Gitlab-Token: expected %v, got %v", apiclient.ErrInvalidSignature, err)
+	}
+
+	steps := []struct {
+		name      string
+		payload   []byte
+		outcome   string
+		reason    string
+		reviewers map[string]apiclient.PRStatus
+	}{
+		{
+			name:    "open by unknown author",
+			payload: gitlabHook(t, project, 2, "open", botGitLabID, "bot_"+suffix, unknownGitLabID, nil, false),
+			outcome: "ignored",
+			reason:  "author login is not linked to a user",
+		},
+		{
+			// MR открыл не автор: логин инициатора автору не принадлежит, автор находится по author_id
+			name:      "open by someone else",
+			payload:   hook("open", botGitLabID, authorGitLabID, []string{"bob_" + suffix}, false),
+			outcome:   "created",
+			reviewers: map[string]apiclient.PRStatus{bob: apiclient.PRStatusOpen, carol: "", author: ""},
+		},
+		{
+			// неактивного ревьювера из GitLab не назначаем, как и непривязанный логин
+			name:      "reviewers changed",
+			payload:   hook("update", botGitLabID, authorGitLabID, []string{"carol_" + suffix, "erin_" + suffix, "unlinked_" + suffix}, true),
+			outcome:   "reviewers_synced",
+			reviewers: map[string]apiclient.PRStatus{bob: "", carol: apiclient.PRStatusOpen, erin: ""},
+		},
+		{
+			name:      "close",
+			payload:   hook("close", botGitLabID, authorGitLabID, nil, false),
+			outcome:   "closed",
+			reviewers: map[string]apiclient.PRStatus{carol: apiclient.PRStatusClosed},
+		},
+		{
+			name:      "reopen",
+			payload:   hook("reopen", botGitLabID, authorGitLabID, nil, false),
+			outcome:   "reopened",
+			reviewers: map[string]apiclient.PRStatus{carol: apiclient.PRStatusOpen},
+		},
+		{
+			name:      "merge",
+			payload:   hook("merge", botGitLabID, authorGitLabID, nil, false),
+			outcome:   "merged",
+			reviewers: map[string]apiclient.PRStatus{carol: apiclient.PRStatusMerged},
+		},
+		{
+			name:    "reopen after merge",
+			payload: hook("reopen", botGitLabID, authorGitLabID, nil, false),
+			outcome: "ignored",
+			reason:  "pull request is already merged",
+		},
+		{
+			// без external_id автор находится по логину, если MR открыл он сам
+			name:      "open by author linked by login",
+			payload:   gitlabHook(t, project, 3, "open", daveGitLabID, "dave_"+suffix, daveGitLabID, []string{"carol_" + suffix}, false),
+			outcome:   "created",
+			reviewers: map[string]apiclient.PRStatus{carol: apiclient.PRStatusOpen},
+		},
+	}
+	for _, step := range steps {
+		result, err := api.GitLabWebhook(ctx, gitlabToken, step.payload)
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if result.Outcome != step.outcome || result.Reason != step.reason {
+			t.Fatalf("%s: expected %s %q, got %+v", step.name, step.outcome, step.reason, result)
+		}
+		for userID, want := range step.reviewers {
+			if got := reviewStatus(t, ctx, api, userID, result.PullRequestID); got != want {
+				t.Fatalf("%s: PR %s in %s's queue with status %q, want %q", step.name, result.PullRequestID, userID, got, want)
+			}
+		}
+	}
+}
+
+func TestE2E_Email(t *testing.T) {
+	ctx := context.Background()
+
+	db := newTestDB(t, ctx)
+	repos := app.NewRepositories(db)
+
+	smtpHost, smtpPort, smtpRoots, messages := startFakeSMTP(t)
+	emailNotifier := service.NewEmailNotifier(repos.Users, repos.PRs, service.SMTPSettings{
+		Host:      smtpHost,
+		Port:      smtpPort,
+		From:      "noreply@example.com",
+		Timeout:   5 * time.Second,
+		TLSConfig: &tls.Config{RootCAs: smtpRoots},
+	})
+
+	server := newTestAPI(t, db, testAPIOptions{Publishers: []app.EventPublisher{emailNotifier}}).Server
+	client := bearerClient(server, e2eAdminToken)
+
+	post := func(path, body string) (int, []byte) {
+		t.Helper()
+		resp, err := client.Post(server.URL+path, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("%s request failed: %v", path, err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		b, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, b
+	}
+
+	suffix := time.Now().Format("150405.000000")
+	authorID, reviewerID := "em1_"+suffix, "em2_"+suffix
+	reviewerEmail := "reviewer_" + suffix + "@example.com"
+
+	status, body := post("/team/add", `{"team_name":"mail_`+suffix+`","members":[
+		{"user_id":"`+authorID+`","username":"Author","is_active":true,"email":"author_`+suffix+`@example.com"},
+		{"user_id":"`+reviewerID+`","username":"Reviewer","is_active":true}]}`)
+	if status != http.StatusCreated {
+		t.Fatalf("unexpected status %d for team/add, body: %s", status, body)
+	}
+
+	status, body = post("/users/setEmailSettings", `{"user_id":"`+reviewerID+`","email":"not an email"}`)
+	if status != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid email, got %d, body: %s", status, body)
+	}
+	status, body = post("/users/setEmailSettings", `{"user_id":"`+reviewerID+`","email":"`+reviewerEmail+`"}`)
+	if status != http.StatusOK {
+		t.Fatalf("unexpected status %d for users/setEmailSettings, body: %s", status, body)
+	}
+
+	status, body = post("/pullRequest/create", `{"pull_request_id":"pr-mail-`+suffix+`",
+		"pull_request_name":"mail","author_id":"`+authorID+`"}`)
+	if status != http.StatusCreated {
+		t.Fatalf("unexpected status %d for create PR, body: %s", status, body)
+	}
+
+	// Назначенный ревьювер получает письмо; автор не назначен — ему не пишем.
+	select {
+	case m := <-messages:
+		if m.to != reviewerEmail {
+			t.Fatalf("assignment email sent to %q, want %q", m.to, reviewerEmail)
+		}
+		if !m.tls {
+			t.Fatal("assignment email was sent without STARTTLS")
+		}
+		if !strings.Contains(m.data, "multipart/alternative") || !strings.Contains(m.data, "pr-mail-"+suffix) {
+			t.Fatalf("unexpected assignment email:\n%s", m.data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("assignment email was not sent")
+	}
+
+	if _, err := emailNotifier.SendDigests(ctx); err != nil {
+		t.Fatalf("send digests: %v", err)
+	}
+
+	// в общей БД могут быть получатели из прошлых прогонов — ищем своё письмо
+	found := false
+	for !found {
+		select {
+		case m := <-messages:
+			if m.to == reviewerEmail {
+				if !strings.Contains(m.data, "pr-mail-"+suffix) {
+					t.Fatalf("digest does not list the pending PR:\n%s", m.data)
+				}
+				found = true
+			}
+		case <-time.After(time.Second):
+			t.Fatal("digest email was not sent to the reviewer")
+		}
+	}
+
+	// после отписки дайджест не приходит
+	status, body = post("/users/setEmailSettings", `{"user_id":"`+reviewerID+`","email":"`+reviewerEmail+`",
+		"opt_out_daily_digest":true}`)
+	if status != http.StatusOK {
+		t.Fatalf("unexpected status %d for users/setEmailSettings, body: %s", status, body)
+	}
+	for len(messages) > 0 {
+		<-messages
+	}
+	if _, err := emailNotifier.SendDigests(ctx); err != nil {
+		t.Fatalf("send digests: %v", err)
+	}
+	for len(messages) > 0 {
+		if m := <-messages; m.to == reviewerEmail {
+			t.Fatal("digest sent after opt-out")
+		}
+	}
+}
+
+// sseEvent одно событие из text/event-stream.
+type sseEvent struct {
+	id    string
+	event string
+	data  string
+}
+
+// readSSE читает следующее событие с data, пропуская комментарии и блоки только с id.
+func readSSE(t *testing.T, r *bufio.Reader) sseEvent {
+	t.Helper()
+
+	var ev sseEvent
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read sse: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case line == "":
+			if ev.data != "" {
+				return ev
+			}
+			ev = sseEvent{}
+		case strings.HasPrefix(line, "id: "):
+			ev.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			ev.event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			ev.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestE2E_ReviewStream(t *testing.T) {
+	ctx := context.Background()
+
+	db := newTestDB(t, ctx)
+	server := newTestAPI(t, db, testAPIOptions{Bus: app.NewEventBus(64)}).Server
+	client := bearerClient(server, e2eAdminToken)
+
+	post := func(path, body string) {
+		t.Helper()
+		resp, err := client.Post(server.URL+path, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("%s request failed: %v", path, err)
+		}
+		b, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			t.Fatalf("unexpected status %d for %s, body: %s", resp.StatusCode, path, b)
+		}
+	}
+
+	suffix := time.Now().Format("150405.000000")
+	authorID, reviewerID := "ss1_"+suffix, "ss2_"+suffix
+
+	post("/team/add", `{"team_name":"stream_`+suffix+`","members":[
+		{"user_id":"`+authorID+`","username":"A","is_active":true},
+		{"user_id":"`+reviewerID+`","username":"B","is_active":true}]}`)
+
+	open := func(lastEventID string) (*bufio.Reader, func()) {
+		t.Helper()
+		streamCtx, cancel := context.WithCancel(ctx)
+		req, _ := http.NewRequestWithContext(streamCtx, http.MethodGet, server.URL+"/users/reviewStream?user_id="+reviewerID, nil)
+		if lastEventID != "" {
+			req.Header.Set("Last-Event-ID", lastEventID)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("reviewStream request failed: %v", err)
+		}
+		if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+			t.Fatalf("unexpected reviewStream response: %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+		}
+		return bufio.NewReader(resp.Body), func() {
+			cancel()
+			_ = resp.Body.Close()
+		}
+	}
+
+	stream, closeStream := open("")
+	if ev := readSSE(t, stream); ev.event != "snapshot" {
+		t.Fatalf("expected snapshot first, got %+v", ev)
+	}
+
+	prID := "pr-stream-" + suffix
+	post("/pullRequest/create", `{"pull_request_id":"`+prID+`","pull_request_name":"stream","author_id":"`+authorID+`"}`)
+
+	assigned := readSSE(t, stream)
+	if assigned.event != "reviewer.assigned" || assigned.id == "" || !strings.Contains(assigned.data, prID) {
+		t.Fatalf("unexpected event: %+v", assigned)
+	}
+	closeStream()
+
+	// пока клиент отключён, PR мержат; после переподключения событие досылается из истории
+	post("/pullRequest/merge", `{"pull_request_id":"`+prID+`"}`)
+
+	stream, closeStream = open(assigned.id)
+	defer closeStream()
+	merged := readSSE(t, stream)
+	if merged.event != "pull_request.merged" || !strings.Contains(merged.data, prID) {
+		t.Fatalf("expected replayed merge, got %+v", merged)
+	}
+}
+
+func TestE2E_GRPC(t *testing.T) {
+	ctx := context.Background()
+
+	db := newTestDB(t, ctx)
+	bus := app.NewEventBus(64)
+	env := newTestAPI(t, db, testAPIOptions{Bus: bus})
+	webhookSvc, tokenSvc := env.Webhooks, env.Tokens
+
+	srv := apigrpc.NewServer(env.Teams, env.Users, env.PRs, webhookSvc, tokenSvc, bus, time.Minute)
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc dial failed: %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	teams := reviewerv1.NewTeamServiceClient(conn)
+	prs := reviewerv1.NewPullRequestServiceClient(conn)
+	withToken := func(ctx context.Context, token string) context.Context {
+		return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+	}
+	admin := withToken(ctx, e2eAdminToken)
+
+	if _, err := prs.GetFairnessReport(ctx, &reviewerv1.GetFairnessReportRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected UNAUTHENTICATED without token, got %v", err)
+	}
+
+	suffix := time.Now().Format("150405.000000")
+	authorID, reviewerID := "g1_"+suffix, "g2_"+suffix
+	_, err = teams.CreateTeam(admin, &reviewerv1.CreateTeamRequest{Team: &reviewerv1.Team{
+		TeamName: "grpc_" + suffix,
+		Members: []*reviewerv1.User{
+			{UserId: authorID, Username: "A", IsActive: true},
+			{UserId: reviewerID, Username: "B", IsActive: true},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("CreateTeam failed: %v", err)
+	}
+
+	_, reviewerToken, err := tokenSvc.Issue(ctx, domain.APIToken{Scope: domain.ScopeUser, UserID: reviewerID, Name: "grpc e2e"})
+	if err != nil {
+		t.Fatalf("issue user token: %v", err)
+	}
+
+	// чужие события user-токену не отдаются
+	other, err := prs.WatchAssignments(withToken(ctx, reviewerToken), &reviewerv1.WatchAssignmentsRequest{ReviewerId: authorID})
+	if err == nil {
+		_, err = other.Recv()
+	}
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PERMISSION_DENIED for someone else's events, got %v", err)
+	}
+
+	watchCtx, cancel := context.WithTimeout(withToken(ctx, reviewerToken), 10*time.Second)
+	defer cancel()
+	watch, err := prs.WatchAssignments(watchCtx, &reviewerv1.WatchAssignmentsRequest{ReviewerId: reviewerID})
+	if err != nil {
+		t.Fatalf("WatchAssignments failed: %v", err)
+	}
+	// заголовки приходят после подписки на шину: дальше ничего не потеряется
+	if _, err := watch.Header(); err != nil {
+		t.Fatalf("WatchAssignments header: %v", err)
+	}
+
+	prID := "pr-grpc-" + suffix
+	created, err := prs.CreatePullRequest(admin, &reviewerv1.CreatePullRequestRequest{
+		PullRequestId: prID, PullRequestName: "grpc", AuthorId: authorID,
+	})
+	if err != nil {
+		t.Fatalf("CreatePullRequest failed: %v", err)
+	}
+	if got := created.GetPullRequest().GetAssignedReviewers(); len(got) != 1 || got[0] != reviewerID {
+		t.Fatalf("unexpected reviewers: %v", got)
+	}
+
+	ev, err := watch.Recv()
+	if err != nil {
+		t.Fatalf("WatchAssignments recv failed: %v", err)
+	}
+	if ev.GetType() != reviewerv1.AssignmentEventType_ASSIGNMENT_EVENT_TYPE_ASSIGNED ||
+		ev.GetReviewerId() != reviewerID || ev.GetPullRequest().GetPullRequestId() != prID {
+		t.Fatalf("unexpected event: %v", ev)
+	}
+
+	_, err = prs.CreatePullRequest(admin, &reviewerv1.CreatePullRequestRequest{
+		PullRequestId: prID, PullRequestName: "grpc", AuthorId: authorID,
+	})
+	st := status.Convert(err)
+	if st.Code() != codes.AlreadyExists {
+		t.Fatalf("expected ALREADY_EXISTS for duplicate PR, got %v", err)
+	}
+	var reason string
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			reason = info.GetReason()
+		}
+	}
+	if reason != string(apihttp.CodePRExists) {
+		t.Fatalf("expected ErrorInfo reason PR_EXISTS, got %q", reason)
+	}
+
+	if _, err := prs.CreatePullRequest(admin, &reviewerv1.CreatePullRequestRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected INVALID_ARGUMENT for empty request, got %v", err)
+	}
+
+	// подписками на вебхуки управляет только admin
+	sub, err := webhookSvc.Subscribe(ctx, domain.WebhookSubscription{
+		TeamName: "grpc_" + suffix, URL: "http://127.0.0.1:1/hook", Secret: "s1",
+	})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	hooks := reviewerv1.NewWebhookServiceClient(conn)
+	deactivate := &reviewerv1.SetSubscriptionActiveRequest{SubscriptionId: sub.ID, IsActive: false}
+	if _, err := hooks.SetSubscriptionActive(withToken(ctx, reviewerToken), deactivate); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PERMISSION_DENIED for user token, got %v", err)
+	}
+	off, err := hooks.SetSubscriptionActive(admin, deactivate)
+	if err != nil {
+		t.Fatalf("SetSubscriptionActive failed: %v", err)
+	}
+	if off.GetSubscription().GetIsActive() || off.GetSubscription().GetSubscriptionId() != sub.ID {
+		t.Fatalf("unexpected subscription: %v", off.GetSubscription())
+	}
+	if _, err := hooks.RotateSubscriptionSecret(admin, &reviewerv1.RotateSubscriptionSecretRequest{SubscriptionId: sub.ID}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected INVALID_ARGUMENT for empty secret, got %v", err)
+	}
+	if _, err := hooks.RotateSubscriptionSecret(admin, &reviewerv1.RotateSubscriptionSecretRequest{SubscriptionId: sub.ID, Secret: "s2"}); err != nil {
+		t.Fatalf("RotateSubscriptionSecret failed: %v", err)
+	}
+}
+
+// backdatePR сдвигает создание PR и назначения его ревьюверов на age назад: так у статистики известная шкала времени.
+func backdatePR(t *testing.T, db *sql.DB, prID string, age time.Duration) {
+	t.Helper()
+
+	for _, query := range []string{
+		`UPDATE pull_requests SET created_at = now() - $2 * interval '1 second' WHERE pull_request_id = $1`,
+		`UPDATE pr_reviewers SET assigned_at = now() - $2 * interval '1 second' WHERE pull_request_id = $1`,
+	} {
+		if _, err := db.Exec(query, prID, age.Seconds()); err != nil {
+			t.Fatalf("backdate %s: %v", prID, err)
+		}
+	}
+}
+
+// assertPercentiles сверяет перцентили с точностью до секунды: между сдвигом времени и вердиктом проходят миллисекунды.
+func assertPercentiles(t *testing.T, name string, got apiclient.Percentiles, count int64, p50, p90, p99 float64) {
+	t.Helper()
+
+	near := func(a, b float64) bool { return a > b-1 && a < b+1 }
+	if got.Count != count || !near(got.P50Seconds, p50) || !near(got.P90Seconds, p90) || !near(got.P99Seconds, p99) {
+		t.Fatalf("%s: got %+v, want count %d p50 %v p90 %v p99 %v", name, got, count, p50, p90, p99)
+	}
+}
+
+func TestE2E_Stats(t *testing.T) {
+	ctx := context.Background()
+
+	db := newTestDB(t, ctx)
+	env := newTestAPI(t, db, testAPIOptions{})
+	server, api := env.Server, env.Client
+
+	// отдельная команда на прогон: статистика фильтруется по ней и не видит данных других тестов
+	suffix := time.Now().Format("150405.000000")
+	team := "stats_" + suffix
+	author, reviewer := "sa_"+suffix, "sr_"+suffix
+	_, err := api.CreateTeam(ctx, apiclient.CreateTeamRequest{
+		TeamName: team,
+		Members: []apiclient.TeamMember{
+			{UserID: author, Username: "Author", IsActive: true},
+			{UserID: reviewer, Username: "Reviewer", IsActive: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+
+	t.Run("latency", func(t *testing.T) {
+		// PR ждали первого вердикта 1, 2 и 4 часа, смержен только первый — через час после создания
+		ages := []time.Duration{time.Hour, 2 * time.Hour, 4 * time.Hour}
+		prIDs := make([]string, 0, len(ages))
+		for i, age := range ages {
+			prID := "pr-latency-" + strconv.Itoa(i) + "-" + suffix
+			if _, err := api.CreatePullRequest(ctx, apiclient.CreatePullRequestRequest{
+				PullRequestID: prID, PullRequestName: "Latency", AuthorID: author,
+			}); err != nil {
+				t.Fatalf("create %s: %v", prID, err)
+			}
+			backdatePR(t, db, prID, age)
+			if _, err := api.SubmitReview(ctx, prID, reviewer, apiclient.VerdictChangesRequested); err != nil {
+				t.Fatalf("first verdict on %s: %v", prID, err)
+			}
+			prIDs = append(prIDs, prID)
+		}
+		if _, err := api.MergePullRequest(ctx, prIDs[0]); err != nil {
+			t.Fatalf("merge %s: %v", prIDs[0], err)
+		}
+
+		// повторный вердикт позже не должен сдвигать время первого
+		time.Sleep(2 * time.Second)
+		for _, prID := range prIDs[1:] {
+			if _, err := api.SubmitReview(ctx, prID, reviewer, apiclient.VerdictApproved); err != nil {
+				t.Fatalf("second verdict on %s: %v", prID, err)
+			}
+		}
+
+		report, err := api.LatencyStats(ctx, apiclient.LatencyFilter{Team: team})
+		if err != nil {
+			t.Fatalf("latency stats: %v", err)
+		}
+		// percentile_cont по 3600, 7200, 14400: p90 = 7200 + 0.8 * 7200, p99 = 7200 + 0.98 * 7200
+		assertPercentiles(t, "overall time to first verdict", report.Overall.TimeToFirstVerdict, 3, 7200, 12960, 14256)
+		assertPercentiles(t, "overall time to merge", report.Overall.TimeToMerge, 1, 3600, 3600, 3600)
+		if len(report.ByReviewer) != 1 || report.ByReviewer[0].Key != reviewer {
+			t.Fatalf("unexpected by_reviewer: %+v", report.ByReviewer)
+		}
+		assertPercentiles(t, "reviewer time to first verdict", report.ByReviewer[0].TimeToFirstVerdict, 3, 7200, 12960, 14256)
+		if len(report.ByTeam) != 1 || report.ByTeam[0].Key != team || len(report.ByAuthor) != 1 || report.ByAuthor[0].Key != author {
+			t.Fatalf("unexpected by_team/by_author: %+v %+v", report.ByTeam, report.ByAuthor)
+		}
+	})
+
+	t.Run("fairness counts reassigned reviewers", func(t *testing.T) {
+		fairTeam := "fair_" + suffix
+		members := []string{"fa_" + suffix, "f1_" + suffix, "f2_" + suffix, "f3_" + suffix}
+		req := apiclient.CreateTeamRequest{TeamName: fairTeam}
+		for _, id := range members {
+			req.Members = append(req.Members, apiclient.TeamMember{UserID: id, Username: id, IsActive: true})
+		}
+		if _, err := api.CreateTeam(ctx, req); err != nil {
+			t.Fatalf("create team: %v", err)
+		}
+
+		pr, err := api.CreatePullRequest(ctx, apiclient.CreatePullRequestRequest{
+			PullRequestID: "pr-fair-" + suffix, PullRequestName: "Fairness", AuthorID: members[0],
+		})
+		if err != nil || len(pr.AssignedReviewers) != 2 {
+			t.Fatalf("create PR: %+v, err %v", pr, err)
+		}
+		// снятый reassign'ом ревьювер остаётся в исторической нагрузке
+		if _, err := api.ReassignReviewer(ctx, pr.PullRequestID, pr.AssignedReviewers[0]); err != nil {
+			t.Fatalf("reassign: %v", err)
+		}
+
+		report, err := api.FairnessReport(ctx, fairTeam)
+		if err != nil || len(report) != 1 {
+			t.Fatalf("fairness report: %+v, err %v", report, err)
+		}
+		var open, total int64
+		for _, m := range report[0].Members {
+			open += m.OpenAssignments
+			total += m.TotalAssignments
+			if m.UserID == pr.AssignedReviewers[0] && (m.OpenAssignments != 0 || m.TotalAssignments != 1) {
+				t.Fatalf("replaced reviewer: %+v, want 0 open and 1 total", m)
+			}
+		}
+		if open != 2 || total != 3 {
+			t.Fatalf("expected 2 open and 3 total assignments, got %d and %d: %+v", open, total, report[0].Members)
+		}
+	})
+
+	t.Run("assignments filters", func(t *testing.T) {
+		// две команды по автору и двум ревьюверам: кандидатов ровно два, назначаются оба
+		asgTeam, otherTeam := "asg_"+suffix, "asgo_"+suffix
+		r1, r2, o1, o2 := "as1_"+suffix, "as2_"+suffix, "ao1_"+suffix, "ao2_"+suffix
+		for _, req := range []apiclient.CreateTeamRequest{
+			{TeamName: asgTeam, Members: []apiclient.TeamMember{
+				{UserID: "asa_" + suffix, Username: "Author", IsActive: true},
+				{UserID: r1, Username: "R1", IsActive: true},
+				{UserID: r2, Username: "R2", IsActive: true},
+			}},
+			{TeamName: otherTeam, Members: []apiclient.TeamMember{
+				{UserID: "aoa_" + suffix, Username: "Author", IsActive: true},
+				{UserID: o1, Username: "O1", IsActive: true},
+				{UserID: o2, Username: "O2", IsActive: true},
+			}},
+		} {
+			if _, err := api.CreateTeam(ctx, req); err != nil {
+				t.Fatalf("create team %s: %v", req.TeamName, err)
+			}
+		}
+
+		// вт 7 и ср 8 января — одна неделя (с пн 6-го), вт 14-го — следующая; B смержен
+		prA, prB, prC, prO := "pr-asg-a-"+suffix, "pr-asg-b-"+suffix, "pr-asg-c-"+suffix, "pr-asg-o-"+suffix
+		for _, pr := range []struct {
+			id, author string
+			assigned   time.Time
+		}{
+			{prA, "asa_" + suffix, time.Date(2025, 1, 7, 10, 0, 0, 0, time.UTC)},
+			{prB, "asa_" + suffix, time.Date(2025, 1, 8, 15, 0, 0, 0, time.UTC)},
+			{prC, "asa_" + suffix, time.Date(2025, 1, 14, 9, 0, 0, 0, time.UTC)},
+			{prO, "aoa_" + suffix, time.Date(2025, 1, 8, 15, 0, 0, 0, time.UTC)},
+		} {
+			if _, err := api.CreatePullRequest(ctx, apiclient.CreatePullRequestRequest{
+				PullRequestID: pr.id, PullRequestName: "Assignments", AuthorID: pr.author,
+			}); err != nil {
+				t.Fatalf("create %s: %v", pr.id, err)
+			}
+			if _, err := db.Exec(`UPDATE pr_reviewers SET assigned_at = $2 WHERE pull_request_id = $1`, pr.id, pr.assigned); err != nil {
+				t.Fatalf("set assigned_at of %s: %v", pr.id, err)
+			}
+		}
+		if _, err := api.MergePullRequest(ctx, prB); err != nil {
+			t.Fatalf("merge %s: %v", prB, err)
+		}
+
+		// строка статистики как "id@период=число", без периода — "id=число"
+		row := func(id string, period *time.Time, n int64) string {
+			if period == nil {
+				return id + "=" + strconv.FormatInt(n, 10)
+			}
+			return id + "@" + period.UTC().Format(time.DateOnly) + "=" + strconv.FormatInt(n, 10)
+		}
+		date := func(s string) *time.Time {
+			d, _ := time.Parse(time.DateOnly, s)
+			return &d
+		}
+
+		cases := []struct {
+			name       string
+			filter     apiclient.AssignmentStatsFilter
+			byReviewer []string
+			byPR       []string
+		}{
+			{
+				name:       "team",
+				filter:     apiclient.AssignmentStatsFilter{Team: asgTeam},
+				byReviewer: []string{r1 + "=3", r2 + "=3"},
+				byPR:       []string{prA + "=2", prB + "=2", prC + "=2"},
+			},
+			{
+				name:       "other team",
+				filter:     apiclient.AssignmentStatsFilter{Team: otherTeam},
+				byReviewer: []string{o1 + "=1", o2 + "=1"},
+				byPR:       []string{prO + "=2"},
+			},
+			{
+				// from включается, to — нет: C назначен 14-го в 09:00
+				name: "period",
+				filter: apiclient.AssignmentStatsFilter{Team: asgTeam,
+					TimeWindow: apiclient.TimeWindow{From: date("2025-01-08"), To: date("2025-01-14")}},
+				byReviewer: []string{r1 + "=1", r2 + "=1"},
+				byPR:       []string{prB + "=2"},
+			},
+			{
+				name:       "status",
+				filter:     apiclient.AssignmentStatsFilter{Team: asgTeam, Status: apiclient.PRStatusMerged},
+				byReviewer: []string{r1 + "=1", r2 + "=1"},
+				byPR:       []string{prB + "=2"},
+			},
+			{
+				name:   "day",
+				filter: apiclient.AssignmentStatsFilter{Team: asgTeam, GroupBy: apiclient.GroupByDay},
+				byReviewer: []string{
+					r1 + "@2025-01-07=1", r2 + "@2025-01-07=1",
+					r1 + "@2025-01-08=1", r2 + "@2025-01-08=1",
+					r1 + "@2025-01-14=1", r2 + "@2025-01-14=1",
+				},
+				byPR: []string{prA + "@2025-01-07=2", prB + "@2025-01-08=2", prC + "@2025-01-14=2"},
+			},
+			{
+				name:   "week",
+				filter: apiclient.AssignmentStatsFilter{Team: asgTeam, Status: apiclient.PRStatusOpen, GroupBy: apiclient.GroupByWeek},
+				byReviewer: []string{
+					r1 + "@2025-01-06=1", r2 + "@2025-01-06=1",
+					r1 + "@2025-01-13=1", r2 + "@2025-01-13=1",
+				},
+				byPR: []string{prA + "@2025-01-06=2", prC + "@2025-01-13=2"},
+			},
+		}
+		for _, c := range cases {
+			stats, err := api.AssignmentStats(ctx, c.filter)
+			if err != nil {
+				t.Fatalf("%s: assignment stats: %v", c.name, err)
+			}
+			byReviewer := make([]string, 0, len(stats.ByReviewer))
+			for _, s := range stats.ByReviewer {
+				byReviewer = append(byReviewer, row(s.UserID, s.Period, s.Assignments))
+			}
+			byPR := make([]string, 0, len(stats.ByPR))
+			for _, s := range stats.ByPR {
+				byPR = append(byPR, row(s.PullRequestID, s.Period, s.Assignments))
+			}
+			if !reflect.DeepEqual(byReviewer, c.byReviewer) || !reflect.DeepEqual(byPR, c.byPR) {
+				t.Fatalf("%s: got by_reviewer %v, by_pr %v; want %v, %v", c.name, byReviewer, byPR, c.byReviewer, c.byPR)
+			}
+		}
+
+		for _, bad := range []struct {
+			filter apiclient.AssignmentStatsFilter
+			field  string
+		}{
+			{filter: apiclient.AssignmentStatsFilter{Team: asgTeam, GroupBy: "month"}, field: "group_by"},
+			{filter: apiclient.AssignmentStatsFilter{Team: asgTeam, Status: "DRAFT"}, field: "status"},
+			{filter: apiclient.AssignmentStatsFilter{TimeWindow: apiclient.TimeWindow{From: date("2025-01-14"), To: date("2025-01-07")}}, field: "to"},
+		} {
+			_, err := api.AssignmentStats(ctx, bad.filter)
+			var apiErr *apiclient.APIError
+			if !errors.Is(err, apiclient.ErrValidation) || !errors.As(err, &apiErr) ||
+				len(apiErr.Details) != 1 || apiErr.Details[0].Field != bad.field {
+				t.Fatalf("%+v: expected validation error on %s, got %v", bad.filter, bad.field, err)
+			}
+		}
+	})
+
+	t.Run("pairs", func(t *testing.T) {
+		// в команде из двух человек ревьювер однозначен: p1 дважды ревьюит p2, p2 один раз — p1
+		pairsTeam := "pairs_" + suffix
+		p1, p2 := "p1_"+suffix, "p2_"+suffix
+		if _, err := api.CreateTeam(ctx, apiclient.CreateTeamRequest{
+			TeamName: pairsTeam,
+			Members: []apiclient.TeamMember{
+				{UserID: p1, Username: "P1", IsActive: true},
+				{UserID: p2, Username: "P2", IsActive: true},
+			},
+		}); err != nil {
+			t.Fatalf("create team: %v", err)
+		}
+		for i, authorID := range []string{p2, p2, p1} {
+			if _, err := api.CreatePullRequest(ctx, apiclient.CreatePullRequestRequest{
+				PullRequestID: "pr-pairs-" + strconv.Itoa(i) + "-" + suffix, PullRequestName: "Pairs", AuthorID: authorID,
+			}); err != nil {
+				t.Fatalf("create PR %d: %v", i, err)
+			}
+		}
+
+		top := 1
+		pairs, err := api.ReviewPairs(ctx, apiclient.PairsFilter{Team: pairsTeam, Top: &top})
+		if err != nil {
+			t.Fatalf("review pairs: %v", err)
+		}
+		want := apiclient.ReviewPairs{
+			Authors:   []string{p1, p2},
+			Reviewers: []string{p1, p2},
+			Matrix:    [][]int64{{0, 1}, {2, 0}},
+			TopPairs:  []apiclient.ReviewPair{{AuthorID: p2, ReviewerID: p1, Assignments: 2}},
+		}
+		if !reflect.DeepEqual(pairs, want) {
+			t.Fatalf("review pairs: got %+v, want %+v", pairs, want)
+		}
+
+		wantCSV := "author \\ reviewer," + p1 + "," + p2 + "\n" +
+			p1 + ",0,1\n" +
+			p2 + ",2,0\n"
+		var buf strings.Builder
+		if err := api.ReviewPairsCSV(ctx, apiclient.PairsFilter{Team: pairsTeam}, &buf); err != nil {
+			t.Fatalf("review pairs CSV: %v", err)
+		}
+		if buf.String() != wantCSV {
+			t.Fatalf("format=csv: got %q, want %q", buf.String(), wantCSV)
+		}
+
+		// тот же CSV по Accept, без format
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/api/v1/stats/pairs?team="+pairsTeam, nil)
+		req.Header.Set("Accept", "text/csv")
+		resp, err := bearerClient(server, e2eAdminToken).Do(req)
+		if err != nil {
+			t.Fatalf("review pairs with Accept: text/csv: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/csv") ||
+			string(body) != wantCSV {
+			t.Fatalf("Accept: text/csv: %d %q %q", resp.StatusCode, resp.Header.Get("Content-Type"), body)
+		}
+	})
+}
+
+func TestE2E_Export(t *testing.T) {
+	ctx := context.Background()
+
+	db := newTestDB(t, ctx)
+	env := newTestAPI(t, db, testAPIOptions{})
+	server, api := env.Server, env.Client
+	client := bearerClient(server, e2eAdminToken)
+
+	// три PR на двух ревьюверов: 3 строки PR, 6 назначений, 2 строки статистики; первый PR смержен
+	suffix := time.Now().Format("150405.000000")
+	team := "export_" + suffix
+	author := "exa_" + suffix
+	_, err := api.CreateTeam(ctx, apiclient.CreateTeamRequest{
+		TeamName: team,
+		Members: []apiclient.TeamMember{
+			{UserID: author, Username: "Author", IsActive: true},
+			{UserID: "ex1_" + suffix, Username: "R1", IsActive: true},
+			{UserID: "ex2_" + suffix, Username: "R2", IsActive: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("create team: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		prID := "pr-export-" + strconv.Itoa(i) + "-" + suffix
+		if _, err := api.CreatePullRequest(ctx, apiclient.CreatePullRequestRequest{
+			PullRequestID: prID, PullRequestName: "Export, \"quoted\"", AuthorID: author,
+		}); err != nil {
+			t.Fatalf("create %s: %v", prID, err)
+		}
+	}
+	if _, err := api.MergePullRequest(ctx, "pr-export-0-"+suffix); err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+
+	get := func(path, accept string) (*http.Response, []byte) {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("read %s: %v", path, err)
+		}
+		return resp, body
+	}
+
+	cases := []struct {
+		name        string
+		path        string
+		accept      string
+		contentType string
+		filename    string
+		rows        int
+		// columns первая строка CSV; для NDJSON — поле, которое должно быть в каждом объекте
+		columns []string
+	}{
+		{
+			name:        "pull requests CSV by default",
+			path:        "/api/v1/exports/pull-requests?team=" + team,
+			contentType: "text/csv; charset=utf-8",
+			filename:    "pull_requests.csv",
+			rows:        3,
+			columns: []string{"pull_request_id", "pull_request_name", "author_id", "status",
+				"assigned_reviewers", "created_at", "merged_at", "closed_at"},
+		},
+		{
+			name:        "pull requests NDJSON by Accept",
+			path:        "/api/v1/exports/pull-requests?team=" + team,
+			accept:      "application/x-ndjson",
+			contentType: "application/x-ndjson",
+			filename:    "pull_requests.ndjson",
+			rows:        3,
+			columns:     []string{"pull_request_id"},
+		},
+		{
+			// format важнее Accept
+			name:        "assignments CSV by format",
+			path:        "/api/v1/exports/assignments?team=" + team + "&format=csv",
+			accept:      "application/x-ndjson",
+			contentType: "text/csv; charset=utf-8",
+			filename:    "assignments.csv",
+			rows:        6,
+			columns: []string{"pull_request_id", "pull_request_name", "author_id", "status",
+				"reviewer_id", "reviewer_team", "assigned_at", "verdict", "verdict_at"},
+		},
+		{
+			name:        "merged assignments NDJSON by format",
+			path:        "/api/v1/exports/assignments?team=" + team + "&status=MERGED&format=jsonl",
+			contentType: "application/x-ndjson",
+			filename:    "assignments.ndjson",
+			rows:        2,
+			columns:     []string{"reviewer_id"},
+		},
+		{
+			name:        "stats on legacy path",
+			path:        "/export/stats?team=" + team,
+			contentType: "text/csv; charset=utf-8",
+			filename:    "assignment_stats.csv",
+			rows:        2,
+			columns:     []string{"user_id", "period", "assignments"},
+		},
+		{
+			// пустая выгрузка — всё равно 200 и заголовок CSV
+			name:        "empty",
+			path:        "/api/v1/exports/pull-requests?team=" + team + "&status=CLOSED",
+			contentType: "text/csv; charset=utf-8",
+			filename:    "pull_requests.csv",
+			rows:        0,
+			columns: []string{"pull_request_id", "pull_request_name", "author_id", "status",
+				"assigned_reviewers", "created_at", "merged_at", "closed_at"},
+		},
+	}
+	for _, c := range cases {
+		resp, body := get(c.path, c.accept)
+		if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != c.contentType ||
+			resp.Header.Get("Content-Disposition") != `attachment; filename="`+c.filename+`"` {
+			t.Fatalf("%s: %d %q %q", c.name, resp.StatusCode, resp.Header.Get("Content-Type"), resp.Header.Get("Content-Disposition"))
+		}
+		// выгрузка идёт потоком: длина заранее неизвестна
+		if resp.ContentLength != -1 || !slices.Contains(resp.TransferEncoding, "chunked") {
+			t.Fatalf("%s: expected chunked response, got Content-Length %d, Transfer-Encoding %v", c.name, resp.ContentLength, resp.TransferEncoding)
+		}
+
+		if c.contentType == "application/x-ndjson" {
+			lines := strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+			if len(lines) != c.rows {
+				t.Fatalf("%s: expected %d lines, got %d: %s", c.name, c.rows, len(lines), body)
+			}
+			for _, line := range lines {
+				var row map[string]any
+				if err := json.Unmarshal([]byte(line), &row); err != nil || row[c.columns[0]] == nil {
+					t.Fatalf("%s: bad NDJSON line %q: %v", c.name, line, err)
+				}
+			}
+			continue
+		}
+
+		records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+		if err != nil {
+			t.Fatalf("%s: parse CSV: %v\n%s", c.name, err, body)
+		}
+		if len(records) != c.rows+1 || !slices.Equal(records[0], c.columns) {
+			t.Fatalf("%s: expected header %v and %d rows, got %q", c.name, c.columns, c.rows, records)
+		}
+		for _, record := range records[1:] {
+			if len(record) != len(c.columns) {
+				t.Fatalf("%s: row %v has %d columns, want %d", c.name, record, len(record), len(c.columns))
+			}
+		}
+	}
+
+	if resp, body := get("/api/v1/exports/pull-requests?format=xml", ""); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("format=xml: expected 400, got %d %s", resp.StatusCode, body)
+	}
+
+	// клиент читает NDJSON построчно
+	var prs []string
+	err = api.ExportPullRequests(ctx, apiclient.ExportFilter{Team: team}, func(pr apiclient.PullRequest) error {
+		prs = append(prs, pr.PullRequestID)
+		return nil
+	})
+	if err != nil || len(prs) != 3 {
+		t.Fatalf("client export of pull requests: %v, err %v", prs, err)
+	}
+	var merged []apiclient.AssignmentExport
+	err = api.ExportAssignments(ctx, apiclient.ExportFilter{Team: team, Status: apiclient.PRStatusMerged}, func(a apiclient.AssignmentExport) error {
+		merged = append(merged, a)
+		return nil
+	})
+	if err != nil || len(merged) != 2 || merged[0].PullRequestID != "pr-export-0-"+suffix || merged[0].ReviewerTeam != team {
+		t.Fatalf("client export of merged assignments: %+v, err %v", merged, err)
+	}
+}
+
+func TestE2E_Health(t *testing.T) {
+	ctx := context.Background()
+
+	db := newTestDB(t, ctx)
+	version, err := migrations.LatestVersion()
+	if err != nil {
+		t.Fatalf("failed to read migrations: %v", err)
+	}
+	repos := app.NewRepositories(db)
+	healthSvc := service.NewHealthService(repos.Schema, version)
+
+	mux := http.NewServeMux()
+	apihttp.NewHealthHandler(healthSvc).Register(mux)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	status := func(path string) int {
+		t.Helper()
+		resp, err := server.Client().Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("%s request failed: %v", path, err)
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if got := status("/healthz"); got != http.StatusOK {
+		t.Fatalf("healthz: expected 200, got %d", got)
+	}
+	if got := status("/readyz"); got != http.StatusOK {
+		t.Fatalf("readyz: expected 200, got %d", got)
+	}
+	if got := status("/version"); got != http.StatusOK {
+		t.Fatalf("version: expected 200, got %d", got)
+	}
+
+	// бинарь, собранный под следующую миграцию, на этой схеме не готов
+	ahead := httptest.NewServer(func() http.Handler {
+		m := http.NewServeMux()
+		apihttp.NewHealthHandler(service.NewHealthService(repos.Schema, version+1)).Register(m)
+		return m
+	}())
+	defer ahead.Close()
+	resp, err := ahead.Client().Get(ahead.URL + "/readyz")
+	if err != nil {
+		t.Fatalf("readyz request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("readyz with newer binary: expected 503, got %d", resp.StatusCode)
+	}
+
+	healthSvc.Drain()
+	if got := status("/readyz"); got != http.StatusServiceUnavailable {
+		t.Fatalf("readyz while draining: expected 503, got %d", got)
+	}
+	if got := status("/healthz"); got != http.StatusOK {
+		t.Fatalf("healthz while draining: expected 200, got %d", got)
+	}
 }
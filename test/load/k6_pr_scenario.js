@@ -22,6 +22,8 @@ export const options = {
 };
 
 const BASE_URL = __ENV.BASE_URL || 'http://localhost:8080';
+// admin-токен: без него API отвечает 401
+const AUTH = { Authorization: `Bearer ${__ENV.API_TOKEN || ''}` };
 
 function randomInt(min, max) {
     return Math.floor(Math.random() * (max - min + 1)) + min;
@@ -52,7 +54,7 @@ export function setup() {
                 members: members,
             }),
             {
-                headers: { 'Content-Type': 'application/json' },
+                headers: { 'Content-Type': 'application/json', ...AUTH },
                 tags: { name: 'team_add' },
             }
         );
@@ -84,7 +86,7 @@ export default function (data) {
             author_id: authorID,
         }),
         {
-            headers: { 'Content-Type': 'application/json' },
+            headers: { 'Content-Type': 'application/json', ...AUTH },
             tags: { name: 'pr_create' },
         }
     );
@@ -109,7 +111,7 @@ export default function (data) {
                 old_user_id: oldReviewerID,
             }),
             {
-                headers: { 'Content-Type': 'application/json' },
+                headers: { 'Content-Type': 'application/json', ...AUTH },
                 tags: { name: 'pr_reassign' },
             }
         );
@@ -126,6 +128,7 @@ export default function (data) {
     // 3) периодически дергаем статистику
     if (__ITER % 5 === 0) {
         const statsRes = http.get(`${BASE_URL}/stats/assignments`, {
+            headers: AUTH,
             tags: { name: 'stats_assignments' },
         });
 